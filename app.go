@@ -8,10 +8,14 @@ import (
 	"fmt"
 	"net/http"
 	"os"
-	"os/signal"
+	"sync"
 	"time"
 
+	"github.com/katalabut/fast-app/clock"
+	"github.com/katalabut/fast-app/di"
 	"github.com/katalabut/fast-app/health"
+	"github.com/katalabut/fast-app/health/notify"
+	"github.com/katalabut/fast-app/health/strategies"
 	"github.com/katalabut/fast-app/logger"
 	"github.com/katalabut/fast-app/service"
 	"github.com/pkg/errors"
@@ -26,9 +30,12 @@ const (
 	exitCodeWatchdog       = 1
 
 	defaultShutdownTimeout = time.Second * 5
-	watchdogTimeout        = defaultShutdownTimeout + time.Second*5
+	watchdogGracePeriod    = time.Second * 5
 )
 
+// errFatalLogged is the cause of the shutdown started by a fatal entry.
+var errFatalLogged = errors.New("fatal error logged")
+
 // App represents the main application instance that manages services,
 // health checks, and graceful shutdown.
 type App struct {
@@ -36,14 +43,27 @@ type App struct {
 	logger *zap.SugaredLogger
 
 	opts                 options
-	runners              []Runner
+	runners              []*Runner
+	hooks                hooks
+	ctx                  context.Context
+	stop                 context.CancelCauseFunc
 	healthManager        *health.Manager
 	observabilityService *service.ObservabilityService
-}
+	notifier             *notify.Notifier
+
+	container  *di.Container
+	provideErr error
+	configErr  error
+	events     *EventBus
+	configs    configState
 
-// Runner wraps a service for execution within the application.
-type Runner struct {
-	service Service
+	// startedAt is the time Start was called, set before the metrics are registered.
+	startedAt time.Time
+
+	mu       sync.RWMutex
+	running  context.Context
+	exitCode int
+	exited   bool
 }
 
 // Service defines the interface that all services must implement.
@@ -76,8 +96,12 @@ func New(config Config, opts ...Option) *App {
 		stopAllOnErr:    true,
 		shutdownTimeout: defaultShutdownTimeout,
 
-		ctx: context.Background(),
-		mux: http.NewServeMux(),
+		ctx:   context.Background(),
+		mux:   http.NewServeMux(),
+		exit:  os.Exit,
+		clock: clock.Real(),
+
+		shutdownSignals: defaultShutdownSignals,
 	}
 
 	for _, o := range opts {
@@ -86,35 +110,152 @@ func New(config Config, opts ...Option) *App {
 
 	// Initialize logger with configuration
 	loggerConfig := logger.Config{
-		AppName:    config.Logger.AppName,
-		Level:      config.Logger.Level,
-		DevMode:    config.Logger.DevMode,
-		MessageKey: config.Logger.MessageKey,
-		LevelKey:   config.Logger.LevelKey,
-		TimeKey:    config.Logger.TimeKey,
+		AppName:     config.Logger.AppName,
+		Level:       config.Logger.Level,
+		Levels:      config.Logger.Levels,
+		DevMode:     config.Logger.DevMode,
+		DevPretty:   config.Logger.DevPretty,
+		MessageKey:  config.Logger.MessageKey,
+		LevelKey:    config.Logger.LevelKey,
+		TimeKey:     config.Logger.TimeKey,
+		Preset:      config.Logger.Preset,
+		GCPProject:  config.Logger.GCPProject,
+		DedupWindow: config.Logger.DedupWindow,
+		Sentry: logger.SentryConfig{
+			DSN:         config.Logger.Sentry.DSN.Reveal(),
+			Environment: config.Logger.Sentry.Environment,
+			SampleRate:  config.Logger.Sentry.SampleRate,
+		},
+		Audit: logger.AuditConfig{
+			Output: config.Logger.Audit.Output,
+		},
+	}
+	if loggerConfig.Sentry.Environment == "" {
+		loggerConfig.Sentry.Environment = config.Profile
 	}
 
-	lg, err := logger.InitLogger(loggerConfig, op.version)
-	if err != nil {
-		panic(errors.Wrap(err, "failed to init logger"))
+	lg := op.logger
+	if lg != nil {
+		logger.SetLogger(lg)
+	} else {
+		var err error
+		lg, err = logger.InitLogger(loggerConfig, op.version)
+		if err != nil {
+			panic(errors.Wrap(err, "failed to init logger"))
+		}
 	}
 
 	// Initialize health manager
-	healthManager := health.NewManager(health.ManagerConfig{
-		CacheTTL: config.Observability.Health.CacheTTL,
-		Strategy: &health.AllHealthyStrategy{},
-	})
+	var configErr error
+	healthManager := op.healthManager
+	if healthManager == nil {
+		// The all_healthy strategy is the manager default, which switches to
+		// weighted once checks are registered with an importance.
+		strategy := op.healthStrategy
+		name := config.Observability.Health.Strategy
+		if strategy == nil && name != "" && name != strategies.AllHealthy {
+			var err error
+			strategy, err = strategies.New(name, config.Observability.Health.Weights)
+			if err != nil {
+				// Reported by Start, a typo must not silently change readiness.
+				configErr = errors.Wrap(err, "invalid health strategy")
+				strategy = nil
+			}
+		}
+
+		redact := op.healthRedactor
+		if redact == nil {
+			redact = health.RedactCredentials
+		}
+
+		healthManager = health.NewManager(health.ManagerConfig{
+			CacheTTL: config.Observability.Health.CacheTTL,
+			Strategy: strategy,
+			Clock:    op.clock,
+			Redact:   redact,
+
+			StaleWhileRevalidate: config.Observability.Health.StaleWhileRevalidate,
+			HistorySize:          config.Observability.Health.HistorySize,
+			PushStaleAfter:       config.Observability.Health.PushStaleAfter,
+			MaxConcurrentChecks:  config.Observability.Health.MaxConcurrentChecks,
+			CheckTimeout:         config.Observability.Health.Timeout,
+		})
+	}
 
 	// Initialize observability service
 	observabilityService := service.NewObservabilityService(config.Observability, healthManager)
+	if op.metricsGatherer != nil {
+		observabilityService.SetGatherer(op.metricsGatherer)
+	}
 
-	return &App{
+	ctx, stop := context.WithCancelCause(op.ctx)
+
+	app := &App{
 		config:               config,
 		logger:               lg,
 		opts:                 op,
+		ctx:                  ctx,
+		stop:                 stop,
 		healthManager:        healthManager,
 		observabilityService: observabilityService,
+		container:            di.New(),
+		configErr:            configErr,
+		events:               newEventBus(lg),
+	}
+
+	healthManager.OnReadinessChange(func(ready bool) {
+		app.events.Publish(Event{Type: EventReadinessChanged, Time: op.clock.Now(), Ready: ready})
+	})
+	healthManager.OnOverallStatusChange(func(from, to health.HealthStatus) {
+		app.events.Publish(Event{Type: EventHealthChanged, Time: op.clock.Now(), Status: to, PreviousStatus: from})
+	})
+
+	if op.configCurrent != nil {
+		app.configs.set(op.configCurrent())
+	}
+
+	if op.gracefulFatal {
+		logger.SetFatalHandler(app.fatal)
 	}
+
+	if !op.manualReadiness {
+		healthManager.AddReadinessCondition(app.servicesReady)
+	}
+
+	if config.Observability.Health.Notifications.Enabled {
+		notifier, err := notify.New(config.Observability.Health.Notifications, notify.Options{
+			App:   config.Logger.AppName,
+			Clock: op.clock,
+		})
+		if err != nil {
+			lg.Errorw("Health notifications disabled", "error", err)
+		} else {
+			notifier.Attach(healthManager)
+			app.notifier = notifier
+		}
+	}
+
+	observabilityService.Handle(infoPath, http.HandlerFunc(app.handleInfo))
+	if config.Observability.Debug.Enabled {
+		observabilityService.HandleDebug(config.Observability.Debug.PathPrefix+servicesPath, http.HandlerFunc(app.handleServices))
+		// Restarts are only available with an admin token
+		if config.Observability.Health.AdminToken.IsSet() {
+			observabilityService.HandleDebug(
+				"POST "+config.Observability.Debug.PathPrefix+servicesPath+"/{name}/restart",
+				observabilityService.RequireAdmin(http.HandlerFunc(app.handleRestart)),
+			)
+		}
+		// The configuration dump is only available with an admin token, so a
+		// credential that is not declared as a secret cannot leak through it
+		if op.explainConfig != nil && config.Observability.Health.AdminToken.IsSet() {
+			observabilityService.HandleDebug(
+				config.Observability.Debug.PathPrefix+configPath,
+				observabilityService.RequireAdmin(http.HandlerFunc(app.handleConfig)),
+			)
+		}
+	}
+
+	return app
 }
 
 // Start begins the application lifecycle, starting all registered services
@@ -127,18 +268,44 @@ func New(config Config, opts ...Option) *App {
 //   - Service startup with panic recovery
 //   - Graceful shutdown coordination
 //   - Watchdog timer for forced shutdown
+//
+// By default the process exits when the application stops. If a custom exit
+// function is set with WithExitFunc or WithNoExit, Start returns after calling it,
+// also when the watchdog fires, and the exit code is available from ExitCode.
 func (a *App) Start() {
 	var (
 		config = a.config
 		lg     = a.logger
 	)
 
-	ctx, cancel := signal.NotifyContext(a.opts.ctx, os.Interrupt)
+	ctx, cancel := a.notifyShutdown(a.ctx)
 	defer cancel()
 
 	defer func() { _ = lg.Sync() }()
 
-	lg.Info("Starting")
+	startFields := []interface{}{"config_hash", a.configHash()}
+	if a.config.Profile != "" {
+		startFields = append(startFields, "profile", a.config.Profile)
+	}
+	lg.Infow("Starting", startFields...)
+
+	if a.configErr != nil {
+		lg.Errorw("Invalid configuration", zap.Error(a.configErr))
+		a.exit(exitCodeApplicationErr)
+		return
+	}
+
+	if a.provideErr != nil {
+		lg.Errorw("Invalid dependency injection setup", zap.Error(a.provideErr))
+		a.exit(exitCodeApplicationErr)
+		return
+	}
+
+	if err := a.validateDependencies(); err != nil {
+		lg.Errorw("Invalid service dependencies", zap.Error(err))
+		a.exit(exitCodeApplicationErr)
+		return
+	}
 
 	{
 		// Automatically setting GOMAXPROCS.
@@ -152,49 +319,87 @@ func (a *App) Start() {
 		}
 	}
 
+	a.startedAt = a.opts.clock.Now()
+	unregisterMetrics := a.registerMetrics()
+	defer unregisterMetrics()
+
+	stopMetricsExport, exportErr := a.startMetricsExport(ctx)
+	if exportErr != nil {
+		lg.Errorw("Failed to start metrics export", zap.Error(exportErr))
+		a.exit(exitCodeApplicationErr)
+		return
+	}
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), a.opts.shutdownTimeout)
+		defer cancel()
+		if err := stopMetricsExport(shutdownCtx); err != nil {
+			lg.Warnw("Failed to stop metrics export", zap.Error(err))
+		}
+	}()
+
+	if err := a.runHooks(ctx, stageBeforeStart, a.hooks.beforeStart); err != nil {
+		lg.Errorw("Failed", zap.Error(err))
+		a.exit(exitCodeApplicationErr)
+		return
+	}
+
 	g, ctx := errgroup.WithContext(ctx)
+	a.setRunning(ctx)
+
+	if a.opts.configLoader != nil {
+		a.notifyReload(ctx)
+	}
+	if a.opts.configWatch != nil {
+		stopWatch := a.opts.configWatch(func() {
+			_ = a.ReloadConfig(ctx)
+		})
+		defer stopWatch()
+	}
+	if !a.opts.noDiagnostics {
+		a.notifyDiagnostics(ctx)
+	}
 
 	// Start observability server (includes health checks, metrics, and debug endpoints)
 	if a.config.Observability.Enabled {
-		g.Go(a.GracefulShutdown(ctx, a.observabilityService.Shutdown))
 		g.Go(func() error {
 			return a.observabilityService.Run(ctx)
 		})
 	}
 
+	// Readiness is flipped and traffic drained first, then services are stopped
+	// one by one in reverse order, followed by the observability server.
+	g.Go(func() error {
+		<-ctx.Done()
+		a.drain()
+		return a.GracefulShutdown(ctx, a.shutdown)()
+	})
+	g.Go(func() error {
+		return a.waitAllStarted(ctx)
+	})
+
 	for _, run := range a.runners {
 		run := run
+		if run.startupDeadline > 0 {
+			g.Go(func() error {
+				return a.watchStartup(ctx, run)
+			})
+		}
+
+		runCtx := run.prepare(ctx)
 
-		g.Go(a.GracefulShutdown(ctx, run.service.Shutdown))
 		g.Go(
-			func() (rerr error) {
-				defer func() {
-					// Recovering panic to log it and return error.
-					if ec := recover(); ec != nil {
-						lg.Errorw(
-							"Panic",
-							zap.String("panic", fmt.Sprintf("%v", ec)),
-							zap.StackSkip("stack", 1),
-						)
-						rerr = fmt.Errorf("shutting down (panic): %v", ec)
-
-						// Also shutting down all services on error.
-						if a.opts.stopAllOnErr {
-							cancel()
-						}
+			func() error {
+				for {
+					if err := a.runService(ctx, runCtx, run, cancel); err != nil {
+						return err
 					}
-				}()
 
-				if err := run.service.Run(ctx); err != nil {
-					if errors.Is(err, ctx.Err()) {
-						// Parent context got cancelled, error is expected.
-						lg.Debug("Graceful shutdown")
+					var ok bool
+					if runCtx, ok = run.restart(ctx); !ok {
 						return nil
 					}
-					return err
+					lg.Infow("Restarting service", "service", run.name)
 				}
-
-				return nil
 			},
 		)
 	}
@@ -208,30 +413,124 @@ func (a *App) Start() {
 	// 	)
 	// }
 
-	go func() {
-		// Guaranteed way to kill application.
-		// Helps if f is stuck, e.g. deadlock during shutdown.
-		<-ctx.Done()
+	done := make(chan struct{})
+	forced := make(chan struct{})
 
-		// Context is canceled, giving application time to shut down gracefully.
+	if !a.opts.watchdogDisabled {
+		go a.watchdog(ctx, done, forced)
+	}
 
-		lg.Info("Waiting for application shutdown")
-		time.Sleep(watchdogTimeout)
+	wait := make(chan error, 1)
+	go func() {
+		wait <- g.Wait()
+	}()
 
-		// Application is not shutting down gracefully, kill it.
-		// This code should not be executed if f is already returned.
+	var err error
+	select {
+	case err = <-wait:
+	case <-forced:
+		// The exit function returned instead of terminating the process,
+		// services that are still running are abandoned.
+		return
+	}
+	close(done)
 
-		lg.Warn("Graceful shutdown watchdog triggered: forcing shutdown")
-		os.Exit(exitCodeWatchdog)
-	}()
+	if hookErr := a.runAfterShutdownHooks(); hookErr != nil && err == nil {
+		err = hookErr
+	}
 
-	if err := g.Wait(); err != nil {
+	if cause := context.Cause(a.ctx); err == nil && errors.Is(cause, errFatalLogged) {
+		err = cause
+	}
+
+	if err != nil {
 		lg.Errorw("Failed", zap.Error(err))
-		os.Exit(exitCodeApplicationErr)
+		a.exit(exitCodeApplicationErr)
+		return
 	}
 
 	lg.Info("Application stopped")
-	os.Exit(exitCodeOk)
+	a.exit(exitCodeOk)
+}
+
+// runService runs a single service until its Run method returns. If a service
+// panics, the panic is recovered and, unless disabled, the application is stopped.
+func (a *App) runService(ctx, runCtx context.Context, run *Runner, stopAll context.CancelFunc) (rerr error) {
+	lg := a.logger
+
+	defer func() {
+		run.markFinished(rerr)
+		if run.hasStarted() {
+			a.publishServiceEvent(EventServiceStopped, run)
+		}
+	}()
+
+	defer func() {
+		// Recovering panic to log it and return error.
+		if ec := recover(); ec != nil {
+			lg.Errorw(
+				"Panic",
+				zap.String("panic", fmt.Sprintf("%v", ec)),
+				zap.StackSkip("stack", 1),
+			)
+			rerr = fmt.Errorf("shutting down (panic): %v", ec)
+
+			// Also shutting down all services on error.
+			if a.opts.stopAllOnErr {
+				stopAll()
+			}
+		}
+	}()
+
+	if err := a.waitForDependencies(ctx, run); err != nil {
+		// Application is shutting down before dependencies became ready.
+		return nil
+	}
+
+	run.markStarted()
+	lg.Debugw("Starting service", "service", run.name)
+	a.publishServiceEvent(EventServiceStarted, run)
+
+	if err := run.service.Run(runCtx); err != nil {
+		if errors.Is(err, runCtx.Err()) {
+			// Parent context got cancelled, error is expected.
+			lg.Debug("Graceful shutdown")
+			return nil
+		}
+		return err
+	}
+
+	switch {
+	case runCtx.Err() != nil:
+	case run.job:
+		lg.Infow("Job completed", "service", run.name)
+	default:
+		lg.Warnw("Service stopped before shutdown", "service", run.name)
+	}
+
+	return nil
+}
+
+// Stop initiates a graceful shutdown of the application from application code,
+// e.g. when a fatal business condition is detected. The reason is logged.
+// Stop does not wait for the shutdown to complete; Start returns (or the process exits)
+// once all services have stopped. Calling Stop before Start makes Start shut down immediately.
+func (a *App) Stop(reason string) {
+	if a.ctx.Err() != nil {
+		return
+	}
+
+	a.logger.Infow("Stop requested", "reason", reason)
+	a.stop(errors.New(reason))
+}
+
+// fatal stops the application when a fatal entry is logged, see WithGracefulFatal.
+func (a *App) fatal(message string) {
+	if a.runningContext() == nil {
+		a.exit(exitCodeApplicationErr)
+		return
+	}
+	a.stop(errors.Wrap(errFatalLogged, message))
 }
 
 // GracefulShutdown creates a shutdown function that waits for the context to be cancelled
@@ -255,33 +554,151 @@ func (a *App) GracefulShutdown(ctx context.Context, f ...func(context.Context) e
 	}
 }
 
+// watchdog forcefully terminates the application if it does not stop
+// within the watchdog timeout after ctx is cancelled. forced is closed
+// if the exit function returns after the watchdog fired.
+func (a *App) watchdog(ctx context.Context, done <-chan struct{}, forced chan<- struct{}) {
+	lg := a.logger
+
+	// Guaranteed way to kill application.
+	// Helps if f is stuck, e.g. deadlock during shutdown.
+	select {
+	case <-ctx.Done():
+	case <-done:
+		return
+	}
+
+	// Context is canceled, giving application time to shut down gracefully.
+
+	timeout := a.watchdogTimeout()
+	lg.Infow("Waiting for application shutdown", "reason", context.Cause(ctx), "watchdog_timeout", timeout)
+	select {
+	case <-a.opts.clock.After(timeout):
+	case <-done:
+		return
+	}
+
+	// Application is not shutting down gracefully, kill it.
+	// This code should not be executed if f is already returned.
+
+	lg.Warn("Graceful shutdown watchdog triggered: forcing shutdown")
+	if a.opts.onWatchdog != nil {
+		a.opts.onWatchdog()
+	}
+	a.exit(exitCodeWatchdog)
+	close(forced)
+}
+
+// exit records the exit code and calls the exit function.
+// Only the first exit code is recorded.
+func (a *App) exit(code int) {
+	a.mu.Lock()
+	if !a.exited {
+		a.exited = true
+		a.exitCode = code
+	}
+	a.mu.Unlock()
+
+	a.opts.exit(code)
+}
+
+// ExitCode returns the exit code of the application and whether it has exited.
+// It is useful when the application is embedded with WithNoExit or WithExitFunc.
+func (a *App) ExitCode() (code int, exited bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.exitCode, a.exited
+}
+
+// watchdogTimeout returns the configured watchdog timeout, which defaults
+// to the drain delay plus the shutdown timeout plus a grace period.
+func (a *App) watchdogTimeout() time.Duration {
+	if a.opts.watchdogTimeout > 0 {
+		return a.opts.watchdogTimeout
+	}
+
+	return a.config.Shutdown.DrainDelay + a.opts.shutdownTimeout + watchdogGracePeriod
+}
+
+// drain flips the application readiness to false and waits for the configured
+// drain delay, so that load balancers stop sending traffic before services are shut down.
+func (a *App) drain() {
+	a.SetReady(false)
+
+	delay := a.config.Shutdown.DrainDelay
+	if delay <= 0 {
+		return
+	}
+
+	a.logger.Infow("Draining traffic before shutdown", "drain_delay", delay)
+	<-a.opts.clock.After(delay)
+}
+
+// runAfterShutdownHooks runs the after shutdown hooks bounded by the shutdown timeout.
+func (a *App) runAfterShutdownHooks() error {
+	ctx, cancel := context.WithTimeout(context.Background(), a.opts.shutdownTimeout)
+	defer cancel()
+
+	return a.runHooks(ctx, stageAfterShutdown, a.hooks.afterShutdown)
+}
+
 // Add registers a service with the application. The service will be started
 // when Start() is called and will be gracefully shut down on application termination.
 //
 // If the service implements health.HealthProvider, its health checks will be
 // automatically registered with the health management system.
 //
+// The service name is taken from WithName, the Namer interface or derived
+// from the service type. Service options also declare dependencies on other
+// services, which are started first, and set a startup deadline.
+// Every service exports its state, restart count, uptime and last error time as metrics.
+//
 // Example:
 //
 //	app.Add(&MyService{})
-//	app.Add(service.NewDefaultDebugService(cfg.DebugServer))
-func (a *App) Add(svc Service) *App {
-	a.runners = append(
-		a.runners, Runner{
-			service: svc,
-		},
-	)
+//	app.Add(db, fastapp.WithName("database"))
+//	app.Add(api, fastapp.DependsOn("database"))
+func (a *App) Add(svc Service, opts ...ServiceOption) *App {
+	a.add(svc, svc, opts)
+	return a
+}
+
+// add registers a service. The default name and health checks are taken from src,
+// which is the value provided by the caller before any wrapping.
+func (a *App) add(svc Service, src interface{}, opts []ServiceOption) *Runner {
+	var so serviceOptions
+	for _, o := range opts {
+		o.apply(&so)
+	}
+
+	if so.phase == 0 {
+		so.phase = PhaseServing
+	}
+
+	if so.name == "" {
+		if n, ok := src.(Namer); ok {
+			so.name = n.Name()
+		} else {
+			so.name = a.uniqueServiceName(defaultServiceName(src))
+		}
+	}
+
+	r := newRunner(svc, so, a.opts.clock)
+	a.runners = append(a.runners, r)
 
 	// Check if service provides health checks
-	if healthProvider, ok := svc.(health.HealthProvider); ok {
+	if healthProvider, ok := src.(health.HealthProvider); ok {
 		healthChecks := healthProvider.HealthChecks()
 		a.healthManager.RegisterCheckers(healthChecks)
+		for _, c := range healthChecks {
+			r.healthChecks = append(r.healthChecks, c.Name())
+		}
 		logger.Debug(context.Background(), "Registered health checks from service",
-			"service_type", fmt.Sprintf("%T", svc),
+			"service_type", fmt.Sprintf("%T", src),
 			"checks_count", len(healthChecks))
 	}
 
-	return a
+	return r
 }
 
 // WithHealthChecks adds global health checks to the application
@@ -292,12 +709,70 @@ func (a *App) WithHealthChecks(checkers ...health.HealthChecker) *App {
 	return a
 }
 
-// SetReady sets the application readiness state
+// ObservabilityAddr returns the address the observability server is listening on,
+// or an empty string if the server has not started yet. It is useful when the
+// configured port is 0 and the operating system picks an ephemeral port.
+func (a *App) ObservabilityAddr() string {
+	return a.observabilityService.Addr()
+}
+
+// HealthManager returns the health manager used by the application.
+func (a *App) HealthManager() *health.Manager {
+	return a.healthManager
+}
+
+// Gate is a named readiness gate, see App.ReadinessGate.
+type Gate = health.Gate
+
+// HealthSnapshot is the latest known health state of an application.
+type HealthSnapshot = health.Snapshot
+
+// Health returns the latest aggregated health status, per-check results and
+// readiness without running any check, so application code, e.g. a middleware
+// shedding load while degraded, can consume it without calling the health endpoints.
+func (a *App) Health() HealthSnapshot {
+	return a.healthManager.Snapshot()
+}
+
+// SetReady sets the application readiness state. Unless WithManualReadiness is used,
+// the application is only ready when, in addition, every service implementing
+// health.ReadinessController or having a readiness gate reports ready,
+// so calling SetReady(true) is not required.
 func (a *App) SetReady(ready bool) {
 	a.healthManager.SetReady(ready)
 }
 
+// ReadinessGate returns the named readiness gate, registering it closed on the
+// first call. The application is not ready until every gate is opened with
+// Gate.Open, and the readiness endpoint lists the pending gates, so readiness
+// can wait for e.g. a cache warm-up without calling SetReady.
+func (a *App) ReadinessGate(name string) *Gate {
+	return a.healthManager.ReadinessGate(name)
+}
+
+// SetMaintenance enables or disables maintenance mode. While enabled, the
+// readiness probe returns 503 with a "maintenance" status and the message,
+// so traffic is drained, while liveness stays healthy and the process is not
+// restarted.
+func (a *App) SetMaintenance(enabled bool, message string) {
+	a.healthManager.SetMaintenance(enabled, message)
+}
+
 // IsReady returns the application readiness state
 func (a *App) IsReady() bool {
 	return a.healthManager.IsReady()
 }
+
+// servicesReady reports whether every service that controls its readiness is running and ready.
+func (a *App) servicesReady() bool {
+	for _, r := range a.runners {
+		if _, ok := r.service.(health.ReadinessController); !ok && r.readinessGate == nil {
+			continue
+		}
+		if r.status().state != StateRunning || !r.isReady() {
+			return false
+		}
+	}
+
+	return true
+}
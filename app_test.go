@@ -0,0 +1,526 @@
+package fastapp
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/katalabut/fast-app/clock"
+	"github.com/katalabut/fast-app/health"
+	"github.com/katalabut/fast-app/health/healthtest"
+	"github.com/katalabut/fast-app/health/strategies"
+	"github.com/katalabut/fast-app/logger"
+)
+
+func TestHealthOptions(t *testing.T) {
+	t.Run("WithHealthManager", func(t *testing.T) {
+		manager := health.NewManager(health.ManagerConfig{})
+		app := New(Config{}, WithHealthManager(manager))
+
+		if app.HealthManager() != manager {
+			t.Error("Expected injected health manager to be used")
+		}
+	})
+
+	t.Run("WithHealthStrategy", func(t *testing.T) {
+		app := New(Config{}, WithHealthStrategy(&strategies.MajorityHealthyStrategy{}))
+		app.WithHealthChecks(
+			healthtest.AlwaysHealthy("a"),
+			healthtest.AlwaysHealthy("b"),
+			healthtest.AlwaysUnhealthy("c", "down"),
+		)
+
+		status := app.HealthManager().GetOverallStatus(context.Background())
+		if status != health.StatusHealthy {
+			t.Errorf("Expected %s with majority strategy, got %s", health.StatusHealthy, status)
+		}
+	})
+
+	t.Run("StrategyFromConfig", func(t *testing.T) {
+		var cfg Config
+		cfg.Observability.Health.Strategy = strategies.Weighted
+		cfg.Observability.Health.Weights = map[string]string{"cache": "optional"}
+
+		app := New(cfg)
+		app.WithHealthChecks(
+			healthtest.AlwaysHealthy("db"),
+			healthtest.AlwaysUnhealthy("cache", "down"),
+		)
+
+		status := app.HealthManager().GetOverallStatus(context.Background())
+		if status != health.StatusHealthy {
+			t.Errorf("Expected %s with weighted strategy, got %s", health.StatusHealthy, status)
+		}
+	})
+
+	t.Run("InvalidStrategyFailsStart", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		exitCode := make(chan int, 2)
+
+		var cfg Config
+		cfg.Observability.Health.Strategy = "all_healty"
+		app := New(cfg, WithContext(ctx), WithExitFunc(func(code int) { exitCode <- code }))
+		app.Add(newTestService())
+		app.Start()
+
+		if code := <-exitCode; code != exitCodeApplicationErr {
+			t.Errorf("Expected exit code %d, got %d", exitCodeApplicationErr, code)
+		}
+	})
+}
+
+func TestReadiness(t *testing.T) {
+	t.Run("DerivedFromServices", func(t *testing.T) {
+		app, cancel, exitCode := newTestApp()
+		svc := newTestService()
+		// Services without readiness control do not affect readiness.
+		app.Add(svc).Add(newRestartableService())
+
+		if app.IsReady() {
+			t.Error("Expected application not to be ready before services started")
+		}
+
+		stop := startTestApp(t, app, cancel, exitCode)
+		<-svc.started
+		if app.IsReady() {
+			t.Error("Expected application not to be ready before service is ready")
+		}
+
+		svc.SetReady(true)
+		if !app.IsReady() {
+			t.Error("Expected application to be ready once service is ready")
+		}
+
+		app.SetReady(false)
+		if app.IsReady() {
+			t.Error("Expected SetReady(false) to take precedence")
+		}
+
+		if code := stop(); code != exitCodeOk {
+			t.Errorf("Expected exit code %d, got %d", exitCodeOk, code)
+		}
+	})
+
+	t.Run("Manual", func(t *testing.T) {
+		app, _, _ := newTestApp(WithManualReadiness())
+		app.Add(newTestService())
+
+		if !app.IsReady() {
+			t.Error("Expected application to be ready with manual readiness")
+		}
+	})
+
+	t.Run("Gates", func(t *testing.T) {
+		app, _, _ := newTestApp(WithManualReadiness())
+		gate := app.ReadinessGate("cache")
+
+		if app.IsReady() {
+			t.Error("Expected application not to be ready with a closed gate")
+		}
+		if pending := app.HealthManager().PendingGates(); len(pending) != 1 || pending[0] != "cache" {
+			t.Errorf("Expected pending gates [cache], got %v", pending)
+		}
+
+		gate.Open()
+		if !app.IsReady() {
+			t.Error("Expected application to be ready once the gate is open")
+		}
+	})
+}
+
+type testService struct {
+	mu      sync.Mutex
+	ready   bool
+	started chan struct{}
+	runErr  error
+}
+
+func newTestService() *testService {
+	return &testService{started: make(chan struct{})}
+}
+
+func (s *testService) Run(ctx context.Context) error {
+	close(s.started)
+	if s.runErr != nil {
+		return s.runErr
+	}
+	<-ctx.Done()
+	return nil
+}
+
+func (s *testService) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+func (s *testService) SetReady(ready bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ready = ready
+}
+
+func (s *testService) IsReady() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.ready
+}
+
+// startTestApp runs the application in the background and returns a function
+// that stops it and returns the exit code.
+func startTestApp(t *testing.T, app *App, cancel context.CancelFunc, exitCode chan int) func() int {
+	t.Helper()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		app.Start()
+	}()
+
+	return func() int {
+		cancel()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("Application did not stop")
+		}
+		return <-exitCode
+	}
+}
+
+func newTestApp(opts ...Option) (*App, context.CancelFunc, chan int) {
+	ctx, cancel := context.WithCancel(context.Background())
+	exitCode := make(chan int, 2)
+
+	opts = append(opts,
+		WithContext(ctx),
+		WithExitFunc(func(code int) { exitCode <- code }),
+	)
+
+	return New(Config{}, opts...), cancel, exitCode
+}
+
+func TestDependencies(t *testing.T) {
+	t.Run("StartsAfterDependencyReady", func(t *testing.T) {
+		app, cancel, exitCode := newTestApp()
+		db := newTestService()
+		api := newTestService()
+
+		app.Add(api, DependsOn("database"))
+		app.Add(db, WithName("database"))
+		stop := startTestApp(t, app, cancel, exitCode)
+
+		<-db.started
+		select {
+		case <-api.started:
+			t.Fatal("Expected api not to start before database is ready")
+		case <-time.After(3 * readinessPollInterval):
+		}
+
+		db.SetReady(true)
+		select {
+		case <-api.started:
+		case <-time.After(time.Second):
+			t.Fatal("Expected api to start once database is ready")
+		}
+
+		if code := stop(); code != exitCodeOk {
+			t.Errorf("Expected exit code %d, got %d", exitCodeOk, code)
+		}
+	})
+
+	t.Run("UnknownDependency", func(t *testing.T) {
+		app, cancel, exitCode := newTestApp()
+		defer cancel()
+
+		app.Add(newTestService(), DependsOn("missing"))
+		app.Start()
+
+		if code := <-exitCode; code != exitCodeApplicationErr {
+			t.Errorf("Expected exit code %d, got %d", exitCodeApplicationErr, code)
+		}
+	})
+
+	t.Run("Cycle", func(t *testing.T) {
+		app, _, _ := newTestApp()
+		app.Add(newTestService(), WithName("a"), DependsOn("b"))
+		app.Add(newTestService(), WithName("b"), DependsOn("a"))
+
+		if err := app.validateDependencies(); err == nil {
+			t.Error("Expected dependency cycle to be detected")
+		}
+	})
+
+	t.Run("DefaultNamesAreUnique", func(t *testing.T) {
+		app, _, _ := newTestApp()
+		app.Add(newTestService())
+		app.Add(newTestService())
+
+		if app.runners[0].Name() != "testService" || app.runners[1].Name() != "testService-2" {
+			t.Errorf("Unexpected names %q and %q", app.runners[0].Name(), app.runners[1].Name())
+		}
+	})
+}
+
+func TestHooks(t *testing.T) {
+	t.Run("Order", func(t *testing.T) {
+		app, cancel, exitCode := newTestApp()
+
+		var (
+			mu     sync.Mutex
+			events []string
+		)
+		record := func(event string) Hook {
+			return func(ctx context.Context) error {
+				mu.Lock()
+				defer mu.Unlock()
+				events = append(events, event)
+				return nil
+			}
+		}
+
+		svc := newTestService()
+		afterStart := make(chan struct{})
+		app.Add(svc).
+			OnBeforeStart(record("before_start")).
+			OnAfterStart(record("after_start")).
+			OnAfterStart(func(ctx context.Context) error { close(afterStart); return nil }).
+			OnBeforeShutdown(record("before_shutdown")).
+			OnAfterShutdown(record("after_shutdown"))
+
+		stop := startTestApp(t, app, cancel, exitCode)
+		<-afterStart
+
+		if code := stop(); code != exitCodeOk {
+			t.Errorf("Expected exit code %d, got %d", exitCodeOk, code)
+		}
+
+		want := []string{"before_start", "after_start", "before_shutdown", "after_shutdown"}
+		mu.Lock()
+		defer mu.Unlock()
+		if len(events) != len(want) {
+			t.Fatalf("Expected events %v, got %v", want, events)
+		}
+		for i := range want {
+			if events[i] != want[i] {
+				t.Errorf("Expected event %d to be %s, got %s", i, want[i], events[i])
+			}
+		}
+	})
+
+	t.Run("BeforeStartError", func(t *testing.T) {
+		app, cancel, exitCode := newTestApp()
+		defer cancel()
+
+		svc := newTestService()
+		app.Add(svc).OnBeforeStart(func(ctx context.Context) error {
+			return errors.New("warmup failed")
+		})
+		app.Start()
+
+		if code := <-exitCode; code != exitCodeApplicationErr {
+			t.Errorf("Expected exit code %d, got %d", exitCodeApplicationErr, code)
+		}
+		select {
+		case <-svc.started:
+			t.Error("Expected service not to start")
+		default:
+		}
+	})
+}
+
+func TestStop(t *testing.T) {
+	t.Run("StopsRunningApp", func(t *testing.T) {
+		app, cancel, exitCode := newTestApp()
+		defer cancel()
+
+		svc := newTestService()
+		app.Add(svc)
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			app.Start()
+		}()
+
+		<-svc.started
+		app.Stop("fatal business condition")
+
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("Expected application to stop")
+		}
+		if code := <-exitCode; code != exitCodeOk {
+			t.Errorf("Expected exit code %d, got %d", exitCodeOk, code)
+		}
+	})
+
+	t.Run("StopBeforeStart", func(t *testing.T) {
+		app, cancel, exitCode := newTestApp()
+		defer cancel()
+
+		app.Add(newTestService())
+		app.Stop("not needed")
+		app.Start()
+
+		if code := <-exitCode; code != exitCodeOk {
+			t.Errorf("Expected exit code %d, got %d", exitCodeOk, code)
+		}
+	})
+}
+
+type fatalService struct {
+	after chan struct{}
+}
+
+func (s *fatalService) Run(ctx context.Context) error {
+	logger.Fatal(ctx, "connection lost")
+	close(s.after)
+	return nil
+}
+
+func (s *fatalService) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+func TestGracefulFatal(t *testing.T) {
+	defer logger.SetFatalHandler(nil)
+
+	app, cancel, exitCode := newTestApp(WithGracefulFatal())
+	defer cancel()
+
+	hooked := make(chan struct{})
+	logger.OnFatal(func() { close(hooked) })
+
+	svc := &fatalService{after: make(chan struct{})}
+	other := newTestService()
+	app.Add(svc).Add(other)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		app.Start()
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected application to stop")
+	}
+	if code := <-exitCode; code != exitCodeApplicationErr {
+		t.Errorf("Expected exit code %d, got %d", exitCodeApplicationErr, code)
+	}
+
+	select {
+	case <-hooked:
+	default:
+		t.Error("Expected fatal hook to run")
+	}
+	select {
+	case <-svc.after:
+		t.Error("Expected the code after Fatal not to run")
+	default:
+	}
+}
+
+type stuckService struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (s *stuckService) Run(ctx context.Context) error {
+	close(s.started)
+	<-s.release
+	return nil
+}
+
+func (s *stuckService) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+func TestWatchdog(t *testing.T) {
+	t.Run("FiresAfterTimeout", func(t *testing.T) {
+		fake := clock.NewFake(time.Now())
+		fired := make(chan struct{})
+		app, cancel, exitCode := newTestApp(
+			WithClock(fake),
+			WithWatchdogTimeout(time.Minute),
+			WithWatchdogHook(func() { close(fired) }),
+		)
+		defer cancel()
+
+		svc := &stuckService{started: make(chan struct{}), release: make(chan struct{})}
+		app.Add(svc)
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			app.Start()
+		}()
+
+		<-svc.started
+		app.Stop("test")
+
+		fake.BlockUntil(1)
+		fake.Advance(time.Minute)
+
+		select {
+		case <-fired:
+		case <-time.After(5 * time.Second):
+			t.Fatal("Expected watchdog hook to be called")
+		}
+		if code := <-exitCode; code != exitCodeWatchdog {
+			t.Errorf("Expected exit code %d, got %d", exitCodeWatchdog, code)
+		}
+
+		close(svc.release)
+		<-done
+	})
+
+	t.Run("StartReturnsWithoutExit", func(t *testing.T) {
+		fake := clock.NewFake(time.Now())
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		app := New(Config{}, WithContext(ctx), WithClock(fake), WithWatchdogTimeout(time.Minute), WithNoExit())
+
+		svc := &stuckService{started: make(chan struct{}), release: make(chan struct{})}
+		defer close(svc.release)
+		app.Add(svc)
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			app.Start()
+		}()
+
+		<-svc.started
+		if _, exited := app.ExitCode(); exited {
+			t.Error("Expected application not to have exited yet")
+		}
+		app.Stop("test")
+
+		fake.BlockUntil(1)
+		fake.Advance(time.Minute)
+
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("Expected Start to return after the watchdog fired")
+		}
+
+		code, exited := app.ExitCode()
+		if !exited || code != exitCodeWatchdog {
+			t.Errorf("Expected exit code %d, got %d (exited %v)", exitCodeWatchdog, code, exited)
+		}
+	})
+
+	t.Run("DefaultTimeout", func(t *testing.T) {
+		app, _, _ := newTestApp(WithShutdownTimeout(time.Minute))
+		if got := app.watchdogTimeout(); got != time.Minute+watchdogGracePeriod {
+			t.Errorf("Expected watchdog timeout %v, got %v", time.Minute+watchdogGracePeriod, got)
+		}
+	})
+}
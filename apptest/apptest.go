@@ -0,0 +1,309 @@
+// Package apptest provides an in-process harness for end-to-end testing of
+// FastApp applications. It starts an App on ephemeral observability ports,
+// captures its logs, intercepts os.Exit and tears everything down when the
+// test finishes.
+//
+// Example:
+//
+//	func TestService(t *testing.T) {
+//	    at := apptest.New(t, config.App{})
+//	    at.Add(NewMyService())
+//	    at.StartReady()
+//
+//	    resp, err := http.Get(at.ChecksURL())
+//	    ...
+//	}
+package apptest
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/creasty/defaults"
+	fastapp "github.com/katalabut/fast-app"
+	"github.com/katalabut/fast-app/config"
+	"github.com/katalabut/fast-app/logger"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+const (
+	// DefaultReadyTimeout is how long WaitUntilReady waits for the application
+	// to report readiness.
+	DefaultReadyTimeout = 10 * time.Second
+
+	// DefaultStopTimeout is how long Stop waits for the application to return
+	// after its context has been cancelled.
+	DefaultStopTimeout = 30 * time.Second
+
+	pollInterval = 10 * time.Millisecond
+)
+
+// App is a FastApp application running inside a test.
+type App struct {
+	tb     testing.TB
+	app    *fastapp.App
+	cfg    config.App
+	logs   *observer.ObservedLogs
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+
+	mu       sync.Mutex
+	exitCode int
+	exited   bool
+}
+
+// New creates an application for testing. Zero-valued configuration fields are
+// filled from their default tags, the observability server is forced to listen
+// on an ephemeral port, with every endpoint on it, and all logs are captured in
+// memory.
+// The application is stopped automatically when the test finishes.
+func New(tb testing.TB, cfg config.App, opts ...fastapp.Option) *App {
+	tb.Helper()
+
+	if err := defaults.Set(&cfg); err != nil {
+		tb.Fatalf("apptest: failed to set config defaults: %v", err)
+	}
+	cfg.Observability.Enabled = true
+	cfg.Observability.Port = 0
+	cfg.Observability.Health.Address = ""
+	cfg.Observability.Metrics.Address = ""
+	cfg.Observability.Debug.Address = ""
+
+	core, logs := observer.New(zapcore.DebugLevel)
+	prevLogger := logger.Logger()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	a := &App{
+		tb:     tb,
+		cfg:    cfg,
+		logs:   logs,
+		ctx:    ctx,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+
+	opts = append(opts,
+		fastapp.WithContext(ctx),
+		fastapp.WithLogger(zap.New(core).Sugar()),
+		fastapp.WithExitFunc(a.exit),
+	)
+	a.app = fastapp.New(cfg, opts...)
+
+	tb.Cleanup(func() {
+		a.Stop()
+		if tb.Failed() {
+			a.dumpLogs()
+		}
+		logger.SetLogger(prevLogger)
+	})
+
+	return a
+}
+
+// App returns the underlying application, e.g. to register health checks.
+func (a *App) App() *fastapp.App {
+	return a.app
+}
+
+// Config returns the effective configuration the application was created with.
+func (a *App) Config() config.App {
+	return a.cfg
+}
+
+// Add registers a service with the underlying application.
+func (a *App) Add(svc fastapp.Service, opts ...fastapp.ServiceOption) *App {
+	a.app.Add(svc, opts...)
+	return a
+}
+
+// AddJob registers a job with the underlying application.
+func (a *App) AddJob(job fastapp.Job, opts ...fastapp.ServiceOption) *App {
+	a.app.AddJob(job, opts...)
+	return a
+}
+
+// Start runs the application in the background and waits until the
+// observability server is listening.
+func (a *App) Start() *App {
+	a.tb.Helper()
+
+	a.startOnce.Do(func() {
+		go func() {
+			defer close(a.done)
+			a.app.Start()
+		}()
+	})
+
+	deadline := time.Now().Add(DefaultReadyTimeout)
+	for a.app.ObservabilityAddr() == "" {
+		select {
+		case <-a.done:
+			a.tb.Fatalf("apptest: application stopped before observability server started")
+		default:
+		}
+		if time.Now().After(deadline) {
+			a.tb.Fatalf("apptest: observability server did not start within %s", DefaultReadyTimeout)
+		}
+		time.Sleep(pollInterval)
+	}
+
+	return a
+}
+
+// StartReady starts the application and blocks until it is ready, see Start and WaitUntilReady.
+func (a *App) StartReady() *App {
+	a.tb.Helper()
+
+	a.Start()
+	a.WaitUntilReady(a.tb)
+	return a
+}
+
+// WaitUntilReady blocks until the readiness endpoint returns 200 OK.
+// The test fails if the application does not become ready within DefaultReadyTimeout.
+func (a *App) WaitUntilReady(tb testing.TB) {
+	tb.Helper()
+	a.WaitUntilReadyTimeout(tb, DefaultReadyTimeout)
+}
+
+// WaitUntilReadyTimeout is like WaitUntilReady but with a custom timeout.
+func (a *App) WaitUntilReadyTimeout(tb testing.TB, timeout time.Duration) {
+	tb.Helper()
+
+	client := &http.Client{Timeout: time.Second}
+	deadline := time.Now().Add(timeout)
+
+	for {
+		resp, err := client.Get(a.ReadyURL())
+		if err == nil {
+			_ = resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return
+			}
+		}
+
+		select {
+		case <-a.done:
+			tb.Fatalf("apptest: application stopped before becoming ready")
+		default:
+		}
+
+		if time.Now().After(deadline) {
+			tb.Fatalf("apptest: application did not become ready within %s", timeout)
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+// BaseURL returns the base URL of the observability server, e.g. http://127.0.0.1:41234.
+func (a *App) BaseURL() string {
+	addr := a.app.ObservabilityAddr()
+	if addr == "" {
+		a.tb.Fatalf("apptest: observability server is not running, call Start first")
+	}
+	return "http://" + addr
+}
+
+// URL returns the absolute URL of the given observability path.
+func (a *App) URL(path string) string {
+	return a.BaseURL() + path
+}
+
+// LiveURL returns the URL of the liveness endpoint.
+func (a *App) LiveURL() string {
+	return a.URL(a.cfg.Observability.Health.LivePath)
+}
+
+// ReadyURL returns the URL of the readiness endpoint.
+func (a *App) ReadyURL() string {
+	return a.URL(a.cfg.Observability.Health.ReadyPath)
+}
+
+// StartupURL returns the URL of the startup endpoint.
+func (a *App) StartupURL() string {
+	return a.URL(a.cfg.Observability.Health.StartupPath)
+}
+
+// ChecksURL returns the URL of the detailed health checks endpoint.
+func (a *App) ChecksURL() string {
+	return a.URL(a.cfg.Observability.Health.CheckPath)
+}
+
+// HistoryURL returns the URL of the health check history endpoint.
+func (a *App) HistoryURL() string {
+	return a.URL(a.cfg.Observability.Health.HistoryPath)
+}
+
+// MetricsURL returns the URL of the metrics endpoint.
+func (a *App) MetricsURL() string {
+	return a.URL(a.cfg.Observability.Metrics.Path)
+}
+
+// ServicesURL returns the URL of the service status endpoint.
+func (a *App) ServicesURL() string {
+	return a.URL(a.cfg.Observability.Debug.PathPrefix + "/services")
+}
+
+// Logs returns all log entries written by the application so far.
+func (a *App) Logs() *observer.ObservedLogs {
+	return a.logs
+}
+
+// Stop initiates a graceful shutdown and waits for Start to return.
+// It is called automatically at the end of the test.
+func (a *App) Stop() {
+	a.stopOnce.Do(func() {
+		a.app.Stop("apptest: stop requested")
+		defer a.cancel()
+
+		// Nothing to wait for if the application was never started.
+		a.startOnce.Do(func() { close(a.done) })
+
+		select {
+		case <-a.done:
+		case <-time.After(DefaultStopTimeout):
+			a.tb.Errorf("apptest: application did not stop within %s", DefaultStopTimeout)
+		}
+	})
+}
+
+// Done returns a channel that is closed when the application has stopped.
+func (a *App) Done() <-chan struct{} {
+	return a.done
+}
+
+// ExitCode returns the exit code the application requested and whether it has exited.
+func (a *App) ExitCode() (int, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.exitCode, a.exited
+}
+
+// exit records the first exit code requested by the application instead of
+// terminating the test binary.
+func (a *App) exit(code int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.exited {
+		return
+	}
+	a.exitCode = code
+	a.exited = true
+}
+
+// dumpLogs writes the captured logs to the test output.
+func (a *App) dumpLogs() {
+	for _, entry := range a.logs.All() {
+		a.tb.Logf("%s %s %s %v", entry.Time.Format(time.RFC3339Nano), entry.Level, entry.Message, entry.ContextMap())
+	}
+}
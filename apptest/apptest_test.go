@@ -0,0 +1,237 @@
+package apptest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	fastapp "github.com/katalabut/fast-app"
+	"github.com/katalabut/fast-app/config"
+	"github.com/katalabut/fast-app/health"
+)
+
+type jobFunc func(ctx context.Context) error
+
+func (f jobFunc) Run(ctx context.Context) error {
+	return f(ctx)
+}
+
+type blockingService struct{}
+
+func (s *blockingService) Run(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+func (s *blockingService) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+func (s *blockingService) HealthChecks() []health.HealthChecker {
+	return []health.HealthChecker{
+		health.NewCustomCheck("blocking", func(ctx context.Context) health.HealthResult {
+			return health.NewHealthyResult("ok")
+		}),
+	}
+}
+
+func TestApp(t *testing.T) {
+	t.Run("StartAndWaitUntilReady", func(t *testing.T) {
+		at := New(t, config.App{})
+		at.Add(&blockingService{})
+		at.Start()
+		at.WaitUntilReady(t)
+
+		resp, err := http.Get(at.ChecksURL())
+		if err != nil {
+			t.Fatalf("Failed to get checks: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("Expected status %d, got %d", http.StatusOK, resp.StatusCode)
+		}
+
+		var response map[string]interface{}
+		if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+
+		checks, ok := response["checks"].(map[string]interface{})
+		if !ok || checks["blocking"] == nil {
+			t.Errorf("Expected blocking check to be present, got %v", response["checks"])
+		}
+	})
+
+	t.Run("StopRecordsExitCode", func(t *testing.T) {
+		at := New(t, config.App{})
+		at.Add(&blockingService{})
+		at.Start()
+		at.Stop()
+
+		code, exited := at.ExitCode()
+		if !exited {
+			t.Fatal("Expected application to exit")
+		}
+		if code != 0 {
+			t.Errorf("Expected exit code 0, got %d", code)
+		}
+
+		if at.Logs().FilterMessage("Application stopped").Len() != 1 {
+			t.Error("Expected 'Application stopped' to be logged")
+		}
+	})
+
+	t.Run("MetricsURL", func(t *testing.T) {
+		at := New(t, config.App{})
+		at.Start()
+
+		resp, err := http.Get(at.MetricsURL())
+		if err != nil {
+			t.Fatalf("Failed to get metrics: %v", err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("Expected status %d, got %d", http.StatusOK, resp.StatusCode)
+		}
+	})
+
+	t.Run("StartReadyWithJob", func(t *testing.T) {
+		ran := make(chan struct{})
+		at := New(t, config.App{})
+		at.Add(&blockingService{},
+			fastapp.WithName("api"),
+			fastapp.DependsOn("migrate"),
+			fastapp.WithReadinessGate(func() bool { return true }),
+		)
+		at.AddJob(jobFunc(func(ctx context.Context) error {
+			close(ran)
+			return nil
+		}), fastapp.WithName("migrate"))
+		at.StartReady()
+
+		select {
+		case <-ran:
+		default:
+			t.Error("Expected job to run before the application became ready")
+		}
+
+		var names []string
+		for _, svc := range at.App().Services() {
+			names = append(names, svc.Name)
+		}
+		if len(names) != 2 || names[0] != "api" || names[1] != "migrate" {
+			t.Errorf("Expected services [api migrate], got %v", names)
+		}
+	})
+
+	t.Run("ServicesURL", func(t *testing.T) {
+		at := New(t, config.App{})
+		at.Start()
+
+		resp, err := http.Get(at.ServicesURL())
+		if err != nil {
+			t.Fatalf("Failed to get services: %v", err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("Expected status %d, got %d", http.StatusOK, resp.StatusCode)
+		}
+	})
+
+	t.Run("HealthOverride", func(t *testing.T) {
+		var cfg config.App
+		cfg.Observability.Health.AdminToken = "secret"
+		at := New(t, cfg)
+		at.Add(&blockingService{})
+		at.Start()
+		at.WaitUntilReady(t)
+
+		override := func(method, token, body string) int {
+			req, err := http.NewRequest(method, at.URL("/health/override"), strings.NewReader(body))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Authorization", "Bearer "+token)
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to %s override: %v", method, err)
+			}
+			resp.Body.Close()
+			return resp.StatusCode
+		}
+		status := func(url string) int {
+			resp, err := http.Get(url)
+			if err != nil {
+				t.Fatalf("Failed to get %s: %v", url, err)
+			}
+			resp.Body.Close()
+			return resp.StatusCode
+		}
+
+		body := `{"status": "unhealthy", "reason": "failover", "ttl": "1m"}`
+		if code := override(http.MethodPost, "wrong", body); code != http.StatusUnauthorized {
+			t.Errorf("Expected status %d without the admin token, got %d", http.StatusUnauthorized, code)
+		}
+		if code := override(http.MethodPost, "secret", body); code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d", http.StatusOK, code)
+		}
+
+		if code := status(at.ReadyURL()); code != http.StatusServiceUnavailable {
+			t.Errorf("Expected ready status %d while overridden, got %d", http.StatusServiceUnavailable, code)
+		}
+		if code := status(at.LiveURL()); code != http.StatusOK {
+			t.Errorf("Expected live status %d while overridden, got %d", http.StatusOK, code)
+		}
+
+		if code := override(http.MethodDelete, "secret", ""); code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d", http.StatusOK, code)
+		}
+		if code := status(at.ReadyURL()); code != http.StatusOK {
+			t.Errorf("Expected ready status %d after clearing the override, got %d", http.StatusOK, code)
+		}
+	})
+
+	t.Run("Maintenance", func(t *testing.T) {
+		at := New(t, config.App{})
+		at.Add(&blockingService{})
+		at.Start()
+		at.WaitUntilReady(t)
+
+		at.App().SetMaintenance(true, "database migration")
+
+		resp, err := http.Get(at.ReadyURL())
+		if err != nil {
+			t.Fatalf("Failed to get readiness: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusServiceUnavailable {
+			t.Errorf("Expected status %d, got %d", http.StatusServiceUnavailable, resp.StatusCode)
+		}
+
+		var response map[string]interface{}
+		if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if response["status"] != "maintenance" || response["message"] != "database migration" {
+			t.Errorf("Expected maintenance status and message, got %v", response)
+		}
+
+		live, err := http.Get(at.LiveURL())
+		if err != nil {
+			t.Fatalf("Failed to get liveness: %v", err)
+		}
+		live.Body.Close()
+		if live.StatusCode != http.StatusOK {
+			t.Errorf("Expected live status %d in maintenance, got %d", http.StatusOK, live.StatusCode)
+		}
+
+		at.App().SetMaintenance(false, "")
+		at.WaitUntilReady(t)
+	})
+}
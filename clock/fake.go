@@ -0,0 +1,149 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Fake is a Clock whose time only moves when Advance or Set is called.
+// Timers and tickers created from it fire when the fake time passes their deadline.
+// It is safe for concurrent use.
+type Fake struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []*fakeWaiter
+}
+
+type fakeWaiter struct {
+	until  time.Time
+	period time.Duration // non-zero for tickers
+	ch     chan time.Time
+}
+
+// NewFake creates a fake clock set to the given time.
+func NewFake(now time.Time) *Fake {
+	f := &Fake{now: now}
+	f.cond = sync.NewCond(&f.mu)
+	return f
+}
+
+// Now returns the current fake time.
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Since returns the fake time elapsed since t.
+func (f *Fake) Since(t time.Time) time.Duration {
+	return f.Now().Sub(t)
+}
+
+// After returns a channel that receives the fake time once it has advanced by d.
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	return f.addWaiter(d, 0).ch
+}
+
+// NewTicker returns a ticker that fires every time the fake time advances by d.
+func (f *Fake) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("clock: non-positive interval for NewTicker")
+	}
+	return &fakeTicker{clock: f, w: f.addWaiter(d, d)}
+}
+
+// Advance moves the fake time forward by d and fires all timers and tickers
+// whose deadline has passed.
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.setLocked(f.now.Add(d))
+}
+
+// Set sets the fake time to t and fires all timers and tickers whose deadline has passed.
+func (f *Fake) Set(t time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.setLocked(t)
+}
+
+// BlockUntil blocks until at least n timers or tickers are waiting on the clock.
+// It lets tests synchronize with goroutines before advancing time.
+func (f *Fake) BlockUntil(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for len(f.waiters) < n {
+		f.cond.Wait()
+	}
+}
+
+func (f *Fake) addWaiter(d, period time.Duration) *fakeWaiter {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	w := &fakeWaiter{
+		until:  f.now.Add(d),
+		period: period,
+		ch:     make(chan time.Time, 1),
+	}
+	if d <= 0 && period == 0 {
+		w.ch <- f.now
+		return w
+	}
+
+	f.waiters = append(f.waiters, w)
+	f.cond.Broadcast()
+	return w
+}
+
+func (f *Fake) removeWaiter(w *fakeWaiter) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for i, existing := range f.waiters {
+		if existing == w {
+			f.waiters = append(f.waiters[:i], f.waiters[i+1:]...)
+			break
+		}
+	}
+}
+
+func (f *Fake) setLocked(t time.Time) {
+	f.now = t
+
+	remaining := f.waiters[:0]
+	for _, w := range f.waiters {
+		if w.until.After(t) {
+			remaining = append(remaining, w)
+			continue
+		}
+
+		// Drop the tick if the receiver is not keeping up, like time.Ticker does.
+		select {
+		case w.ch <- t:
+		default:
+		}
+
+		if w.period > 0 {
+			for !w.until.After(t) {
+				w.until = w.until.Add(w.period)
+			}
+			remaining = append(remaining, w)
+		}
+	}
+	f.waiters = remaining
+}
+
+type fakeTicker struct {
+	clock *Fake
+	w     *fakeWaiter
+}
+
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.w.ch
+}
+
+func (t *fakeTicker) Stop() {
+	t.clock.removeWaiter(t.w)
+}
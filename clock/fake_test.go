@@ -0,0 +1,75 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFake(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("After", func(t *testing.T) {
+		c := NewFake(start)
+		ch := c.After(time.Second)
+
+		c.Advance(500 * time.Millisecond)
+		select {
+		case <-ch:
+			t.Fatal("Expected timer not to fire before deadline")
+		default:
+		}
+
+		c.Advance(500 * time.Millisecond)
+		select {
+		case got := <-ch:
+			if !got.Equal(start.Add(time.Second)) {
+				t.Errorf("Expected %v, got %v", start.Add(time.Second), got)
+			}
+		default:
+			t.Fatal("Expected timer to fire at deadline")
+		}
+	})
+
+	t.Run("Ticker", func(t *testing.T) {
+		c := NewFake(start)
+		ticker := c.NewTicker(time.Second)
+		defer ticker.Stop()
+
+		for i := 0; i < 3; i++ {
+			c.Advance(time.Second)
+			select {
+			case <-ticker.C():
+			default:
+				t.Fatalf("Expected tick %d", i+1)
+			}
+		}
+	})
+
+	t.Run("BlockUntil", func(t *testing.T) {
+		c := NewFake(start)
+		fired := make(chan struct{})
+
+		go func() {
+			<-c.After(time.Minute)
+			close(fired)
+		}()
+
+		c.BlockUntil(1)
+		c.Advance(time.Minute)
+
+		select {
+		case <-fired:
+		case <-time.After(time.Second):
+			t.Fatal("Expected waiter to be released")
+		}
+	})
+
+	t.Run("Since", func(t *testing.T) {
+		c := NewFake(start)
+		c.Advance(3 * time.Second)
+
+		if got := c.Since(start); got != 3*time.Second {
+			t.Errorf("Expected 3s, got %v", got)
+		}
+	})
+}
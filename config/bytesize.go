@@ -0,0 +1,108 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ByteSize is a size in bytes. It is configured as a number of bytes or with
+// a unit, e.g. "10MB" or "512KiB", in files, environment variables and
+// `default` tags. Units are case-insensitive; KB, MB, GB, TB and PB are
+// powers of 1000 and KiB, MiB, GiB, TiB and PiB (or Ki, Mi...) powers of 1024.
+type ByteSize int64
+
+// Byte sizes.
+const (
+	Byte ByteSize = 1
+
+	KB ByteSize = 1000 * Byte
+	MB ByteSize = 1000 * KB
+	GB ByteSize = 1000 * MB
+	TB ByteSize = 1000 * GB
+	PB ByteSize = 1000 * TB
+
+	KiB ByteSize = 1024 * Byte
+	MiB ByteSize = 1024 * KiB
+	GiB ByteSize = 1024 * MiB
+	TiB ByteSize = 1024 * GiB
+	PiB ByteSize = 1024 * TiB
+)
+
+var byteUnits = map[string]ByteSize{
+	"": Byte, "b": Byte,
+	"k": KB, "kb": KB, "m": MB, "mb": MB, "g": GB, "gb": GB, "t": TB, "tb": TB, "p": PB, "pb": PB,
+	"ki": KiB, "kib": KiB, "mi": MiB, "mib": MiB, "gi": GiB, "gib": GiB, "ti": TiB, "tib": TiB, "pi": PiB, "pib": PiB,
+}
+
+// ParseByteSize parses a size such as "10MB", "512KiB", "1.5 GiB" or "4096".
+func ParseByteSize(s string) (ByteSize, error) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i < 0 {
+		i = len(s)
+	}
+
+	number, unit := s[:i], strings.ToLower(strings.TrimSpace(s[i:]))
+	multiplier, ok := byteUnits[unit]
+	if number == "" || !ok {
+		return 0, fmt.Errorf("invalid byte size %q, expected e.g. 10MB or 512KiB", s)
+	}
+
+	if n, err := strconv.ParseInt(number, 10, 64); err == nil {
+		if n > math.MaxInt64/int64(multiplier) {
+			return 0, fmt.Errorf("byte size %q overflows", s)
+		}
+		return ByteSize(n) * multiplier, nil
+	}
+
+	f, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid byte size %q, expected e.g. 10MB or 512KiB", s)
+	}
+	size := f * float64(multiplier)
+	if size >= math.MaxInt64 {
+		return 0, fmt.Errorf("byte size %q overflows", s)
+	}
+	return ByteSize(size), nil
+}
+
+// Int64 returns the size in bytes.
+func (b ByteSize) Int64() int64 {
+	return int64(b)
+}
+
+// String returns the size with the largest unit dividing it, binary units
+// first, e.g. "10MiB", "10MB" or "1500B".
+func (b ByteSize) String() string {
+	for _, u := range []struct {
+		size ByteSize
+		name string
+	}{
+		{PiB, "PiB"}, {PB, "PB"}, {TiB, "TiB"}, {TB, "TB"}, {GiB, "GiB"}, {GB, "GB"},
+		{MiB, "MiB"}, {MB, "MB"}, {KiB, "KiB"}, {KB, "KB"},
+	} {
+		if b != 0 && b%u.size == 0 {
+			return strconv.FormatInt(int64(b/u.size), 10) + u.name
+		}
+	}
+	return strconv.FormatInt(int64(b), 10) + "B"
+}
+
+// MarshalText encodes the size as its String.
+func (b ByteSize) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+// UnmarshalText decodes a size with ParseByteSize.
+func (b *ByteSize) UnmarshalText(text []byte) error {
+	size, err := ParseByteSize(string(text))
+	if err != nil {
+		return err
+	}
+	*b = size
+	return nil
+}
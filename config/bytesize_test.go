@@ -0,0 +1,64 @@
+package config
+
+import "testing"
+
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected ByteSize
+	}{
+		{"4096", 4096},
+		{"512B", 512},
+		{"10MB", 10 * MB},
+		{"10mb", 10 * MB},
+		{"512KiB", 512 * KiB},
+		{"1.5 GiB", GiB + 512*MiB},
+		{"256Mi", 256 * MiB},
+		{"2 tb", 2 * TB},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseByteSize(tt.input)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("Expected %d, got %d", tt.expected, got)
+			}
+		})
+	}
+
+	for _, input := range []string{"", "MB", "10XB", "1.2.3MB", "-5MB", "99999999PiB"} {
+		t.Run("Invalid"+input, func(t *testing.T) {
+			if _, err := ParseByteSize(input); err == nil {
+				t.Errorf("Expected error for %q", input)
+			}
+		})
+	}
+}
+
+func TestByteSizeString(t *testing.T) {
+	tests := map[ByteSize]string{
+		0:             "0B",
+		1500:          "1500B",
+		10 * MiB:      "10MiB",
+		10 * MB:       "10MB",
+		3 * GiB:       "3GiB",
+		KiB + 1:       "1025B",
+		512 * KiB * 3: "1536KiB",
+	}
+	for size, expected := range tests {
+		if got := size.String(); got != expected {
+			t.Errorf("Expected %s, got %s", expected, got)
+		}
+	}
+
+	var size ByteSize
+	if err := size.UnmarshalText([]byte("10MiB")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	text, _ := size.MarshalText()
+	if string(text) != "10MiB" {
+		t.Errorf("Expected 10MiB, got %s", text)
+	}
+}
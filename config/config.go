@@ -9,6 +9,10 @@ import (
 // App holds the main configuration for a FastApp application.
 // It includes all subsystem configurations in a centralized location.
 type App struct {
+	// Profile is the active configuration profile, e.g. "prod", set by
+	// configloader.WithProfile
+	Profile string
+
 	// Logger configuration for structured logging
 	Logger Logger
 
@@ -17,6 +21,17 @@ type App struct {
 
 	// Observability contains configuration for metrics, health checks, and debugging
 	Observability Observability
+
+	// Shutdown contains configuration for the graceful shutdown
+	Shutdown Shutdown
+}
+
+// Shutdown contains configuration for the graceful shutdown.
+type Shutdown struct {
+	// DrainDelay is how long to wait after readiness has been flipped to false
+	// before services are shut down, so that load balancers stop sending traffic.
+	// Typically a few seconds longer than the readiness probe period on Kubernetes.
+	DrainDelay time.Duration `default:"0s"`
 }
 
 // Logger contains configuration for the structured logging system.
@@ -27,9 +42,17 @@ type Logger struct {
 	// Level sets the minimum log level (debug, info, warn, error, fatal)
 	Level string `default:"info"`
 
+	// Levels sets the log levels of components, the names of the loggers
+	// returned by logger.Named, e.g. {"kafka": "warn"}
+	Levels map[string]string
+
 	// DevMode enables development-friendly console output with colors
 	DevMode bool `default:"false"`
 
+	// DevPretty, with DevMode, prints each field on an aligned line, with maps
+	// and structs pretty-printed, shortened timestamps and clickable callers
+	DevPretty bool `default:"false"`
+
 	// MessageKey is the JSON key for the log message
 	MessageKey string `default:"message"`
 
@@ -38,6 +61,42 @@ type Logger struct {
 
 	// TimeKey is the JSON key for the timestamp
 	TimeKey string `default:"timestamp"`
+
+	// Preset maps the JSON keys and levels to those a log backend parses
+	// natively: "gcp", "aws" or "otel"; it overrides the keys above
+	Preset string
+
+	// GCPProject is the project of the trace names of the "gcp" preset,
+	// defaults to the GOOGLE_CLOUD_PROJECT environment variable
+	GCPProject string
+
+	// DedupWindow collapses identical messages logged again within it into
+	// a single "repeated N times" entry; 0 disables it
+	DedupWindow time.Duration `default:"0s"`
+
+	// Sentry forwards error and fatal log entries to Sentry
+	Sentry Sentry
+
+	// Audit contains configuration for the audit log, written by logger.Audit
+	Audit Audit
+}
+
+// Audit contains configuration for the audit log.
+type Audit struct {
+	// Output is where audit events are written: "stdout", "stderr" or a file path
+	Output string `default:"stdout"`
+}
+
+// Sentry contains configuration for error reporting to Sentry.
+type Sentry struct {
+	// DSN of the Sentry project; errors are only reported when it is set
+	DSN Secret
+
+	// Environment is reported with each error; defaults to the configuration profile
+	Environment string
+
+	// SampleRate is the fraction of errors reported, from 0 to 1; 0 reports all of them
+	SampleRate float64 `default:"1"`
 }
 
 // AutoMaxProcs contains configuration for automatic GOMAXPROCS setup.
@@ -58,6 +117,9 @@ type Observability struct {
 	// Port specifies the HTTP port for all observability endpoints
 	Port int `default:"9090"`
 
+	// TLS configuration for serving the endpoints over HTTPS
+	TLS TLS
+
 	// Metrics configuration for Prometheus metrics
 	Metrics Metrics
 
@@ -66,6 +128,36 @@ type Observability struct {
 
 	// Debug configuration for debugging and profiling endpoints
 	Debug Debug
+
+	// LogLevelPath is the URL path for reading and changing the log level at
+	// runtime. It is protected by Health.AdminToken, which is shared by all
+	// admin endpoints, and disabled when that token is empty
+	LogLevelPath string `default:"/admin/loglevel"`
+}
+
+// TLS contains configuration for serving HTTPS, with client certificates
+// verified for mutual TLS when a client CA is set.
+type TLS struct {
+	// CertFile is the path of the PEM encoded server certificate, enabling
+	// TLS along with KeyFile. Files are reloaded when they change, e.g. when
+	// a certificate is renewed
+	CertFile string
+
+	// KeyFile is the path of the PEM encoded private key of the certificate
+	KeyFile string
+
+	// ClientCAFile is the path of the PEM encoded CAs client certificates are
+	// verified with; clients must present one when it is set (mutual TLS),
+	// except on a Health.Address serving only the health endpoints, for probes
+	ClientCAFile string
+
+	// ReloadInterval is how often the files are checked for changes
+	ReloadInterval time.Duration `default:"1m"`
+}
+
+// Enabled reports whether TLS is configured, with any of the files set.
+func (t TLS) Enabled() bool {
+	return t.CertFile != "" || t.KeyFile != "" || t.ClientCAFile != ""
 }
 
 // Metrics contains configuration for Prometheus metrics.
@@ -75,6 +167,37 @@ type Metrics struct {
 
 	// Path is the URL path for metrics endpoint
 	Path string `default:"/metrics"`
+
+	// Address serves the metrics endpoint on its own listener instead of
+	// Port, e.g. ":9091"
+	Address string
+
+	// Exporter selects how metrics are exported: "prometheus" serves them at
+	// Path, "otlp" pushes them to an OpenTelemetry collector and "both" does both
+	Exporter string `default:"prometheus"`
+
+	// OTLP contains configuration for pushing metrics over OTLP
+	OTLP OTLPMetrics
+}
+
+// Metrics exporters.
+const (
+	MetricsExporterPrometheus = "prometheus"
+	MetricsExporterOTLP       = "otlp"
+	MetricsExporterBoth       = "both"
+)
+
+// OTLPMetrics contains configuration for pushing metrics over OTLP/HTTP.
+type OTLPMetrics struct {
+	// Endpoint is the URL metrics are pushed to, e.g. "http://otel-collector:4318/v1/metrics";
+	// the OTEL_EXPORTER_OTLP_ENDPOINT environment variable or localhost when empty
+	Endpoint string
+
+	// Headers are sent with each push, e.g. an API key
+	Headers map[string]Secret
+
+	// Interval is the time between pushes
+	Interval time.Duration `default:"30s"`
 }
 
 // Health contains configuration for health check endpoints.
@@ -82,20 +205,120 @@ type Health struct {
 	// Enabled determines if health check endpoints should be available
 	Enabled bool `default:"true"`
 
+	// Address serves the health check endpoints on their own listener
+	// instead of Port, e.g. ":8081" exposed to the kubelet only
+	Address string
+
 	// LivePath is the URL path for liveness probe endpoint
 	LivePath string `default:"/health/live"`
 
 	// ReadyPath is the URL path for readiness probe endpoint
 	ReadyPath string `default:"/health/ready"`
 
+	// StartupPath is the URL path for startup probe endpoint
+	StartupPath string `default:"/health/startup"`
+
 	// CheckPath is the URL path for detailed health check information
 	CheckPath string `default:"/health/checks"`
 
-	// Timeout is the maximum time to wait for health checks to complete
+	// Format is the response format of the detailed health check endpoint:
+	// default or health+json. Requests accepting application/health+json
+	// always receive the health+json format
+	Format string `default:"default"`
+
+	// CheckStatusCodes maps statuses (healthy, degraded, unhealthy) to the HTTP
+	// status code of the detailed health check endpoint, by default 200 for
+	// healthy and degraded and 503 for unhealthy, e.g. {"degraded": 218}
+	CheckStatusCodes map[string]int
+
+	// ReadyStatusCodes maps statuses (healthy, degraded, unhealthy, maintenance)
+	// to the HTTP status code of the readiness endpoint, by default 200 for
+	// healthy and 503 otherwise
+	ReadyStatusCodes map[string]int
+
+	// Terse limits the detailed health check endpoint to the status of each
+	// check, hiding messages and details unless requested with ?verbose=true
+	Terse bool `default:"false"`
+
+	// HistoryPath is the URL path for the recent results of every check
+	HistoryPath string `default:"/health/history"`
+
+	// OverridePath is the URL path for manually overriding health statuses,
+	// e.g. to drain traffic before a planned failover
+	OverridePath string `default:"/health/override"`
+
+	// AdminToken is the bearer token required by every admin endpoint of the
+	// observability server, not only the health ones: OverridePath,
+	// Observability.LogLevelPath and the service restart endpoint. They are
+	// all disabled when it is empty
+	AdminToken Secret
+
+	// HistorySize is the number of recent results kept per check
+	HistorySize int `default:"10"`
+
+	// Timeout is the maximum time to wait for health checks to complete, and
+	// bounds each run of a check registered without a timeout of its own
 	Timeout time.Duration `default:"30s"`
 
+	// MaxConcurrentChecks limits how many health checks run at the same time,
+	// zero for no limit
+	MaxConcurrentChecks int `default:"0"`
+
 	// CacheTTL is how long to cache health check results
 	CacheTTL time.Duration `default:"5s"`
+
+	// PushStaleAfter is how long a pushed health status is reported before
+	// the check is considered unhealthy
+	PushStaleAfter time.Duration `default:"1m"`
+
+	// Strategy aggregates the overall status: all_healthy, majority, weighted
+	// or score. With all_healthy, checks registered with an importance switch to weighted
+	Strategy string `default:"all_healthy"`
+
+	// Weights maps check names to their importance (critical, important or
+	// optional) for the weighted strategy, or to a number for the score strategy
+	Weights map[string]string
+
+	// StaleWhileRevalidate serves expired cached results while checks are
+	// refreshed in the background, so slow checks never block probes
+	StaleWhileRevalidate bool `default:"false"`
+
+	// Notifications configures webhooks notified when health statuses change
+	Notifications Notifications
+}
+
+// Notifications contains configuration for health status change notifications.
+type Notifications struct {
+	// Enabled determines if notifications should be sent
+	Enabled bool `default:"false"`
+
+	// MinSeverity is the least severe status (degraded or unhealthy) that is
+	// notified; transitions between less severe statuses are ignored
+	MinSeverity string `default:"degraded"`
+
+	// Debounce is how long a new status must persist before it is notified
+	Debounce time.Duration `default:"30s"`
+
+	// Timeout is the maximum time to wait for a webhook to respond
+	Timeout time.Duration `default:"10s"`
+
+	// Webhooks are the endpoints notified of status changes
+	Webhooks []Webhook
+}
+
+// Webhook describes an endpoint notified of health status changes.
+type Webhook struct {
+	// Type is the payload format: generic (when empty), slack or pagerduty
+	Type string
+
+	// URL is the endpoint the payload is posted to
+	URL string
+
+	// RoutingKey is the PagerDuty integration key
+	RoutingKey Secret
+
+	// Headers are added to every request, e.g. for authentication
+	Headers map[string]Secret
 }
 
 // Debug contains configuration for debugging and profiling endpoints.
@@ -105,4 +328,8 @@ type Debug struct {
 
 	// PathPrefix is the URL path prefix for debug endpoints
 	PathPrefix string `default:"/debug"`
+
+	// Address serves the debug endpoints on their own listener instead of
+	// Port, e.g. "127.0.0.1:6060" to keep profiling local
+	Address string
 }
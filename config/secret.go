@@ -0,0 +1,42 @@
+package config
+
+import "encoding/json"
+
+// masked replaces the value of a Secret when it is printed or encoded.
+const masked = "***"
+
+// Secret is a configuration string, such as a password or an API key, that is
+// never printed, logged or encoded: String, GoString, MarshalJSON and
+// MarshalYAML return "***", so logging a configuration struct cannot leak it.
+// Reveal returns the actual value. Secrets are loaded like plain strings.
+type Secret string
+
+// Reveal returns the secret value.
+func (s Secret) Reveal() string {
+	return string(s)
+}
+
+// IsSet reports whether the secret is not empty.
+func (s Secret) IsSet() bool {
+	return s != ""
+}
+
+// String returns "***". It is also used by the logger.
+func (s Secret) String() string {
+	return masked
+}
+
+// GoString returns "***" for the %#v verb.
+func (s Secret) GoString() string {
+	return masked
+}
+
+// MarshalJSON encodes the secret as "***".
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return json.Marshal(masked)
+}
+
+// MarshalYAML encodes the secret as "***".
+func (s Secret) MarshalYAML() (interface{}, error) {
+	return masked, nil
+}
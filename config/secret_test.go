@@ -0,0 +1,40 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSecret(t *testing.T) {
+	cfg := struct {
+		User     string
+		Password Secret
+	}{User: "app", Password: "hunter2"}
+
+	if got := cfg.Password.Reveal(); got != "hunter2" {
+		t.Errorf("Expected revealed value hunter2, got %q", got)
+	}
+
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	outputs := map[string]string{
+		"%v":   fmt.Sprintf("%v", cfg),
+		"%+v":  fmt.Sprintf("%+v", cfg),
+		"%#v":  fmt.Sprintf("%#v", cfg),
+		"%s":   fmt.Sprintf("%s", cfg.Password),
+		"json": string(data),
+	}
+	for format, out := range outputs {
+		if strings.Contains(out, "hunter2") {
+			t.Errorf("Expected %s output to mask the secret, got %s", format, out)
+		}
+		if !strings.Contains(out, "***") {
+			t.Errorf("Expected %s output to contain ***, got %s", format, out)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package fastapp
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/katalabut/fast-app/configloader"
+	"gopkg.in/yaml.v3"
+)
+
+// configPath is the path of the effective configuration endpoint, relative to the debug path prefix.
+const configPath = "/config"
+
+// WithConfigDump enables the effective configuration endpoint at /debug/config,
+// which loads the configuration with configloader.Explain and the given options
+// and renders it as JSON, or YAML with ?format=yaml, together with the source
+// of every key (env, file, default...). config.Secret values and fields tagged
+// `sensitive:"true"` are masked. The endpoint requires debug endpoints to be enabled
+// and is only served to requests carrying Health.AdminToken as a bearer token.
+//
+// Example:
+//
+//	app := fastapp.New(cfg.App, fastapp.WithConfigDump[AppConfig](configloader.WithFile("config.yaml")))
+func WithConfigDump[T any](opts ...configloader.Option) Option {
+	return optionFunc(
+		func(o *options) {
+			o.explainConfig = func() ([]configloader.Entry, error) {
+				return configloader.Explain[T](opts...)
+			}
+		},
+	)
+}
+
+// handleConfig handles effective configuration requests.
+func (a *App) handleConfig(w http.ResponseWriter, r *http.Request) {
+	entries, err := a.opts.explainConfig()
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	cfg := make(map[string]interface{})
+	sources := make(map[string]string, len(entries))
+	for _, e := range entries {
+		setKey(cfg, strings.Split(e.Key, "."), e.Value)
+		sources[e.Key] = e.Source
+	}
+
+	response := map[string]interface{}{
+		"config":    cfg,
+		"sources":   sources,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	}
+
+	if r.URL.Query().Get("format") == "yaml" {
+		w.Header().Set("Content-Type", "application/yaml")
+		w.WriteHeader(http.StatusOK)
+		yaml.NewEncoder(w).Encode(response)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// setKey sets a value in nested maps by its key path.
+func setKey(m map[string]interface{}, keys []string, value interface{}) {
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := m[key].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			m[key] = next
+		}
+		m = next
+	}
+	m[keys[len(keys)-1]] = value
+}
@@ -0,0 +1,124 @@
+package fastapp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/katalabut/fast-app/config"
+	"github.com/katalabut/fast-app/configloader"
+)
+
+type dumpedConfig struct {
+	App    config.App
+	APIKey config.Secret
+}
+
+func TestConfigDump(t *testing.T) {
+	app, _, _ := newTestApp(WithConfigDump[dumpedConfig](
+		configloader.WithoutEnv(),
+		configloader.WithValues("apikey", "hunter2", "app.logger.level", "debug"),
+	))
+
+	t.Run("JSON", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		app.handleConfig(rec, httptest.NewRequest(http.MethodGet, "/debug/config", nil))
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("Expected status %d, got %d", http.StatusOK, rec.Code)
+		}
+		if strings.Contains(rec.Body.String(), "hunter2") {
+			t.Errorf("Expected the secret to be masked, got %s", rec.Body.String())
+		}
+
+		var response struct {
+			Config struct {
+				APIKey string
+				App    struct {
+					Logger struct {
+						Level   string
+						AppName string
+					}
+				}
+			} `json:"config"`
+			Sources map[string]string `json:"sources"`
+		}
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+
+		if response.Config.APIKey != "***" {
+			t.Errorf("Expected masked API key, got %q", response.Config.APIKey)
+		}
+		if response.Config.App.Logger.Level != "debug" || response.Sources["App.Logger.Level"] != "map" {
+			t.Errorf("Expected level debug from map, got %q from %q",
+				response.Config.App.Logger.Level, response.Sources["App.Logger.Level"])
+		}
+		if response.Sources["App.Logger.AppName"] != configloader.SourceDefault {
+			t.Errorf("Expected app name from default, got %q", response.Sources["App.Logger.AppName"])
+		}
+	})
+
+	t.Run("YAML", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		app.handleConfig(rec, httptest.NewRequest(http.MethodGet, "/debug/config?format=yaml", nil))
+
+		if ct := rec.Header().Get("Content-Type"); ct != "application/yaml" {
+			t.Errorf("Expected YAML content type, got %q", ct)
+		}
+		if !strings.Contains(rec.Body.String(), "APIKey: '***'") {
+			t.Errorf("Expected masked API key in YAML, got %s", rec.Body.String())
+		}
+	})
+}
+
+func TestConfigDumpAuthorization(t *testing.T) {
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+	for token, want := range map[string]map[string]int{
+		"":       {"": http.StatusNotFound, "secret": http.StatusNotFound},
+		"secret": {"": http.StatusUnauthorized, "wrong": http.StatusUnauthorized, "secret": http.StatusOK},
+	} {
+		ctx, cancel := context.WithCancel(context.Background())
+		exitCode := make(chan int, 2)
+		app := New(
+			Config{Observability: config.Observability{
+				Enabled: true,
+				Debug:   config.Debug{Enabled: true, PathPrefix: "/debug"},
+				Health:  config.Health{AdminToken: config.Secret(token)},
+			}},
+			WithContext(ctx),
+			WithExitFunc(func(code int) { exitCode <- code }),
+			WithConfigDump[dumpedConfig](configloader.WithoutEnv()),
+		)
+		svc := newTestService()
+		app.Add(svc)
+		stop := startTestApp(t, app, cancel, exitCode)
+		<-svc.started
+
+		deadline := time.Now().Add(5 * time.Second)
+		for app.ObservabilityAddr() == "" && time.Now().Before(deadline) {
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		for bearer, status := range want {
+			req, _ := http.NewRequest(http.MethodGet, "http://"+app.ObservabilityAddr()+"/debug/config", nil)
+			if bearer != "" {
+				req.Header.Set("Authorization", "Bearer "+bearer)
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != status {
+				t.Errorf("Expected status %d with admin token %q and bearer %q, got %d", status, token, bearer, resp.StatusCode)
+			}
+		}
+
+		stop()
+	}
+}
@@ -19,6 +19,13 @@ package configloader
 //	    log.Fatal(err)
 //	}
 func New[T any](opts ...Option) (*T, error) {
+	cfg, _, err := load[T](opts)
+	return cfg, err
+}
+
+// load parses a configuration of type T from the environment and the sources
+// set by opts, returning the parser to inspect its sources.
+func load[T any](opts []Option) (*T, *Parser, error) {
 	var cfg T
 	ops := []Option{
 		WithEnv(""),
@@ -27,10 +34,10 @@ func New[T any](opts ...Option) (*T, error) {
 
 	parser, err := NewParser(ops...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	err = parser.Parse(&cfg)
 
-	return &cfg, err
+	return &cfg, parser, err
 }
@@ -0,0 +1,77 @@
+package configloader
+
+import (
+	"testing"
+	"time"
+)
+
+type testConfig struct {
+	Port    int           `default:"8080"`
+	Host    string        `default:"localhost"`
+	Timeout time.Duration `default:"5s"`
+	Nested  struct {
+		Name string `default:"nested"`
+	}
+}
+
+func TestWithMap(t *testing.T) {
+	t.Run("OverridesEnv", func(t *testing.T) {
+		t.Setenv("PORT", "9000")
+
+		cfg, err := New[testConfig](WithMap(map[string]interface{}{
+			"port": 7000,
+		}))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if cfg.Port != 7000 {
+			t.Errorf("Expected port 7000, got %d", cfg.Port)
+		}
+		if cfg.Host != "localhost" {
+			t.Errorf("Expected default host 'localhost', got '%s'", cfg.Host)
+		}
+	})
+
+	t.Run("NestedAndMerged", func(t *testing.T) {
+		cfg, err := New[testConfig](
+			WithoutEnv(),
+			WithMap(map[string]interface{}{
+				"nested": map[string]interface{}{"name": "custom"},
+			}),
+			WithValues("timeout", "1m"),
+		)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if cfg.Nested.Name != "custom" {
+			t.Errorf("Expected nested name 'custom', got '%s'", cfg.Nested.Name)
+		}
+		if cfg.Timeout != time.Minute {
+			t.Errorf("Expected timeout 1m, got %v", cfg.Timeout)
+		}
+	})
+
+	t.Run("OddValues", func(t *testing.T) {
+		if _, err := New[testConfig](WithValues("port")); err == nil {
+			t.Error("Expected error for odd number of values")
+		}
+	})
+
+	t.Run("Reset", func(t *testing.T) {
+		p, err := NewParser(WithValues("port", 1234))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		p.Reset()
+
+		var cfg testConfig
+		if err := p.Parse(&cfg); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if cfg.Port != 8080 {
+			t.Errorf("Expected default port 8080 after reset, got %d", cfg.Port)
+		}
+	})
+}
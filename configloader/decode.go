@@ -0,0 +1,69 @@
+package configloader
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// decodeErrors decodes every configured key on its own into the type of its
+// field, returning a *ValidationError listing each key that fails with its
+// value, the source it was set by and the expected type, or nil if no single
+// key is at fault.
+func (p *Parser) decodeErrors(cfg interface{}) error {
+	sources, err := p.keySources()
+	if err != nil {
+		return nil
+	}
+
+	verr := &ValidationError{}
+	walkLeaves(reflect.ValueOf(cfg), "", false, func(path string, v reflect.Value, _ reflect.StructField, masked bool) {
+		key := strings.ToLower(path)
+		if !p.viper.IsSet(key) {
+			return
+		}
+		value := p.viper.Get(key)
+
+		target := reflect.New(v.Type())
+		decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+			DecodeHook:       decodeHooks(),
+			WeaklyTypedInput: true,
+			Result:           target.Interface(),
+		})
+		if err == nil {
+			err = decoder.Decode(value)
+		}
+		if err == nil {
+			return
+		}
+
+		src := "unknown source"
+		for _, s := range sources {
+			if s.has(key) {
+				src = s.name
+				break
+			}
+		}
+
+		shown := "***"
+		if s, ok := value.(string); ok && !masked {
+			shown = strconv.Quote(s)
+		} else if !masked {
+			shown = fmt.Sprint(value)
+		}
+		msg := fmt.Sprintf("expected %s, got %s from %s", v.Type(), shown, src)
+		// Decode hooks explain why a value is invalid, e.g. a malformed time.
+		if reason, ok := strings.CutPrefix(err.Error(), "error decoding '': "); ok && !masked {
+			msg += ": " + reason
+		}
+		verr.Fields = append(verr.Fields, FieldError{Path: path, Message: msg})
+	})
+
+	if len(verr.Fields) == 0 {
+		return nil
+	}
+	return verr
+}
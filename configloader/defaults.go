@@ -0,0 +1,137 @@
+package configloader
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/creasty/defaults"
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+)
+
+// setDefaults fills the zero fields of cfg from their `default` tags. Slices
+// and maps take either JSON or a comma-separated list:
+//
+//	Tags    []string          `default:"[a,b,c]"`                // or `default:"a,b,c"`
+//	Headers map[string]string `default:"X-Api:v1,X-Client:app"` // or `default:"{\"X-Api\": \"v1\"}"`
+//
+// Elements are converted like configuration values, so e.g. a []time.Duration
+// accepts `default:"1s,5s"`.
+func setDefaults(cfg interface{}) error {
+	if err := setCollectionDefaults(reflect.ValueOf(cfg), ""); err != nil {
+		return err
+	}
+	if err := defaults.Set(cfg); err != nil {
+		return errors.Wrap(err, "failed to set defaults")
+	}
+	return nil
+}
+
+// setCollectionDefaults sets the zero slices and maps of a struct from their
+// `default` tags before defaults.Set, which only supports JSON and leaves
+// fields already set untouched.
+func setCollectionDefaults(v reflect.Value, path string) error {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if !isSection(v.Type()) {
+		return nil
+	}
+
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		f := t.Field(i)
+		field := v.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		fieldPath := joinPath(path, f.Name)
+
+		tag, ok := f.Tag.Lookup("default")
+		switch {
+		case field.Kind() == reflect.Struct || field.Kind() == reflect.Ptr:
+			if err := setCollectionDefaults(field, fieldPath); err != nil {
+				return err
+			}
+		case !ok || tag == "-" || !field.IsZero():
+		case field.Kind() == reflect.Slice || field.Kind() == reflect.Map:
+			if err := decodeDefault(field, tag); err != nil {
+				return errors.Wrapf(err, "invalid default of %s", fieldPath)
+			}
+		}
+	}
+	return nil
+}
+
+// decodeDefault decodes the default tag of a slice or map field.
+func decodeDefault(field reflect.Value, tag string) error {
+	tag = strings.TrimSpace(tag)
+
+	var data interface{}
+	if err := json.Unmarshal([]byte(tag), &data); err != nil {
+		data = parseList(field.Kind(), tag)
+	}
+
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook:       decodeHooks(),
+		WeaklyTypedInput: true,
+		Result:           field.Addr().Interface(),
+	})
+	if err != nil {
+		return err
+	}
+	if err := decoder.Decode(data); err != nil {
+		return err
+	}
+
+	if field.IsNil() {
+		// An empty default such as "[]" or "{}" still initializes the field.
+		if field.Kind() == reflect.Map {
+			field.Set(reflect.MakeMap(field.Type()))
+		} else {
+			field.Set(reflect.MakeSlice(field.Type(), 0, 0))
+		}
+	}
+	return nil
+}
+
+// parseList parses "a,b,c" or "[a,b,c]" into a slice and "k:v,k2:v2" or
+// "{k:v,k2:v2}" into a map of strings.
+func parseList(kind reflect.Kind, s string) interface{} {
+	if kind == reflect.Map {
+		s = strings.TrimSuffix(strings.TrimPrefix(s, "{"), "}")
+		values := make(map[string]interface{})
+		for _, item := range splitList(s) {
+			key, value, _ := strings.Cut(item, ":")
+			values[unquote(key)] = unquote(value)
+		}
+		return values
+	}
+
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "["), "]")
+	values := make([]interface{}, 0)
+	for _, item := range splitList(s) {
+		values = append(values, unquote(item))
+	}
+	return values
+}
+
+func splitList(s string) []string {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+// unquote trims the spaces and quotes around an element.
+func unquote(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
@@ -0,0 +1,73 @@
+package configloader
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type collectionConfig struct {
+	Tags      []string          `default:"[a,b,c]"`
+	Hosts     []string          `default:"db1, db2"`
+	Ports     []int             `default:"[8080, 8081]"`
+	Backoff   []time.Duration   `default:"1s,5s"`
+	Headers   map[string]string `default:"{\"X-Api\": \"v1\"}"`
+	Labels    map[string]string `default:"team:core, tier:backend"`
+	Limits    map[string]int    `default:"{read:100,write:10}"`
+	Empty     []string          `default:"[]"`
+	Untouched []string
+	Nested    struct {
+		Names []string `default:"x,y"`
+	}
+}
+
+func TestCollectionDefaults(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		cfg, err := New[collectionConfig](WithoutEnv())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := collectionConfig{
+			Tags:    []string{"a", "b", "c"},
+			Hosts:   []string{"db1", "db2"},
+			Ports:   []int{8080, 8081},
+			Backoff: []time.Duration{time.Second, 5 * time.Second},
+			Headers: map[string]string{"X-Api": "v1"},
+			Labels:  map[string]string{"team": "core", "tier": "backend"},
+			Limits:  map[string]int{"read": 100, "write": 10},
+			Empty:   []string{},
+		}
+		expected.Nested.Names = []string{"x", "y"}
+		if !reflect.DeepEqual(*cfg, expected) {
+			t.Errorf("Expected %+v, got %+v", expected, *cfg)
+		}
+	})
+
+	t.Run("ConfiguredValuesWin", func(t *testing.T) {
+		cfg, err := New[collectionConfig](WithoutEnv(), WithValues(
+			"tags", []string{"z"},
+			"labels", map[string]interface{}{"team": "edge"},
+		))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(cfg.Tags, []string{"z"}) {
+			t.Errorf("Expected tags [z], got %v", cfg.Tags)
+		}
+		if !reflect.DeepEqual(cfg.Labels, map[string]string{"team": "edge"}) {
+			t.Errorf("Expected labels team:edge, got %v", cfg.Labels)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		type invalidConfig struct {
+			Ports []int `default:"80,http"`
+		}
+		_, err := New[invalidConfig](WithoutEnv())
+		if err == nil || !strings.Contains(err.Error(), "invalid default of Ports") {
+			t.Errorf("Expected invalid default error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,79 @@
+package configloader
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+)
+
+// Change is a configuration key whose value differs between two configurations.
+type Change struct {
+	// Key is the dot-separated key path, e.g. "App.Logger.Level".
+	Key string `json:"key"`
+	// Old and New are the values before and after the change, "***" for
+	// config.Secret values and fields tagged `sensitive:"true"`.
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// leaf is a configuration key with its value.
+type leaf struct {
+	value  reflect.Value
+	masked bool
+}
+
+// Diff returns the keys whose values differ between two configurations of
+// the same type, in declaration order. Secrets are compared by value but
+// reported masked.
+func Diff(old, new interface{}) []Change {
+	oldLeaves := make(map[string]leaf)
+	walkLeaves(reflect.ValueOf(old), "", false, func(path string, v reflect.Value, _ reflect.StructField, masked bool) {
+		oldLeaves[path] = leaf{v, masked}
+	})
+
+	var changes []Change
+	walkLeaves(reflect.ValueOf(new), "", false, func(path string, v reflect.Value, _ reflect.StructField, masked bool) {
+		prev, ok := oldLeaves[path]
+		if ok && reflect.DeepEqual(rawLeaf(prev.value), rawLeaf(v)) {
+			return
+		}
+
+		change := Change{Key: path, New: leafValue(v, masked)}
+		if ok {
+			change.Old = leafValue(prev.value, prev.masked)
+		}
+		changes = append(changes, change)
+	})
+	return changes
+}
+
+// Hash returns a hex-encoded SHA-256 hash of the values of a configuration,
+// e.g. to verify every replica runs the same configuration. Secrets are
+// hashed masked, so the hash reveals nothing about them and changes with any
+// value but them.
+func Hash(cfg interface{}) string {
+	h := sha256.New()
+	walkLeaves(reflect.ValueOf(cfg), "", false, func(path string, v reflect.Value, _ reflect.StructField, masked bool) {
+		fmt.Fprintf(h, "%s=%v\n", path, leafValue(v, masked))
+	})
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// rawLeaf returns the unmasked value of a key, with pointers dereferenced.
+func rawLeaf(v reflect.Value) interface{} {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if text, ok := leafText(v); ok {
+		return text
+	}
+	if v.Kind() == reflect.String {
+		// Unwraps string types such as config.Secret, which would be masked.
+		return v.String()
+	}
+	return v.Interface()
+}
@@ -0,0 +1,65 @@
+package configloader
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/katalabut/fast-app/config"
+)
+
+type diffConfig struct {
+	Server struct {
+		Port    int
+		Timeout time.Duration
+	}
+	Password config.Secret
+	APIKey   string `sensitive:"true"`
+	Tags     []string
+}
+
+func TestDiff(t *testing.T) {
+	old := diffConfig{Password: "a", APIKey: "k", Tags: []string{"x"}}
+	old.Server.Port = 8080
+	old.Server.Timeout = time.Second
+
+	t.Run("Unchanged", func(t *testing.T) {
+		if changes := Diff(old, old); len(changes) != 0 {
+			t.Errorf("Expected no changes, got %+v", changes)
+		}
+		if Hash(old) != Hash(old) {
+			t.Error("Expected the same hash for the same configuration")
+		}
+	})
+
+	t.Run("Changed", func(t *testing.T) {
+		cfg := old
+		cfg.Server.Timeout = 5 * time.Second
+		cfg.Password = "b"
+		cfg.APIKey = "l"
+		cfg.Tags = []string{"x", "y"}
+
+		expected := []Change{
+			{Key: "Server.Timeout", Old: "1s", New: "5s"},
+			{Key: "Password", Old: "***", New: "***"},
+			{Key: "APIKey", Old: "***", New: "***"},
+			{Key: "Tags", Old: []string{"x"}, New: []string{"x", "y"}},
+		}
+		if changes := Diff(&old, &cfg); !reflect.DeepEqual(changes, expected) {
+			t.Errorf("Expected %+v, got %+v", expected, changes)
+		}
+	})
+
+	t.Run("HashMasksSecrets", func(t *testing.T) {
+		cfg := old
+		cfg.Password = "b"
+		if Hash(old) != Hash(cfg) {
+			t.Error("Expected the same hash when only a secret changes")
+		}
+
+		cfg.Server.Port = 9090
+		if Hash(old) == Hash(cfg) {
+			t.Error("Expected a different hash when a value changes")
+		}
+	})
+}
@@ -0,0 +1,93 @@
+package configloader
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// mountConfigMap writes the keys like the kubelet does: into a timestamped
+// directory, linked by "..data", with a link per key into it. Updates swap
+// the "..data" link atomically.
+func mountConfigMap(t *testing.T, dir, version string, keys map[string]string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("Symbolic links require privileges on Windows")
+	}
+
+	data := filepath.Join(dir, "..data_"+version)
+	if err := os.Mkdir(data, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for key, value := range keys {
+		if err := os.WriteFile(filepath.Join(data, key), []byte(value+"\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		link := filepath.Join(dir, key)
+		if _, err := os.Lstat(link); os.IsNotExist(err) {
+			if err := os.Symlink(filepath.Join("..data", key), link); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	tmp := filepath.Join(dir, "..data_tmp")
+	if err := os.Symlink(filepath.Base(data), tmp); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(tmp, filepath.Join(dir, "..data")); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWithDir(t *testing.T) {
+	t.Run("Load", func(t *testing.T) {
+		dir := t.TempDir()
+		mountConfigMap(t, dir, "1", map[string]string{"port": "9000", "nested.name": "secret"})
+
+		file := filepath.Join(t.TempDir(), "config.yaml")
+		writeConfig(t, file, "port: 8000\nhost: example.com\n")
+
+		cfg, err := New[testConfig](WithoutEnv(), WithFile(file), WithDir(dir))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if cfg.Port != 9000 || cfg.Nested.Name != "secret" {
+			t.Errorf("Expected port 9000 and nested name from the directory, got %+v", cfg)
+		}
+		if cfg.Host != "example.com" {
+			t.Errorf("Expected host from the file, got %q", cfg.Host)
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		if _, err := New[testConfig](WithDir(filepath.Join(t.TempDir(), "missing"))); err == nil {
+			t.Error("Expected error for a missing directory")
+		}
+	})
+
+	t.Run("WatchSymlinkSwap", func(t *testing.T) {
+		dir := t.TempDir()
+		mountConfigMap(t, dir, "1", map[string]string{"port": "9000"})
+
+		w, err := NewWatcher[testConfig](WithoutEnv(), WithDir(dir), WithWatch())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		defer w.Close()
+
+		ch := w.Subscribe()
+		mountConfigMap(t, dir, "2", map[string]string{"port": "9001"})
+
+		select {
+		case cfg := <-ch:
+			if cfg.Port != 9001 {
+				t.Errorf("Expected port 9001, got %d", cfg.Port)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("ConfigMap update was not detected")
+		}
+	})
+}
@@ -0,0 +1,173 @@
+package configloader
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// WriteExample writes a configuration skeleton of type T with defaults filled
+// in, in the "yaml" or "json" format, e.g. to generate a config.example.yaml
+// from the code. In YAML, the `desc` tag of a field is written as a comment
+// above its key:
+//
+//	type Config struct {
+//	    Port int `default:"8080" desc:"Port the API listens on"`
+//	}
+func WriteExample[T any](w io.Writer, format string) error {
+	var cfg T
+	if err := setDefaults(&cfg); err != nil {
+		return err
+	}
+	v := reflect.ValueOf(cfg)
+
+	switch strings.ToLower(format) {
+	case "yaml", "yml":
+		node, err := exampleNode(v)
+		if err != nil {
+			return err
+		}
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(node); err != nil {
+			return err
+		}
+		return enc.Close()
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(exampleValue(v))
+	default:
+		return fmt.Errorf("unsupported example format: %s", format)
+	}
+}
+
+// exampleField is a key of a configuration struct.
+type exampleField struct {
+	name  string
+	desc  string
+	value reflect.Value
+}
+
+// exampleFields returns the keys of a struct in declaration order, with the
+// fields of squashed structs inlined.
+func exampleFields(v reflect.Value) []exampleField {
+	var fields []exampleField
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(f.Tag.Get("mapstructure"), ",")
+		switch {
+		case name == "-":
+			continue
+		case opts == "squash":
+			fields = append(fields, exampleFields(indirect(v.Field(i)))...)
+			continue
+		case name == "":
+			name = f.Name
+		}
+		fields = append(fields, exampleField{name: name, desc: f.Tag.Get("desc"), value: v.Field(i)})
+	}
+	return fields
+}
+
+// indirect dereferences pointers, using the zero value for nil pointers so
+// that the skeleton shows their keys.
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v = reflect.Zero(v.Type().Elem())
+			continue
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func exampleNode(v reflect.Value) (*yaml.Node, error) {
+	v = indirect(v)
+	if !isSection(v.Type()) {
+		node := &yaml.Node{}
+		if err := node.Encode(exampleLeaf(v)); err != nil {
+			return nil, err
+		}
+		return node, nil
+	}
+
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for _, f := range exampleFields(v) {
+		value, err := exampleNode(f.value)
+		if err != nil {
+			return nil, err
+		}
+		key := &yaml.Node{Kind: yaml.ScalarNode, Value: f.name, HeadComment: f.desc}
+		node.Content = append(node.Content, key, value)
+	}
+	return node, nil
+}
+
+func exampleValue(v reflect.Value) interface{} {
+	v = indirect(v)
+	if !isSection(v.Type()) {
+		return exampleLeaf(v)
+	}
+
+	var section orderedSection
+	for _, f := range exampleFields(v) {
+		section = append(section, orderedKey{f.name, exampleValue(f.value)})
+	}
+	return section
+}
+
+// exampleLeaf returns the value of a key as it is written in a configuration file.
+func exampleLeaf(v reflect.Value) interface{} {
+	if text, ok := leafText(v); ok {
+		return text
+	}
+	if v.Kind() == reflect.String {
+		// Unwraps string types such as config.Secret, which would be masked.
+		return v.String()
+	}
+	return v.Interface()
+}
+
+type orderedKey struct {
+	name  string
+	value interface{}
+}
+
+// orderedSection is encoded as a JSON object keeping the order of its keys.
+type orderedSection []orderedKey
+
+func (s orderedSection) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range s {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(key.name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(key.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
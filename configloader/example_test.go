@@ -0,0 +1,87 @@
+package configloader
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/katalabut/fast-app/config"
+)
+
+type exampleConfig struct {
+	Server struct {
+		Port    int           `default:"8080" desc:"Port the API listens on"`
+		Timeout time.Duration `default:"5s"`
+	} `desc:"HTTP server"`
+	APIKey config.Secret `desc:"Key of the payments API"`
+	Tags   []string      `default:"[\"a\",\"b\"]"`
+}
+
+func TestWriteExample(t *testing.T) {
+	t.Run("YAML", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := WriteExample[exampleConfig](&buf, "yaml"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := `# HTTP server
+Server:
+  # Port the API listens on
+  Port: 8080
+  Timeout: 5s
+# Key of the payments API
+APIKey: ""
+Tags:
+  - a
+  - b
+`
+		if buf.String() != expected {
+			t.Errorf("Expected:\n%s\ngot:\n%s", expected, buf.String())
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := WriteExample[exampleConfig](&buf, "json"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		var decoded struct {
+			Server struct {
+				Port    int
+				Timeout string
+			}
+		}
+		if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+			t.Fatalf("Failed to decode example: %v\n%s", err, buf.String())
+		}
+		if decoded.Server.Port != 8080 || decoded.Server.Timeout != "5s" {
+			t.Errorf("Expected defaults in the example, got %+v", decoded.Server)
+		}
+	})
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := WriteExample[exampleConfig](&buf, "yaml"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		writeConfig(t, path, buf.String())
+		cfg, err := New[exampleConfig](WithoutEnv(), WithFile(path))
+		if err != nil {
+			t.Fatalf("Expected the example to load, got %v", err)
+		}
+		if cfg.Server.Port != 8080 {
+			t.Errorf("Expected port 8080, got %d", cfg.Server.Port)
+		}
+	})
+
+	t.Run("UnsupportedFormat", func(t *testing.T) {
+		if err := WriteExample[exampleConfig](&bytes.Buffer{}, "ini"); err == nil {
+			t.Error("Expected error for an unsupported format")
+		}
+	})
+}
@@ -0,0 +1,49 @@
+package configloader
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/katalabut/fast-app/configloader/source"
+)
+
+func TestExpandEnv(t *testing.T) {
+	t.Setenv("EXPAND_HOST", "db.internal")
+	t.Setenv("EXPAND_EMPTY", "")
+
+	tests := []struct {
+		in, expected string
+	}{
+		{"${EXPAND_HOST}", "db.internal"},
+		{"postgres://${EXPAND_HOST}:5432", "postgres://db.internal:5432"},
+		{"${EXPAND_UNSET}", ""},
+		{"${EXPAND_UNSET:-8080}", "8080"},
+		{"${EXPAND_EMPTY:-fallback}", "fallback"},
+		{"${EXPAND_HOST:-fallback}", "db.internal"},
+		{"$${EXPAND_HOST}", "${EXPAND_HOST}"},
+		{"pa$$word $EXPAND_HOST", "pa$$word $EXPAND_HOST"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := source.ExpandEnv(tt.in); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+
+	t.Run("File", func(t *testing.T) {
+		t.Setenv("EXPAND_PORT", "9000")
+
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		writeConfig(t, path, "port: ${EXPAND_PORT}\nhost: ${EXPAND_UNSET:-example.com}\n")
+
+		cfg, err := New[testConfig](WithoutEnv(), WithFile(path))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if cfg.Port != 9000 || cfg.Host != "example.com" {
+			t.Errorf("Expected port 9000 and host example.com, got %+v", cfg)
+		}
+	})
+}
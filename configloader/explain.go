@@ -0,0 +1,175 @@
+package configloader
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/katalabut/fast-app/config"
+	"github.com/katalabut/fast-app/configloader/source"
+	"github.com/spf13/viper"
+)
+
+// Sources of an Entry besides the names of the configuration sources.
+const (
+	// SourceDefault is the source of a value set by a `default` struct tag.
+	SourceDefault = "default"
+	// SourceUnset is the source of a value set by no source, left zero.
+	SourceUnset = "unset"
+)
+
+var secretType = reflect.TypeOf(config.Secret(""))
+
+// Entry is a resolved configuration key.
+type Entry struct {
+	// Key is the dot-separated key path, e.g. "App.Logger.Level".
+	Key string `json:"key" yaml:"key"`
+	// Value is the resolved value, "***" for config.Secret values and
+	// fields tagged `sensitive:"true"`.
+	Value interface{} `json:"value" yaml:"value"`
+	// Source is the name of the source the value was taken from, e.g. "env"
+	// or "file", SourceDefault or SourceUnset.
+	Source string `json:"source" yaml:"source"`
+}
+
+// Explain loads a configuration of type T like New and returns every key of
+// it with its value, masking secrets, and the source that set it, to debug
+// precedence issues. Sources take precedence in the order: values set with
+// WithMap, environment variables, configuration service, directory, file,
+// embedded file.
+func Explain[T any](opts ...Option) ([]Entry, error) {
+	cfg, p, err := load[T](opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.explain(cfg)
+}
+
+// sourceOf reports whether a loaded source sets a key.
+type sourceOf struct {
+	name string
+	has  func(key string) bool
+}
+
+func (p *Parser) explain(cfg interface{}) ([]Entry, error) {
+	sources, err := p.keySources()
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	walkLeaves(reflect.ValueOf(cfg), "", false, func(path string, v reflect.Value, f reflect.StructField, masked bool) {
+		entry := Entry{Key: path, Value: leafValue(v, masked), Source: SourceUnset}
+		if _, ok := f.Tag.Lookup("default"); ok {
+			entry.Source = SourceDefault
+		}
+		for _, src := range sources {
+			if src.has(strings.ToLower(path)) {
+				entry.Source = src.name
+				break
+			}
+		}
+		entries = append(entries, entry)
+	})
+
+	return entries, nil
+}
+
+// keySources returns the loaded sources in order of precedence.
+func (p *Parser) keySources() ([]sourceOf, error) {
+	var sources []sourceOf
+
+	if m, ok := p.sources[source.MapSourceName].(*source.Map); ok {
+		sources = append(sources, sourceOf{source.MapSourceName, m.Has})
+	}
+	if env, ok := p.sources[source.EnvSourceName].(*source.Env); ok {
+		sources = append(sources, sourceOf{source.EnvSourceName, func(key string) bool {
+			_, ok := os.LookupEnv(env.VarName(key))
+			return ok
+		}})
+	}
+	// Values of these sources are merged, so each is loaded again on its own.
+	for _, name := range []string{source.HTTPSourceName, source.DirSourceName, source.FileSourceName, source.FSSourceName} {
+		src, ok := p.sources[name]
+		if !ok {
+			continue
+		}
+		v := viper.New()
+		if err := src.Load(v); err != nil {
+			return nil, err
+		}
+		sources = append(sources, sourceOf{name, v.IsSet})
+	}
+
+	return sources, nil
+}
+
+// walkLeaves calls fn with the key path of every leaf field of a
+// configuration, reporting whether its value must be masked.
+func walkLeaves(v reflect.Value, path string, masked bool, fn func(path string, v reflect.Value, f reflect.StructField, masked bool)) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v = reflect.Zero(v.Type().Elem())
+			continue
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return
+	}
+
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(f.Tag.Get("mapstructure"), ",")
+		fieldPath := path
+		switch {
+		case name == "-":
+			continue
+		case opts == "squash":
+		case name == "":
+			fieldPath = joinPath(path, f.Name)
+		default:
+			fieldPath = joinPath(path, name)
+		}
+
+		field := v.Field(i)
+		ft := f.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		fieldMasked := masked || f.Tag.Get("sensitive") == "true" || ft == secretType
+		if isSection(ft) {
+			walkLeaves(field, fieldPath, fieldMasked, fn)
+			continue
+		}
+		fn(fieldPath, field, f, fieldMasked)
+	}
+}
+
+func leafValue(v reflect.Value, masked bool) interface{} {
+	if masked {
+		return "***"
+	}
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	// Durations and similar types read better as text than as numbers.
+	if text, ok := leafText(v); ok {
+		return text
+	}
+	if s, ok := v.Interface().(fmt.Stringer); ok && v.Kind() != reflect.Struct {
+		return s.String()
+	}
+	return v.Interface()
+}
@@ -0,0 +1,136 @@
+package configloader
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/katalabut/fast-app/config"
+)
+
+type explainConfig struct {
+	Host     string        `default:"localhost"`
+	Port     int           `default:"8080"`
+	Timeout  time.Duration `default:"5s"`
+	Region   string
+	Database struct {
+		User     string
+		Password config.Secret
+		DSN      string `sensitive:"true"`
+	}
+}
+
+func TestExplain(t *testing.T) {
+	t.Setenv("EXPLAIN_DATABASE_USER", "app")
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, "port: 9000\ndatabase:\n  password: hunter2\n  dsn: postgres://app:hunter2@db\n")
+
+	entries, err := Explain[explainConfig](WithEnv("EXPLAIN"), WithFile(path), WithValues("host", "example.com"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	got := make(map[string]Entry)
+	for _, e := range entries {
+		got[e.Key] = e
+	}
+
+	expected := []Entry{
+		{Key: "Host", Value: "example.com", Source: "map"},
+		{Key: "Port", Value: 9000, Source: "file"},
+		{Key: "Timeout", Value: "5s", Source: SourceDefault},
+		{Key: "Region", Value: "", Source: SourceUnset},
+		{Key: "Database.User", Value: "app", Source: "env"},
+		{Key: "Database.Password", Value: "***", Source: "file"},
+		{Key: "Database.DSN", Value: "***", Source: "file"},
+	}
+	for _, e := range expected {
+		if got[e.Key] != e {
+			t.Errorf("Expected %+v, got %+v", e, got[e.Key])
+		}
+	}
+	if len(entries) != len(expected) {
+		t.Errorf("Expected %d entries, got %d", len(expected), len(entries))
+	}
+}
+
+func TestExplainMasksAppSecrets(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, "observability:\n  health:\n    admintoken: hunter2\n")
+
+	entries, err := Explain[config.App](WithFile(path))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	masked := map[string]bool{"Observability.Health.AdminToken": false}
+	for _, e := range entries {
+		if _, ok := masked[e.Key]; ok {
+			masked[e.Key] = true
+			if e.Value != "***" {
+				t.Errorf("Expected %s to be masked, got %v", e.Key, e.Value)
+			}
+		}
+	}
+	for key, found := range masked {
+		if !found {
+			t.Errorf("Expected an entry for %s", key)
+		}
+	}
+
+	old := config.App{}
+	cfg := config.App{}
+	cfg.Observability.Health.AdminToken = "hunter2"
+	changes := Diff(old, cfg)
+	if len(changes) != len(masked) {
+		t.Errorf("Expected %d changes, got %+v", len(masked), changes)
+	}
+	for _, c := range changes {
+		if c.Old != "***" || c.New != "***" {
+			t.Errorf("Expected the change of %s to be masked, got %+v", c.Key, c)
+		}
+	}
+}
+
+func TestExplainMasksWebhookSecrets(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, "observability:\n  health:\n    notifications:\n      webhooks:\n"+
+		"        - type: pagerduty\n          routingkey: pd-key\n          headers:\n            authorization: hunter2\n")
+
+	entries, err := Explain[config.App](WithFile(path))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	old := config.App{}
+	cfg := config.App{}
+	cfg.Observability.Health.Notifications.Webhooks = []config.Webhook{
+		{Type: "pagerduty", RoutingKey: "pd-key", Headers: map[string]config.Secret{"Authorization": "hunter2"}},
+	}
+	changes := Diff(old, cfg)
+	if len(changes) != 1 {
+		t.Errorf("Expected 1 change, got %+v", changes)
+	}
+
+	for name, v := range map[string]interface{}{"Explain": entries, "Diff": changes} {
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		for _, secret := range []string{"pd-key", "hunter2"} {
+			if strings.Contains(string(encoded), secret) {
+				t.Errorf("Expected %s not to reveal %q, got %s", name, secret, encoded)
+			}
+			if strings.Contains(fmt.Sprint(v), secret) {
+				t.Errorf("Expected %s not to reveal %q when printed, got %v", name, secret, v)
+			}
+		}
+	}
+	if !strings.Contains(fmt.Sprint(changes), "pagerduty") {
+		t.Errorf("Expected the webhook change to be reported, got %v", changes)
+	}
+}
@@ -0,0 +1,69 @@
+package configloader
+
+import (
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+type fsConfig struct {
+	Name string
+	Port int
+	Log  struct {
+		Level string
+	}
+}
+
+func TestFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"config.default.yaml": &fstest.MapFile{Data: []byte("name: tool\nport: 8080\nlog:\n  level: info\n")},
+	}
+
+	t.Run("Defaults", func(t *testing.T) {
+		cfg, err := New[fsConfig](WithoutEnv(), WithFS(fsys, "config.default.yaml"))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if cfg.Name != "tool" || cfg.Port != 8080 || cfg.Log.Level != "info" {
+			t.Errorf("Expected the embedded configuration, got %+v", cfg)
+		}
+	})
+
+	t.Run("Overridden", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		writeConfig(t, path, "log:\n  level: debug\n")
+		t.Setenv("PORT", "9000")
+
+		cfg, err := New[fsConfig](WithFile(path), WithFS(fsys, "config.default.yaml"))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if cfg.Name != "tool" {
+			t.Errorf("Expected name from the embedded file, got %q", cfg.Name)
+		}
+		if cfg.Log.Level != "debug" {
+			t.Errorf("Expected level from the file, got %q", cfg.Log.Level)
+		}
+		if cfg.Port != 9000 {
+			t.Errorf("Expected port from the environment, got %d", cfg.Port)
+		}
+
+		entries, err := Explain[fsConfig](WithFile(path), WithFS(fsys, "config.default.yaml"))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		sources := make(map[string]string)
+		for _, e := range entries {
+			sources[e.Key] = e.Source
+		}
+		if sources["Name"] != "fs" || sources["Log.Level"] != "file" || sources["Port"] != "env" {
+			t.Errorf("Expected fs, file and env sources, got %v", sources)
+		}
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		if _, err := New[fsConfig](WithFS(fsys, "missing.yaml")); err == nil {
+			t.Error("Expected error for a missing file")
+		}
+	})
+}
@@ -0,0 +1,127 @@
+package configloader
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/katalabut/fast-app/config"
+	"github.com/mitchellh/mapstructure"
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	urlType      = reflect.TypeOf(url.URL{})
+	ipType       = reflect.TypeOf(net.IP{})
+	regexpType   = reflect.TypeOf(regexp.Regexp{})
+	int64Type    = reflect.TypeOf(int64(0))
+	byteSizeType = reflect.TypeOf(config.ByteSize(0))
+)
+
+// isSection reports whether a configuration type is a section of nested keys,
+// rather than a value decoded from a single key, such as time.Time or url.URL.
+func isSection(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	return t != timeType && t != urlType && t != regexpType
+}
+
+// leafText returns the text a value decoded by the hooks is written as in a
+// configuration file, reporting false for other values.
+func leafText(v reflect.Value) (string, bool) {
+	switch x := v.Interface().(type) {
+	case time.Duration:
+		return x.String(), true
+	case config.ByteSize:
+		return x.String(), true
+	case time.Time:
+		if x.IsZero() {
+			return "", true
+		}
+		return x.Format(time.RFC3339), true
+	case url.URL:
+		return x.String(), true
+	case net.IP:
+		if x == nil {
+			return "", true
+		}
+		return x.String(), true
+	case regexp.Regexp:
+		return x.String(), true
+	}
+	return "", false
+}
+
+// decodeHooks convert configuration values to the types of the fields.
+func decodeHooks() mapstructure.DecodeHookFunc {
+	return mapstructure.ComposeDecodeHookFunc(
+		mapstructure.StringToTimeDurationHookFunc(),
+		stringToTypeHook(),
+		mapstructure.StringToSliceHookFunc(","),
+	)
+}
+
+// stringToTypeHook decodes strings into time.Time (RFC 3339), url.URL,
+// net.IP and regexp.Regexp values, and pointers to them, and byte sizes such
+// as "10MB" into config.ByteSize and int64 values. Empty strings decode into
+// zero values.
+func stringToTypeHook() mapstructure.DecodeHookFuncType {
+	return func(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+		if from.Kind() != reflect.String {
+			return data, nil
+		}
+		s := data.(string)
+
+		switch to {
+		case timeType:
+			if s == "" {
+				return time.Time{}, nil
+			}
+			t, err := time.Parse(time.RFC3339, s)
+			if err != nil {
+				return nil, fmt.Errorf("invalid time %q, expected RFC 3339, e.g. 2006-01-02T15:04:05Z", s)
+			}
+			return t, nil
+		case urlType:
+			u, err := url.Parse(s)
+			if err != nil {
+				return nil, fmt.Errorf("invalid URL %q: %w", s, err)
+			}
+			return *u, nil
+		case ipType:
+			if s == "" {
+				return net.IP(nil), nil
+			}
+			ip := net.ParseIP(s)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid IP address %q", s)
+			}
+			return ip, nil
+		case regexpType:
+			re, err := regexp.Compile(s)
+			if err != nil {
+				return nil, fmt.Errorf("invalid regular expression %q: %w", s, err)
+			}
+			return re, nil
+		case byteSizeType:
+			if s == "" {
+				return config.ByteSize(0), nil
+			}
+			return config.ParseByteSize(s)
+		case int64Type:
+			if _, err := strconv.ParseInt(s, 0, 64); err == nil {
+				return data, nil
+			}
+			if size, err := config.ParseByteSize(s); err == nil {
+				return size.Int64(), nil
+			}
+		}
+
+		return data, nil
+	}
+}
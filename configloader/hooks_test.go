@@ -0,0 +1,128 @@
+package configloader
+
+import (
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/katalabut/fast-app/config"
+)
+
+type typedConfig struct {
+	Since    time.Time
+	Endpoint *url.URL
+	Mirror   url.URL
+	Bind     net.IP
+	Pattern  *regexp.Regexp
+}
+
+func TestDecodeHooks(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		cfg, err := New[typedConfig](WithoutEnv(), WithValues(
+			"since", "2024-05-01T10:00:00Z",
+			"endpoint", "https://api.example.com/v1",
+			"mirror", "https://mirror.example.com",
+			"bind", "10.0.0.1",
+			"pattern", "^orders-[0-9]+$",
+		))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if !cfg.Since.Equal(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)) {
+			t.Errorf("Expected since 2024-05-01T10:00:00Z, got %v", cfg.Since)
+		}
+		if cfg.Endpoint == nil || cfg.Endpoint.Host != "api.example.com" || cfg.Endpoint.Path != "/v1" {
+			t.Errorf("Expected endpoint URL, got %v", cfg.Endpoint)
+		}
+		if cfg.Mirror.Host != "mirror.example.com" {
+			t.Errorf("Expected mirror host, got %q", cfg.Mirror.Host)
+		}
+		if !cfg.Bind.Equal(net.ParseIP("10.0.0.1")) {
+			t.Errorf("Expected bind 10.0.0.1, got %v", cfg.Bind)
+		}
+		if cfg.Pattern == nil || !cfg.Pattern.MatchString("orders-42") {
+			t.Errorf("Expected pattern to match orders-42, got %v", cfg.Pattern)
+		}
+	})
+
+	tests := []struct {
+		key, value, expected string
+	}{
+		{"since", "yesterday", "invalid time"},
+		{"endpoint", "http://[::1", "invalid URL"},
+		{"bind", "10.0.0.256", "invalid IP address"},
+		{"pattern", "orders-(", "invalid regular expression"},
+	}
+	for _, tt := range tests {
+		t.Run("Invalid"+tt.key, func(t *testing.T) {
+			_, err := New[typedConfig](WithoutEnv(), WithValues(tt.key, tt.value))
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Expected error containing %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestDecodeByteSize(t *testing.T) {
+	type uploadConfig struct {
+		MaxFileSize config.ByteSize `default:"10MiB"`
+		MaxBody     int64
+		BufferSize  config.ByteSize
+	}
+
+	t.Run("Defaults", func(t *testing.T) {
+		cfg, err := New[uploadConfig](WithoutEnv())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if cfg.MaxFileSize != 10*config.MiB {
+			t.Errorf("Expected max file size 10MiB, got %v", cfg.MaxFileSize)
+		}
+	})
+
+	t.Run("Values", func(t *testing.T) {
+		cfg, err := New[uploadConfig](WithoutEnv(), WithValues(
+			"maxfilesize", "512KiB",
+			"maxbody", "2MB",
+			"buffersize", 4096,
+		))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if cfg.MaxFileSize != 512*config.KiB {
+			t.Errorf("Expected max file size 512KiB, got %v", cfg.MaxFileSize)
+		}
+		if cfg.MaxBody != 2000000 {
+			t.Errorf("Expected max body 2000000, got %d", cfg.MaxBody)
+		}
+		if cfg.BufferSize != 4096 {
+			t.Errorf("Expected buffer size 4096, got %d", cfg.BufferSize)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := New[uploadConfig](WithoutEnv(), WithValues("maxfilesize", "10XB"))
+		if err == nil || !strings.Contains(err.Error(), "invalid byte size") {
+			t.Errorf("Expected invalid byte size error, got %v", err)
+		}
+	})
+}
+
+func TestDecodedTypesAreKeys(t *testing.T) {
+	_, err := New[typedConfig](WithoutEnv(), WithStrict(), WithValues("mirror", "https://mirror.example.com"))
+	if err != nil {
+		t.Errorf("Expected URL fields to be single keys, got %v", err)
+	}
+
+	var buf strings.Builder
+	if err := WriteExample[typedConfig](&buf, "yaml"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), `Mirror: ""`) {
+		t.Errorf("Expected URL fields to be written as strings, got:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,87 @@
+package configloader
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/katalabut/fast-app/configloader/source"
+)
+
+// HTTPOption configures a configuration service source added with WithHTTP.
+type HTTPOption func(*source.HTTPConfig)
+
+// HTTPHeader adds a header sent with every request, e.g. for authentication.
+func HTTPHeader(key, value string) HTTPOption {
+	return func(c *source.HTTPConfig) {
+		c.Header.Add(key, value)
+	}
+}
+
+// HTTPFormat sets the format of the configuration, e.g. "yaml", instead of
+// detecting it from the Content-Type header or the URL extension.
+func HTTPFormat(format string) HTTPOption {
+	return func(c *source.HTTPConfig) {
+		c.Format = format
+	}
+}
+
+// HTTPFallback sets a local file read when the service is unreachable and no
+// configuration has been fetched yet.
+func HTTPFallback(path string) HTTPOption {
+	return func(c *source.HTTPConfig) {
+		c.Fallback = path
+	}
+}
+
+// HTTPTimeout sets the timeout of a request, 10 seconds by default.
+func HTTPTimeout(timeout time.Duration) HTTPOption {
+	return func(c *source.HTTPConfig) {
+		c.Timeout = timeout
+	}
+}
+
+// HTTPPollInterval sets how often a Watcher created with WithWatch polls the
+// service for changes, 30 seconds by default.
+func HTTPPollInterval(interval time.Duration) HTTPOption {
+	return func(c *source.HTTPConfig) {
+		c.PollInterval = interval
+	}
+}
+
+// HTTPClient sets the client sending the requests, e.g. for mutual TLS.
+func HTTPClient(client *http.Client) HTTPOption {
+	return func(c *source.HTTPConfig) {
+		c.Client = client
+	}
+}
+
+// WithHTTP adds a configuration service as a source, fetching a JSON or YAML
+// configuration from url. Values from the service are merged over the
+// configuration file and directory. Combined with WithWatch, a Watcher polls
+// the service, sending the entity tag of the last response so that an
+// unchanged configuration is not transferred again. When the service is
+// unreachable the last fetched configuration, or the HTTPFallback file, is used.
+//
+// Example:
+//
+//	configloader.WithHTTP("https://config.internal/api/orders.yaml",
+//	    configloader.HTTPHeader("Authorization", "Bearer "+token),
+//	    configloader.HTTPFallback("config.yaml"),
+//	)
+func WithHTTP(url string, opts ...HTTPOption) Option {
+	cfg := source.HTTPConfig{Header: make(http.Header)}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	// The source is shared by every parser created with the option, so a
+	// Watcher keeps the entity tag and the last configuration across reloads.
+	src, err := source.NewHTTP(url, cfg)
+
+	return func(p *Parser) error {
+		if err != nil {
+			return err
+		}
+		return p.SetSource(src)
+	}
+}
@@ -0,0 +1,136 @@
+package configloader
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+type configService struct {
+	mu       sync.Mutex
+	body     string
+	etag     string
+	requests int
+	notMod   int
+	auth     string
+}
+
+func (s *configService) set(body, etag string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.body, s.etag = body, etag
+}
+
+func (s *configService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests++
+	s.auth = r.Header.Get("Authorization")
+	if r.Header.Get("If-None-Match") == s.etag {
+		s.notMod++
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/yaml")
+	w.Header().Set("ETag", s.etag)
+	w.Write([]byte(s.body))
+}
+
+func TestWithHTTP(t *testing.T) {
+	t.Run("FetchAndNotModified", func(t *testing.T) {
+		svc := &configService{body: "port: 9000\n", etag: `"v1"`}
+		server := httptest.NewServer(svc)
+		defer server.Close()
+
+		w, err := NewWatcher[testConfig](WithoutEnv(), WithHTTP(server.URL, HTTPHeader("Authorization", "Bearer token")))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		defer w.Close()
+
+		if cfg := w.Config(); cfg.Port != 9000 {
+			t.Errorf("Expected port 9000, got %d", cfg.Port)
+		}
+		if svc.auth != "Bearer token" {
+			t.Errorf("Expected authorization header, got %q", svc.auth)
+		}
+
+		if err := w.Reload(); err != nil {
+			t.Fatalf("Unexpected reload error: %v", err)
+		}
+		if svc.notMod != 1 {
+			t.Errorf("Expected an unchanged configuration to be answered with 304, got %d", svc.notMod)
+		}
+		if cfg := w.Config(); cfg.Port != 9000 {
+			t.Errorf("Expected cached port 9000, got %d", cfg.Port)
+		}
+
+		svc.set("port: 9001\n", `"v2"`)
+		if err := w.Reload(); err != nil {
+			t.Fatalf("Unexpected reload error: %v", err)
+		}
+		if cfg := w.Config(); cfg.Port != 9001 {
+			t.Errorf("Expected port 9001, got %d", cfg.Port)
+		}
+
+		// The last configuration is kept while the service is unreachable.
+		server.Close()
+		if err := w.Reload(); err != nil {
+			t.Fatalf("Unexpected reload error: %v", err)
+		}
+		if cfg := w.Config(); cfg.Port != 9001 {
+			t.Errorf("Expected last port 9001, got %d", cfg.Port)
+		}
+	})
+
+	t.Run("Fallback", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+
+		fallback := filepath.Join(t.TempDir(), "fallback.yaml")
+		writeConfig(t, fallback, "port: 7000\n")
+
+		cfg, err := New[testConfig](WithoutEnv(), WithHTTP(server.URL, HTTPFallback(fallback)))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if cfg.Port != 7000 {
+			t.Errorf("Expected port 7000 from the fallback, got %d", cfg.Port)
+		}
+
+		if _, err := New[testConfig](WithoutEnv(), WithHTTP(server.URL)); err == nil {
+			t.Error("Expected error without a fallback")
+		}
+	})
+
+	t.Run("Poll", func(t *testing.T) {
+		svc := &configService{body: `{"port": 9000}`, etag: `"v1"`}
+		server := httptest.NewServer(svc)
+		defer server.Close()
+
+		w, err := NewWatcher[testConfig](WithoutEnv(),
+			WithHTTP(server.URL, HTTPFormat("json"), HTTPPollInterval(10*time.Millisecond)),
+			WithWatch(),
+		)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		defer w.Close()
+
+		ch := w.Subscribe()
+		svc.set(`{"port": 9001}`, `"v2"`)
+
+		select {
+		case cfg := <-ch:
+			if cfg.Port != 9001 {
+				t.Errorf("Expected port 9001, got %d", cfg.Port)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Configuration change was not polled")
+		}
+	})
+}
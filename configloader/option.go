@@ -1,6 +1,8 @@
 package configloader
 
 import (
+	"fmt"
+	"io/fs"
 	"os"
 
 	"github.com/katalabut/fast-app/configloader/source"
@@ -27,6 +29,7 @@ func WithEnv(prefix string) Option {
 // WithFile adds configuration file support to the configuration loader.
 // It accepts multiple file paths and will use the first existing file.
 // Supported formats include JSON, YAML, TOML, and other formats supported by Viper.
+// YAML and JSON files encrypted with SOPS are decrypted, see WithSOPSDecrypter.
 func WithFile(paths ...string) Option {
 	return func(p *Parser) error {
 		src, err := source.NewFile(paths...)
@@ -37,6 +40,55 @@ func WithFile(paths ...string) Option {
 	}
 }
 
+// WithSOPSDecrypter sets the function decrypting configuration files encrypted
+// with SOPS. Encrypted YAML and JSON files are detected by their "sops"
+// metadata and decrypted with the sops binary by default; decrypt.File of
+// github.com/getsops/sops/v3/decrypt decrypts them without the binary:
+//
+//	configloader.WithSOPSDecrypter(decrypt.File)
+func WithSOPSDecrypter(decrypt source.DecryptFunc) Option {
+	return func(p *Parser) error {
+		p.decrypt = decrypt
+		return nil
+	}
+}
+
+// WithFS adds a configuration file read from a file system as the lowest
+// layer, typically a default configuration embedded in the binary, which
+// files, environment variables and the other sources override:
+//
+//	//go:embed config.default.yaml
+//	var defaultConfig embed.FS
+//
+//	cfg, err := configloader.New[AppConfig](
+//	    configloader.WithFS(defaultConfig, "config.default.yaml"),
+//	    configloader.WithFile("config.yaml"),
+//	)
+func WithFS(fsys fs.FS, path string) Option {
+	return func(p *Parser) error {
+		src, err := source.NewFS(fsys, path)
+		if err != nil {
+			return err
+		}
+		return p.SetSource(src)
+	}
+}
+
+// WithDir adds a directory of key files as a configuration source, the layout
+// of Kubernetes ConfigMap and Secret volumes: each file name is a key, e.g.
+// "database.password", and its content the value. Values from the directory
+// are merged over the configuration file. Combined with WithWatch, a Watcher
+// reloads the configuration when Kubernetes updates the volume.
+func WithDir(path string) Option {
+	return func(p *Parser) error {
+		src, err := source.NewDir(path)
+		if err != nil {
+			return err
+		}
+		return p.SetSource(src)
+	}
+}
+
 // WithFileFromEnv adds configuration file support with the file path taken from
 // the CONFIG_FILE environment variable. If the environment variable is not set,
 // it falls back to the provided paths. This is useful for containerized environments
@@ -50,3 +102,53 @@ func WithFileFromEnv(paths ...string) Option {
 
 	return WithFile(pathsState...)
 }
+
+// WithMap adds in-memory configuration values with the highest precedence,
+// overriding environment variables and files. Keys use the same dot-separated
+// paths as configuration files (e.g. "app.observability.port"), and nested maps
+// are accepted as well. Multiple WithMap and WithValues options are merged.
+//
+// This is mainly useful for tests and tools that need to build a configuration
+// programmatically without temporary files or mutating process environment.
+func WithMap(values map[string]interface{}) Option {
+	return func(p *Parser) error {
+		if src, ok := p.sources[source.MapSourceName].(*source.Map); ok {
+			src.Merge(values)
+			return nil
+		}
+
+		return p.SetSource(source.NewMap(values))
+	}
+}
+
+// WithValues is like WithMap but accepts alternating keys and values:
+//
+//	configloader.WithValues("app.logger.level", "debug", "app.observability.port", 0)
+func WithValues(kvs ...interface{}) Option {
+	return func(p *Parser) error {
+		if len(kvs)%2 != 0 {
+			return fmt.Errorf("odd number of key-value arguments: %d", len(kvs))
+		}
+
+		values := make(map[string]interface{}, len(kvs)/2)
+		for i := 0; i < len(kvs); i += 2 {
+			key, ok := kvs[i].(string)
+			if !ok {
+				return fmt.Errorf("key at position %d is not a string: %v", i, kvs[i])
+			}
+			values[key] = kvs[i+1]
+		}
+
+		return WithMap(values)(p)
+	}
+}
+
+// WithoutEnv removes the environment variable source, which New adds by default.
+// Combined with WithMap it gives tests a configuration that does not depend on
+// the process environment.
+func WithoutEnv() Option {
+	return func(p *Parser) error {
+		delete(p.sources, source.EnvSourceName)
+		return nil
+	}
+}
@@ -1,8 +1,11 @@
 package configloader
 
 import (
-	"github.com/creasty/defaults"
-	"github.com/mitchellh/mapstructure"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/katalabut/fast-app/configloader/source"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 )
@@ -10,6 +13,10 @@ import (
 type Parser struct {
 	viper   *viper.Viper
 	sources map[string]Source
+	watch   bool
+	strict  strictMode
+	profile string
+	decrypt source.DecryptFunc
 }
 
 type Source interface {
@@ -17,6 +24,14 @@ type Source interface {
 	Load(*viper.Viper) error
 }
 
+// Validator is implemented by configurations, or any struct nested in them,
+// that check themselves once defaults are applied, e.g. port ranges or mutually
+// exclusive flags. Parse returns the validation errors with their key paths, so
+// an invalid configuration is rejected on load and on reload.
+type Validator interface {
+	Validate() error
+}
+
 func NewParser(opts ...Option) (*Parser, error) {
 	p := &Parser{
 		viper:   viper.New(),
@@ -32,33 +47,86 @@ func NewParser(opts ...Option) (*Parser, error) {
 	return p, nil
 }
 
+// sourceOrder is the order sources are loaded in, each merged over the
+// values of the previous ones.
+var sourceOrder = []string{
+	source.FSSourceName,
+	source.FileSourceName,
+	source.DirSourceName,
+	source.HTTPSourceName,
+	source.EnvSourceName,
+	source.MapSourceName,
+}
+
 func (p *Parser) Parse(cfg interface{}) error {
-	for _, source := range p.sources {
-		if err := source.Load(p.viper); err != nil {
+	if file, ok := p.sources[source.FileSourceName].(*source.File); ok {
+		file.SetProfile(p.profile)
+		file.SetDecrypter(p.decrypt)
+	}
+
+	for _, name := range sourceOrder {
+		if src, ok := p.sources[name]; ok {
+			if err := src.Load(p.viper); err != nil {
+				return err
+			}
+		}
+	}
+	for name, src := range p.sources {
+		if slices.Contains(sourceOrder, name) {
+			continue
+		}
+		if err := src.Load(p.viper); err != nil {
 			return err
 		}
 	}
 
-	hooks := []mapstructure.DecodeHookFunc{
-		mapstructure.StringToTimeDurationHookFunc(),
-		mapstructure.StringToSliceHookFunc(","),
+	env, _ := p.sources[source.EnvSourceName].(*source.Env)
+	if env != nil {
+		if err := env.Bind(p.viper, configKeys(reflect.TypeOf(cfg), "")); err != nil {
+			return errors.Wrap(err, "failed to bind environment variables")
+		}
+	}
+
+	if p.strict != strictOff {
+		if err := p.checkUnknownKeys(cfg); err != nil {
+			return err
+		}
 	}
 
-	viperOpts := viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(hooks...))
+	viperOpts := viper.DecodeHook(decodeHooks())
 
 	if err := p.viper.Unmarshal(cfg, viperOpts); err != nil {
+		if verr := p.decodeErrors(cfg); verr != nil {
+			return verr
+		}
 		return err
 	}
 
-	if err := defaults.Set(cfg); err != nil {
-		return errors.Wrap(err, "failed to set defaults")
+	if err := setDefaults(cfg); err != nil {
+		return err
+	}
+	if p.profile != "" {
+		setProfile(cfg, p.profile)
 	}
 
-	// todo: validate cfg
+	if err := validateConfig(cfg, env); err != nil {
+		return err
+	}
 
 	return nil
 }
 
+// Reset discards all registered sources and loaded values, so the parser
+// can be configured again from scratch, e.g. between test cases.
+func (p *Parser) Reset() {
+	p.viper = viper.New()
+	p.sources = make(map[string]Source)
+	p.watch = false
+	p.strict = strictOff
+	p.profile = ""
+	p.decrypt = nil
+}
+
 func (p *Parser) SetSource(s Source) error {
 	if s == nil {
 		return errors.New("empty source")
@@ -68,3 +136,38 @@ func (p *Parser) SetSource(s Source) error {
 
 	return nil
 }
+
+// configKeys returns the dot-separated keys of the leaf fields of a
+// configuration type, e.g. "database.password".
+func configKeys(t reflect.Type, prefix string) []string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if !isSection(t) {
+		if prefix == "" {
+			return nil
+		}
+		return []string{prefix}
+	}
+
+	var keys []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(f.Tag.Get("mapstructure"), ",")
+		switch {
+		case name == "-":
+			continue
+		case opts == "squash":
+			keys = append(keys, configKeys(f.Type, prefix)...)
+			continue
+		case name == "":
+			name = f.Name
+		}
+		keys = append(keys, configKeys(f.Type, joinPath(prefix, strings.ToLower(name)))...)
+	}
+	return keys
+}
@@ -0,0 +1,71 @@
+package configloader
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/katalabut/fast-app/config"
+)
+
+const (
+	envProfile = "APP_PROFILE"
+)
+
+// WithProfile selects the configuration profile, e.g. "dev", "stage" or
+// "prod". The profile file next to the configuration file, config.prod.yaml
+// for config.yaml, is layered over it when it exists, so each environment
+// only overrides the keys that differ. The APP_PROFILE environment variable,
+// when set, takes precedence over name, so the same binary runs with a
+// different profile per environment; WithProfile("") only reads it.
+//
+// The active profile is stored in the Profile field of the config.App the
+// configuration contains, for the application to log and report it.
+func WithProfile(name string) Option {
+	if profile := os.Getenv(envProfile); profile != "" {
+		name = profile
+	}
+
+	return func(p *Parser) error {
+		if strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+			return fmt.Errorf("invalid profile: %q", name)
+		}
+		p.profile = name
+		return nil
+	}
+}
+
+var appConfigType = reflect.TypeOf(config.App{})
+
+// setProfile sets the Profile field of the config.App contained in cfg, cfg
+// itself or a top-level field, unless it is already set.
+func setProfile(cfg interface{}, profile string) {
+	v := reflect.ValueOf(cfg)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+
+	if v.Type() != appConfigType {
+		if v.Kind() != reflect.Struct {
+			return
+		}
+		found := false
+		for i := 0; i < v.NumField(); i++ {
+			if v.Field(i).Type() == appConfigType && v.Field(i).CanSet() {
+				v, found = v.Field(i), true
+				break
+			}
+		}
+		if !found {
+			return
+		}
+	}
+
+	if app := v.Addr().Interface().(*config.App); app.Profile == "" {
+		app.Profile = profile
+	}
+}
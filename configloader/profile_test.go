@@ -0,0 +1,86 @@
+package configloader
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/katalabut/fast-app/config"
+)
+
+type profileConfig struct {
+	App      config.App
+	Database struct {
+		Host string `default:"localhost"`
+		Pool int    `default:"5"`
+	}
+}
+
+func TestProfile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	writeConfig(t, path, "database:\n  host: db.internal\n  pool: 10\n")
+	writeConfig(t, filepath.Join(dir, "config.prod.yaml"), "database:\n  pool: 50\n")
+
+	t.Run("LayersProfileFile", func(t *testing.T) {
+		cfg, err := New[profileConfig](WithoutEnv(), WithFile(path), WithProfile("prod"))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if cfg.Database.Host != "db.internal" {
+			t.Errorf("Expected host from the base file, got %q", cfg.Database.Host)
+		}
+		if cfg.Database.Pool != 50 {
+			t.Errorf("Expected pool 50 from the profile file, got %d", cfg.Database.Pool)
+		}
+		if cfg.App.Profile != "prod" {
+			t.Errorf("Expected profile prod, got %q", cfg.App.Profile)
+		}
+	})
+
+	t.Run("MissingProfileFile", func(t *testing.T) {
+		cfg, err := New[profileConfig](WithoutEnv(), WithProfile("dev"), WithFile(path))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if cfg.Database.Pool != 10 {
+			t.Errorf("Expected pool 10 from the base file, got %d", cfg.Database.Pool)
+		}
+		if cfg.App.Profile != "dev" {
+			t.Errorf("Expected profile dev, got %q", cfg.App.Profile)
+		}
+	})
+
+	t.Run("EnvironmentVariable", func(t *testing.T) {
+		t.Setenv("APP_PROFILE", "prod")
+
+		cfg, err := New[profileConfig](WithoutEnv(), WithFile(path), WithProfile("dev"))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if cfg.Database.Pool != 50 || cfg.App.Profile != "prod" {
+			t.Errorf("Expected the prod profile, got %q with pool %d", cfg.App.Profile, cfg.Database.Pool)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		if _, err := New[profileConfig](WithoutEnv(), WithFile(path), WithProfile("../prod")); err == nil {
+			t.Error("Expected error for a profile with a path")
+		}
+	})
+
+	t.Run("Watcher", func(t *testing.T) {
+		w, err := NewWatcher[profileConfig](WithoutEnv(), WithFile(path), WithProfile("prod"))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		defer w.Close()
+
+		writeConfig(t, filepath.Join(dir, "config.prod.yaml"), "database:\n  pool: 80\n")
+		if err := w.Reload(); err != nil {
+			t.Fatalf("Unexpected reload error: %v", err)
+		}
+		if pool := w.Config().Database.Pool; pool != 80 {
+			t.Errorf("Expected pool 80 after reload, got %d", pool)
+		}
+	})
+}
@@ -0,0 +1,75 @@
+package configloader
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/katalabut/fast-app/config"
+)
+
+const sopsEncrypted = `database:
+    password: ENC[AES256_GCM,data:p673w==,iv:YY=,tag:UQ=,type:str]
+sops:
+    age:
+        - recipient: age1yt3tfqlfrwdwx0z0ynwplcr6qxcxfaqycuprpmy89nr83ltx74tqdpszlw
+    lastmodified: "2024-05-01T10:00:00Z"
+    mac: ENC[AES256_GCM,data:Tm9wZQ==,iv:YY=,tag:UQ=,type:str]
+    version: 3.8.1
+`
+
+type sopsConfig struct {
+	Database struct {
+		Password config.Secret
+	}
+}
+
+func TestSOPS(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, sopsEncrypted)
+
+	t.Run("Decrypts", func(t *testing.T) {
+		var decrypted []string
+		decrypt := func(p, format string) ([]byte, error) {
+			decrypted = append(decrypted, filepath.Base(p)+":"+format)
+			return []byte("database:\n  password: hunter2\n"), nil
+		}
+
+		cfg, err := New[sopsConfig](WithoutEnv(), WithFile(path), WithSOPSDecrypter(decrypt))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if cfg.Database.Password.Reveal() != "hunter2" {
+			t.Errorf("Expected decrypted password, got %q", cfg.Database.Password.Reveal())
+		}
+		if len(decrypted) != 1 || decrypted[0] != "config.yaml:yaml" {
+			t.Errorf("Expected config.yaml to be decrypted as yaml, got %v", decrypted)
+		}
+	})
+
+	t.Run("PlainFile", func(t *testing.T) {
+		plain := filepath.Join(t.TempDir(), "config.yaml")
+		writeConfig(t, plain, "database:\n  password: plain\n")
+
+		decrypt := func(string, string) ([]byte, error) {
+			t.Error("Expected a plain file not to be decrypted")
+			return nil, nil
+		}
+		cfg, err := New[sopsConfig](WithoutEnv(), WithFile(plain), WithSOPSDecrypter(decrypt))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if cfg.Database.Password.Reveal() != "plain" {
+			t.Errorf("Expected plain password, got %q", cfg.Database.Password.Reveal())
+		}
+	})
+
+	t.Run("MissingBinary", func(t *testing.T) {
+		t.Setenv("PATH", t.TempDir())
+
+		_, err := New[sopsConfig](WithoutEnv(), WithFile(path))
+		if err == nil || !strings.Contains(err.Error(), "sops binary was not found") {
+			t.Errorf("Expected missing sops binary error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,91 @@
+package source
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+const DirSourceName = "dir"
+
+// Dir is a source reading a directory of key files, the layout of Kubernetes
+// ConfigMap and Secret volumes. Each file name is a key, with dots separating
+// nested keys, e.g. "database.password", and the file content, without its
+// trailing newline, is the value. Hidden entries, such as the "..data" link
+// Kubernetes swaps on update, are skipped.
+type Dir struct {
+	path string
+}
+
+// NewDir creates a new Dir source. It returns an error if the path is not a directory.
+func NewDir(path string) (*Dir, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(absPath)
+	if err != nil {
+		return nil, fmt.Errorf("directory not found: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("not a directory: %s", absPath)
+	}
+
+	return &Dir{path: absPath}, nil
+}
+
+func (d *Dir) Name() string {
+	return DirSourceName
+}
+
+// Path returns the absolute path of the directory.
+func (d *Dir) Path() string {
+	return d.path
+}
+
+func (d *Dir) Load(v *viper.Viper) error {
+	entries, err := os.ReadDir(d.path)
+	if err != nil {
+		return fmt.Errorf("failed to read directory: %w", err)
+	}
+
+	values := make(map[string]interface{})
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, ".") {
+			continue
+		}
+
+		// Kubernetes mounts the keys as links, so entries are stat'ed
+		// rather than checked with entry.Type.
+		path := filepath.Join(d.path, name)
+		info, err := os.Stat(path)
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		setNested(values, strings.Split(strings.ToLower(name), "."), strings.TrimRight(string(content), "\r\n"))
+	}
+
+	return v.MergeConfigMap(values)
+}
+
+func setNested(values map[string]interface{}, keys []string, value string) {
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := values[key].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			values[key] = next
+		}
+		values = next
+	}
+	values[keys[len(keys)-1]] = value
+}
@@ -28,3 +28,26 @@ func (e *Env) Load(v *viper.Viper) error {
 
 	return nil
 }
+
+// Bind makes each of the keys, e.g. "database.password", read from its
+// environment variable. Viper only looks up environment variables of keys it
+// already knows, so keys set nowhere else have to be bound explicitly.
+func (e *Env) Bind(v *viper.Viper, keys []string) error {
+	for _, key := range keys {
+		if err := v.BindEnv(key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// VarName returns the environment variable of a key, e.g. APP_DATABASE_PASSWORD
+// for "database.password" with prefix "APP".
+func (e *Env) VarName(key string) string {
+	if e.prefix != "" {
+		key = e.prefix + "_" + key
+	}
+
+	return strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
@@ -0,0 +1,27 @@
+package source
+
+import (
+	"os"
+	"regexp"
+)
+
+// envReference matches ${NAME} and ${NAME:-default}, optionally escaped as $${NAME}.
+var envReference = regexp.MustCompile(`\$?\$\{([A-Za-z_][A-Za-z0-9_]*)(?::-([^}]*))?\}`)
+
+// ExpandEnv replaces ${NAME} with the value of the environment variable NAME
+// and ${NAME:-default} with default if NAME is unset or empty. $${NAME} is
+// kept as the literal ${NAME}. Bare $NAME is left as is, since it commonly
+// appears in passwords and templates.
+func ExpandEnv(s string) string {
+	return envReference.ReplaceAllStringFunc(s, func(ref string) string {
+		if ref[1] == '$' {
+			return ref[1:]
+		}
+
+		m := envReference.FindStringSubmatch(ref)
+		if value := os.Getenv(m[1]); value != "" {
+			return value
+		}
+		return m[2]
+	})
+}
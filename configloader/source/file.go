@@ -13,7 +13,9 @@ import (
 const FileSourceName = "file"
 
 type File struct {
-	path string
+	path    string
+	profile string
+	decrypt DecryptFunc
 }
 
 // NewFile creates a new File instance from the provided paths.
@@ -54,6 +56,33 @@ func (f *File) Name() string {
 	return FileSourceName
 }
 
+// Path returns the absolute path of the configuration file.
+func (f *File) Path() string {
+	return f.path
+}
+
+// SetProfile layers the profile file, e.g. config.prod.yaml for the profile
+// "prod" and the file config.yaml, over the file when it exists.
+func (f *File) SetProfile(profile string) {
+	f.profile = profile
+}
+
+// SetDecrypter sets the function decrypting SOPS-encrypted files, DecryptSOPS
+// if nil.
+func (f *File) SetDecrypter(decrypt DecryptFunc) {
+	f.decrypt = decrypt
+}
+
+// ProfilePath returns the absolute path of the profile file, whether it
+// exists or not, or an empty string without a profile.
+func (f *File) ProfilePath() string {
+	if f.profile == "" {
+		return ""
+	}
+	ext := filepath.Ext(f.path)
+	return strings.TrimSuffix(f.path, ext) + "." + f.profile + ext
+}
+
 func (f *File) Load(v *viper.Viper) error {
 	ext := strings.TrimLeft(strings.ToLower(path.Ext(f.path)), ".")
 	// viper.SupportedExts
@@ -70,13 +99,38 @@ func (f *File) Load(v *viper.Viper) error {
 		return fmt.Errorf("unsupported file type: %s", ext)
 	}
 
+	// Files encrypted with SOPS are decrypted, so they can be kept in git.
+	content, err := readConfigFile(f.path, ext, f.decrypt)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	// Environment variables referenced as ${NAME} or ${NAME:-default} are
+	// expanded, so secrets injected into the environment can be used in files.
 	v.SetConfigFile(f.path)
 	v.SetConfigType(ext)
 
-	if err := v.ReadInConfig(); err != nil {
+	// Merged rather than read, so the values of a WithFS default
+	// configuration the file does not set are kept.
+	if err := v.MergeConfig(strings.NewReader(ExpandEnv(string(content)))); err != nil {
 		return fmt.Errorf("failed to read file: %w", err)
 	}
 
+	profilePath := f.ProfilePath()
+	if profilePath == "" {
+		return nil
+	}
+	content, err = readConfigFile(profilePath, ext, f.decrypt)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read profile file: %w", err)
+	}
+	if err := v.MergeConfig(strings.NewReader(ExpandEnv(string(content)))); err != nil {
+		return fmt.Errorf("failed to read profile file %s: %w", filepath.Base(profilePath), err)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,54 @@
+package source
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+const FSSourceName = "fs"
+
+// FS is a source reading a configuration file from a file system, typically
+// a default configuration embedded in the binary with //go:embed.
+type FS struct {
+	fsys fs.FS
+	path string
+}
+
+// NewFS creates a new FS source reading the file at path in fsys. It returns
+// an error if the file does not exist or its format is not supported.
+func NewFS(fsys fs.FS, path string) (*FS, error) {
+	if fsys == nil {
+		return nil, fmt.Errorf("file system is required")
+	}
+	if _, err := fs.Stat(fsys, path); err != nil {
+		return nil, fmt.Errorf("file not found: %w", err)
+	}
+	if formatOf(path, "") == "" {
+		return nil, fmt.Errorf("unsupported file type: %s", path)
+	}
+
+	return &FS{fsys: fsys, path: path}, nil
+}
+
+func (f *FS) Name() string {
+	return FSSourceName
+}
+
+func (f *FS) Load(v *viper.Viper) error {
+	content, err := fs.ReadFile(f.fsys, f.path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", f.path, err)
+	}
+
+	tmp := viper.New()
+	tmp.SetConfigType(strings.TrimPrefix(strings.ToLower(path.Ext(f.path)), "."))
+	if err := tmp.ReadConfig(strings.NewReader(ExpandEnv(string(content)))); err != nil {
+		return fmt.Errorf("failed to read %s: %w", f.path, err)
+	}
+
+	return v.MergeConfigMap(tmp.AllSettings())
+}
@@ -0,0 +1,198 @@
+package source
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+const HTTPSourceName = "http"
+
+// Defaults of HTTPConfig.
+const (
+	DefaultHTTPTimeout      = 10 * time.Second
+	DefaultHTTPPollInterval = 30 * time.Second
+)
+
+// HTTPConfig configures an HTTP source.
+type HTTPConfig struct {
+	// Header is sent with every request, e.g. for authentication.
+	Header http.Header
+	// Format of the configuration, e.g. "json" or "yaml". Detected from the
+	// Content-Type header or the URL extension if empty, JSON otherwise.
+	Format string
+	// Fallback is a local file read when the service is unreachable and no
+	// configuration has been fetched yet.
+	Fallback string
+	// Timeout of a request, DefaultHTTPTimeout if zero.
+	Timeout time.Duration
+	// PollInterval is how often a watcher polls the service, DefaultHTTPPollInterval if zero.
+	PollInterval time.Duration
+	// Client sends the requests, a client with Timeout if nil.
+	Client *http.Client
+}
+
+// HTTP is a source fetching a JSON or YAML configuration from a configuration
+// service. The entity tag of the last response is sent with the next request,
+// so an unchanged configuration is answered with 304 Not Modified and the
+// cached one is used.
+type HTTP struct {
+	url    string
+	config HTTPConfig
+
+	mu     sync.Mutex
+	etag   string
+	body   []byte
+	format string
+}
+
+// NewHTTP creates a new HTTP source fetching the configuration from url.
+func NewHTTP(url string, config HTTPConfig) (*HTTP, error) {
+	if url == "" {
+		return nil, fmt.Errorf("url is required")
+	}
+	if config.Timeout == 0 {
+		config.Timeout = DefaultHTTPTimeout
+	}
+	if config.PollInterval == 0 {
+		config.PollInterval = DefaultHTTPPollInterval
+	}
+	if config.Client == nil {
+		config.Client = &http.Client{Timeout: config.Timeout}
+	}
+
+	return &HTTP{url: url, config: config}, nil
+}
+
+func (h *HTTP) Name() string {
+	return HTTPSourceName
+}
+
+// PollInterval returns how often the service should be polled for changes.
+func (h *HTTP) PollInterval() time.Duration {
+	return h.config.PollInterval
+}
+
+func (h *HTTP) Load(v *viper.Viper) error {
+	body, format, err := h.fetch()
+	if err != nil {
+		if body, format, err = h.fallback(err); err != nil {
+			return err
+		}
+	}
+
+	tmp := viper.New()
+	tmp.SetConfigType(format)
+	if err := tmp.ReadConfig(bytes.NewReader(body)); err != nil {
+		return fmt.Errorf("failed to parse configuration from %s: %w", h.url, err)
+	}
+
+	return v.MergeConfigMap(tmp.AllSettings())
+}
+
+// fetch returns the current configuration of the service, the cached one if
+// it has not changed.
+func (h *HTTP) fetch() ([]byte, string, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	req, err := http.NewRequest(http.MethodGet, h.url, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create request: %w", err)
+	}
+	for key, values := range h.config.Header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	if h.etag != "" {
+		req.Header.Set("If-None-Match", h.etag)
+	}
+
+	resp, err := h.config.Client.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch configuration from %s: %w", h.url, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && h.body != nil:
+		return h.body, h.format, nil
+	case resp.StatusCode != http.StatusOK:
+		return nil, "", fmt.Errorf("failed to fetch configuration from %s: %s", h.url, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read configuration from %s: %w", h.url, err)
+	}
+
+	h.etag = resp.Header.Get("ETag")
+	h.body = body
+	h.format = h.detectFormat(resp.Header.Get("Content-Type"))
+	return h.body, h.format, nil
+}
+
+// fallback returns the last fetched configuration, or the fallback file if
+// nothing has been fetched yet, when the service is unreachable.
+func (h *HTTP) fallback(fetchErr error) ([]byte, string, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.body != nil {
+		return h.body, h.format, nil
+	}
+	if h.config.Fallback == "" {
+		return nil, "", fetchErr
+	}
+
+	body, err := os.ReadFile(h.config.Fallback)
+	if err != nil {
+		return nil, "", fmt.Errorf("%v, and failed to read fallback: %w", fetchErr, err)
+	}
+	return body, formatOf(h.config.Fallback, "yaml"), nil
+}
+
+func (h *HTTP) detectFormat(contentType string) string {
+	if h.config.Format != "" {
+		return h.config.Format
+	}
+
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		switch {
+		case strings.HasSuffix(mediaType, "json"):
+			return "json"
+		case strings.HasSuffix(mediaType, "yaml"):
+			return "yaml"
+		case strings.HasSuffix(mediaType, "toml"):
+			return "toml"
+		}
+	}
+
+	return formatOf(h.url, "json")
+}
+
+// formatOf returns the configuration format of a file name or URL by its
+// extension, or def if it has none supported.
+func formatOf(name, def string) string {
+	if i := strings.IndexAny(name, "?#"); i >= 0 {
+		name = name[:i]
+	}
+
+	ext := strings.TrimPrefix(strings.ToLower(path.Ext(name)), ".")
+	for _, e := range viper.SupportedExts {
+		if e == ext {
+			return ext
+		}
+	}
+	return def
+}
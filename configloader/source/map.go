@@ -0,0 +1,77 @@
+package source
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+const MapSourceName = "map"
+
+// Map is a source of in-memory configuration values. Values set by this source
+// take precedence over environment variables and configuration files.
+type Map struct {
+	values map[string]interface{}
+}
+
+// NewMap creates a new Map source from the provided values.
+// Nested maps are flattened into dot-separated keys, so both
+// {"observability": {"port": 0}} and {"observability.port": 0} are accepted.
+func NewMap(values map[string]interface{}) *Map {
+	m := &Map{values: make(map[string]interface{})}
+	m.Merge(values)
+
+	return m
+}
+
+// Merge adds values to the source, overwriting existing keys.
+func (m *Map) Merge(values map[string]interface{}) {
+	flatten("", values, m.values)
+}
+
+func (m *Map) Name() string {
+	return MapSourceName
+}
+
+func (m *Map) Load(v *viper.Viper) error {
+	for key, value := range m.values {
+		v.Set(key, value)
+	}
+
+	return nil
+}
+
+// Has reports whether the source sets the key, or a key nested in it.
+func (m *Map) Has(key string) bool {
+	key = strings.ToLower(key)
+	for k := range m.values {
+		if k == key || strings.HasPrefix(k, key+".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func flatten(prefix string, in map[string]interface{}, out map[string]interface{}) {
+	for key, value := range in {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		key = strings.ToLower(key)
+
+		switch nested := value.(type) {
+		case map[string]interface{}:
+			flatten(key, nested, out)
+		case map[interface{}]interface{}:
+			converted := make(map[string]interface{}, len(nested))
+			for k, v := range nested {
+				converted[fmt.Sprint(k)] = v
+			}
+			flatten(key, converted, out)
+		default:
+			out[key] = value
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package source
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DecryptFunc decrypts a SOPS-encrypted file in the given format, "yaml" or
+// "json", returning its plain content. The signature matches decrypt.File of
+// github.com/getsops/sops/v3/decrypt, which can be used instead of the binary.
+type DecryptFunc func(path, format string) ([]byte, error)
+
+// sopsBinary is the command DecryptSOPS runs.
+const sopsBinary = "sops"
+
+// DecryptSOPS decrypts a file with the sops binary found in PATH, using the
+// keys it is configured with, e.g. an age key in SOPS_AGE_KEY_FILE or the KMS
+// credentials of the pod.
+func DecryptSOPS(path, format string) ([]byte, error) {
+	bin, err := exec.LookPath(sopsBinary)
+	if err != nil {
+		return nil, fmt.Errorf("file is encrypted with SOPS, but the sops binary was not found: %w", err)
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.Command(bin, "--decrypt", "--input-type", format, "--output-type", format, path)
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("failed to decrypt with sops: %s", msg)
+		}
+		return nil, fmt.Errorf("failed to decrypt with sops: %w", err)
+	}
+	return out, nil
+}
+
+// isSOPSEncrypted reports whether a YAML or JSON file has the metadata SOPS
+// adds to the files it encrypts.
+func isSOPSEncrypted(content []byte, format string) bool {
+	var doc struct {
+		SOPS *struct {
+			MAC string `json:"mac" yaml:"mac"`
+		} `json:"sops" yaml:"sops"`
+	}
+
+	var err error
+	switch format {
+	case "yaml", "yml":
+		err = yaml.Unmarshal(content, &doc)
+	case "json":
+		err = json.Unmarshal(content, &doc)
+	default:
+		return false
+	}
+	return err == nil && doc.SOPS != nil && doc.SOPS.MAC != ""
+}
+
+// readConfigFile reads a configuration file, decrypting it if it is
+// encrypted with SOPS.
+func readConfigFile(path, format string, decrypt DecryptFunc) ([]byte, error) {
+	content, err := os.ReadFile(path)
+	if err != nil || !isSOPSEncrypted(content, format) {
+		return content, err
+	}
+
+	if decrypt == nil {
+		decrypt = DecryptSOPS
+	}
+	if format == "yml" {
+		format = "yaml"
+	}
+	content, err = decrypt(path, format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt %s: %w", path, err)
+	}
+	return content, nil
+}
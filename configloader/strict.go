@@ -0,0 +1,76 @@
+package configloader
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/katalabut/fast-app/logger"
+)
+
+type strictMode int
+
+const (
+	strictOff strictMode = iota
+	strictWarn
+	strictError
+)
+
+// WithStrict makes parsing fail when a source, e.g. the configuration file,
+// contains keys that do not map to any field of the configuration, catching
+// typos like "observabilty.port" that would otherwise silently fall back to
+// defaults. The error is a *ValidationError listing every unknown key.
+func WithStrict() Option {
+	return func(p *Parser) error {
+		p.strict = strictError
+		return nil
+	}
+}
+
+// WithStrictWarn is like WithStrict but logs a warning for the unknown keys
+// instead of failing.
+func WithStrictWarn() Option {
+	return func(p *Parser) error {
+		p.strict = strictWarn
+		return nil
+	}
+}
+
+// checkUnknownKeys reports the loaded keys not mapping to a field of cfg.
+func (p *Parser) checkUnknownKeys(cfg interface{}) error {
+	known := configKeys(reflect.TypeOf(cfg), "")
+
+	var unknown []string
+	for _, key := range p.viper.AllKeys() {
+		if !isKnownKey(key, known) {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+
+	if p.strict == strictWarn {
+		logger.WarnKV(context.Background(), "Unknown configuration keys", "keys", unknown)
+		return nil
+	}
+
+	verr := &ValidationError{}
+	for _, key := range unknown {
+		verr.Fields = append(verr.Fields, FieldError{Path: key, Message: "is not a known configuration key"})
+	}
+	return verr
+}
+
+// isKnownKey reports whether a key is a field of the configuration or nested
+// in one, e.g. an entry of a map field.
+func isKnownKey(key string, known []string) bool {
+	for _, k := range known {
+		if key == k || strings.HasPrefix(key, k+".") {
+			return true
+		}
+	}
+	return false
+}
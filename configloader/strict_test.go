@@ -0,0 +1,51 @@
+package configloader
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+type strictConfig struct {
+	Observability struct {
+		Port int `default:"9090"`
+	}
+	Headers map[string]string
+}
+
+func TestWithStrict(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, "observabilty:\n  port: 8080\nheaders:\n  x-team: payments\nverbose: true\n")
+
+	t.Run("UnknownKeys", func(t *testing.T) {
+		_, err := New[strictConfig](WithoutEnv(), WithFile(path), WithStrict())
+
+		var verr *ValidationError
+		if !errors.As(err, &verr) {
+			t.Fatalf("Expected *ValidationError, got %v", err)
+		}
+		if len(verr.Fields) != 2 || verr.Fields[0].Path != "observabilty.port" || verr.Fields[1].Path != "verbose" {
+			t.Errorf("Expected unknown keys observabilty.port and verbose, got %v", verr.Fields)
+		}
+	})
+
+	t.Run("Warn", func(t *testing.T) {
+		cfg, err := New[strictConfig](WithoutEnv(), WithFile(path), WithStrictWarn())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if cfg.Observability.Port != 9090 || cfg.Headers["x-team"] != "payments" {
+			t.Errorf("Expected default port and headers, got %+v", cfg)
+		}
+	})
+
+	t.Run("KnownKeys", func(t *testing.T) {
+		_, err := New[strictConfig](WithoutEnv(), WithStrict(), WithValues(
+			"observability.port", 8080,
+			"headers.x-team", "payments",
+		))
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+}
@@ -0,0 +1,252 @@
+package configloader
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/katalabut/fast-app/configloader/source"
+	"github.com/pkg/errors"
+)
+
+// FieldError is an invalid configuration value.
+type FieldError struct {
+	// Path is the dot-separated key path of the field, e.g. "App.Observability.Port",
+	// or empty for an error of the configuration as a whole.
+	Path string
+	// Message describes why the value is invalid.
+	Message string
+}
+
+// ValidationError lists every invalid field of a configuration.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		msgs[i] = f.Message
+		if f.Path != "" {
+			msgs[i] = f.Path + " " + f.Message
+		}
+	}
+	return "invalid configuration: " + strings.Join(msgs, "; ")
+}
+
+var validate = newValidate()
+
+func newValidate() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	// Report fields by their configuration key rather than their Go name.
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		name, _, _ := strings.Cut(f.Tag.Get("mapstructure"), ",")
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return f.Name
+		}
+		return name
+	})
+	return v
+}
+
+// validateConfig checks the fields tagged `required:"true"` are set and the
+// `validate` struct tags of cfg, e.g. `validate:"required,min=1,url"`, and
+// calls Validate on cfg and every nested value implementing Validator,
+// returning a *ValidationError listing every invalid field.
+func validateConfig(cfg interface{}, env *source.Env) error {
+	verr := &ValidationError{}
+	checkRequired(reflect.ValueOf(cfg), "", env, verr)
+	if err := validateTags(cfg, verr); err != nil {
+		return err
+	}
+	validateMethods(reflect.ValueOf(cfg), "", verr)
+
+	if len(verr.Fields) == 0 {
+		return nil
+	}
+	return verr
+}
+
+func validateTags(cfg interface{}, verr *ValidationError) error {
+	err := validate.Struct(cfg)
+	if err == nil {
+		return nil
+	}
+
+	var invalid *validator.InvalidValidationError
+	if errors.As(err, &invalid) {
+		// Not a struct, there are no tags to check.
+		return nil
+	}
+
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return errors.Wrap(err, "failed to validate configuration")
+	}
+
+	for _, fe := range fieldErrs {
+		// The namespace starts with the name of the configuration type.
+		_, path, _ := strings.Cut(fe.Namespace(), ".")
+		verr.Fields = append(verr.Fields, FieldError{Path: path, Message: tagMessage(fe)})
+	}
+	return nil
+}
+
+// checkRequired reports the fields tagged `required:"true"` still zero once
+// defaults are applied, naming the key and the environment variable to set.
+func checkRequired(v reflect.Value, path string, env *source.Env, verr *ValidationError) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return
+	}
+
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(f.Tag.Get("mapstructure"), ",")
+		fieldPath := path
+		switch {
+		case name == "-":
+			continue
+		case opts == "squash":
+		case name == "":
+			fieldPath = joinPath(path, f.Name)
+		default:
+			fieldPath = joinPath(path, name)
+		}
+
+		if f.Tag.Get("required") == "true" && v.Field(i).IsZero() {
+			msg := "is required, set the " + fieldPath + " key"
+			if env != nil {
+				msg += " or the " + env.VarName(strings.ToLower(fieldPath)) + " environment variable"
+			}
+			verr.Fields = append(verr.Fields, FieldError{Path: fieldPath, Message: msg})
+			continue
+		}
+		checkRequired(v.Field(i), fieldPath, env, verr)
+	}
+}
+
+var validatorType = reflect.TypeOf((*Validator)(nil)).Elem()
+
+// validateMethods walks the configuration tree depth-first, calling Validate
+// on every value implementing Validator with its key path.
+func validateMethods(v reflect.Value, path string, verr *ValidationError) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		if v.Kind() == reflect.Ptr && v.Type().Implements(validatorType) {
+			break
+		}
+		v = v.Elem()
+	}
+
+	if v.CanInterface() {
+		target := v
+		if v.Kind() != reflect.Ptr && v.CanAddr() && v.Addr().Type().Implements(validatorType) {
+			target = v.Addr()
+		}
+		if val, ok := target.Interface().(Validator); ok {
+			addValidateError(verr, path, val.Validate())
+		}
+	}
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			name, _, _ := strings.Cut(f.Tag.Get("mapstructure"), ",")
+			switch {
+			case name == "-":
+				continue
+			case name == "" && f.Anonymous:
+				// Embedded fields share the path of their parent.
+				validateMethods(v.Field(i), path, verr)
+				continue
+			case name == "":
+				name = f.Name
+			}
+			validateMethods(v.Field(i), joinPath(path, name), verr)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			validateMethods(v.Index(i), fmt.Sprintf("%s[%d]", path, i), verr)
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			validateMethods(iter.Value(), joinPath(path, fmt.Sprint(iter.Key().Interface())), verr)
+		}
+	}
+}
+
+func addValidateError(verr *ValidationError, path string, err error) {
+	if err == nil {
+		return
+	}
+
+	var nested *ValidationError
+	if errors.As(err, &nested) {
+		for _, f := range nested.Fields {
+			verr.Fields = append(verr.Fields, FieldError{Path: joinPath(path, f.Path), Message: f.Message})
+		}
+		return
+	}
+	verr.Fields = append(verr.Fields, FieldError{Path: path, Message: err.Error()})
+}
+
+func joinPath(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	if name == "" {
+		return parent
+	}
+	return parent + "." + name
+}
+
+func tagMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "min", "gte":
+		return "must be at least " + fe.Param()
+	case "max", "lte":
+		return "must be at most " + fe.Param()
+	case "gt":
+		return "must be greater than " + fe.Param()
+	case "lt":
+		return "must be less than " + fe.Param()
+	case "oneof":
+		return "must be one of " + fe.Param()
+	case "url":
+		return "must be a valid URL"
+	case "hostname_port":
+		return "must be a host:port address"
+	}
+	if fe.Param() != "" {
+		return fmt.Sprintf("failed the %s=%s validation", fe.Tag(), fe.Param())
+	}
+	return fmt.Sprintf("failed the %s validation", fe.Tag())
+}
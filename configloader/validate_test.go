@@ -0,0 +1,245 @@
+package configloader
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type validatedConfig struct {
+	Name     string `validate:"required"`
+	Port     int    `default:"8080" validate:"min=1,max=65535"`
+	Endpoint string `mapstructure:"endpoint_url" validate:"omitempty,url"`
+	Database struct {
+		Host string `validate:"required"`
+	}
+}
+
+func TestValidateTags(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		cfg, err := New[validatedConfig](WithoutEnv(), WithValues(
+			"name", "api",
+			"database.host", "db",
+			"endpoint_url", "https://example.com",
+		))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if cfg.Port != 8080 {
+			t.Errorf("Expected default port 8080, got %d", cfg.Port)
+		}
+	})
+
+	t.Run("ListsEveryInvalidField", func(t *testing.T) {
+		_, err := New[validatedConfig](WithoutEnv(), WithValues(
+			"port", 70000,
+			"endpoint_url", "not a url",
+		))
+
+		var verr *ValidationError
+		if !errors.As(err, &verr) {
+			t.Fatalf("Expected *ValidationError, got %v", err)
+		}
+
+		got := make(map[string]string)
+		for _, f := range verr.Fields {
+			got[f.Path] = f.Message
+		}
+		expected := map[string]string{
+			"Name":          "is required",
+			"Port":          "must be at most 65535",
+			"endpoint_url":  "must be a valid URL",
+			"Database.Host": "is required",
+		}
+		for path, msg := range expected {
+			if got[path] != msg {
+				t.Errorf("Expected %s %q, got %q", path, msg, got[path])
+			}
+		}
+		if len(verr.Fields) != len(expected) {
+			t.Errorf("Expected %d invalid fields, got %v", len(expected), verr.Fields)
+		}
+	})
+}
+
+type portRange struct {
+	From int
+	To   int
+}
+
+func (r portRange) Validate() error {
+	if r.From > r.To {
+		return errors.New("must not end before it starts")
+	}
+	return nil
+}
+
+type tlsConfig struct {
+	Enabled  bool
+	Insecure bool
+}
+
+func (c *tlsConfig) Validate() error {
+	if c.Enabled && c.Insecure {
+		return errors.New("enabled and insecure are mutually exclusive")
+	}
+	return nil
+}
+
+type methodConfig struct {
+	Ports    portRange
+	TLS      tlsConfig
+	Backends []portRange
+	Port     int `validate:"min=1"`
+}
+
+func (c methodConfig) Validate() error {
+	if c.Port == 0 && len(c.Backends) == 0 {
+		return errors.New("port or backends must be set")
+	}
+	return nil
+}
+
+func TestValidateMethods(t *testing.T) {
+	_, err := New[methodConfig](WithoutEnv(), WithMap(map[string]interface{}{
+		"ports": map[string]interface{}{"from": 9000, "to": 8000},
+		"tls":   map[string]interface{}{"enabled": true, "insecure": true},
+		"backends": []interface{}{
+			map[string]interface{}{"from": 1, "to": 2},
+			map[string]interface{}{"from": 3, "to": 2},
+		},
+	}))
+
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("Expected *ValidationError, got %v", err)
+	}
+
+	got := make(map[string]string)
+	for _, f := range verr.Fields {
+		got[f.Path] = f.Message
+	}
+	expected := map[string]string{
+		"Port":        "must be at least 1",
+		"Ports":       "must not end before it starts",
+		"TLS":         "enabled and insecure are mutually exclusive",
+		"Backends[1]": "must not end before it starts",
+	}
+	for path, msg := range expected {
+		if got[path] != msg {
+			t.Errorf("Expected %s %q, got %q", path, msg, got[path])
+		}
+	}
+	if len(verr.Fields) != len(expected) {
+		t.Errorf("Expected %d invalid fields, got %v", len(expected), verr.Fields)
+	}
+
+	_, err = New[methodConfig](WithoutEnv(), WithValues("port", 0))
+	if !errors.As(err, &verr) {
+		t.Fatalf("Expected *ValidationError, got %v", err)
+	}
+	found := false
+	for _, f := range verr.Fields {
+		found = found || f.Path == "" && f.Message == "port or backends must be set"
+	}
+	if !found {
+		t.Errorf("Expected the root Validate error, got %v", verr.Fields)
+	}
+}
+
+type requiredConfig struct {
+	Service struct {
+		APIKey string `required:"true"`
+		Region string `required:"true" default:"eu"`
+	}
+	Token string `required:"true"`
+}
+
+func TestRequired(t *testing.T) {
+	t.Run("Missing", func(t *testing.T) {
+		_, err := New[requiredConfig](WithEnv("APP"))
+
+		var verr *ValidationError
+		if !errors.As(err, &verr) {
+			t.Fatalf("Expected *ValidationError, got %v", err)
+		}
+		if len(verr.Fields) != 2 {
+			t.Fatalf("Expected 2 missing fields, got %v", verr.Fields)
+		}
+
+		expected := "is required, set the Service.APIKey key or the APP_SERVICE_APIKEY environment variable"
+		if verr.Fields[0].Path != "Service.APIKey" || verr.Fields[0].Message != expected {
+			t.Errorf("Expected Service.APIKey %q, got %s %q", expected, verr.Fields[0].Path, verr.Fields[0].Message)
+		}
+		if verr.Fields[1].Path != "Token" {
+			t.Errorf("Expected Token to be missing, got %s", verr.Fields[1].Path)
+		}
+	})
+
+	t.Run("SetFromEnv", func(t *testing.T) {
+		t.Setenv("APP_SERVICE_APIKEY", "secret")
+		t.Setenv("APP_TOKEN", "token")
+
+		cfg, err := New[requiredConfig](WithEnv("APP"))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if cfg.Service.APIKey != "secret" || cfg.Service.Region != "eu" {
+			t.Errorf("Expected API key from env and default region, got %+v", cfg.Service)
+		}
+	})
+
+	t.Run("WithoutEnv", func(t *testing.T) {
+		_, err := New[requiredConfig](WithoutEnv(), WithValues("service.apikey", "secret"))
+
+		var verr *ValidationError
+		if !errors.As(err, &verr) || len(verr.Fields) != 1 {
+			t.Fatalf("Expected 1 missing field, got %v", err)
+		}
+		if expected := "is required, set the Token key"; verr.Fields[0].Message != expected {
+			t.Errorf("Expected %q, got %q", expected, verr.Fields[0].Message)
+		}
+	})
+}
+
+func TestDecodeErrors(t *testing.T) {
+	type decodeConfig struct {
+		Server struct {
+			Port    int
+			Timeout time.Duration
+		}
+		Debug  bool
+		Since  time.Time
+		Secret int `sensitive:"true"`
+	}
+
+	t.Setenv("SERVER_PORT", "eighty")
+	_, err := New[decodeConfig](WithMap(map[string]interface{}{
+		"server.timeout": "5 minutes",
+		"debug":          "maybe",
+		"since":          "yesterday",
+		"secret":         "hunter2",
+	}))
+
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("Expected *ValidationError, got %T: %v", err, err)
+	}
+
+	expected := map[string]string{
+		"Server.Port":    `expected int, got "eighty" from env`,
+		"Server.Timeout": `expected time.Duration, got "5 minutes" from map`,
+		"Debug":          `expected bool, got "maybe" from map`,
+		"Since":          `expected time.Time, got "yesterday" from map: invalid time "yesterday"`,
+		"Secret":         `expected int, got *** from map`,
+	}
+	if len(verr.Fields) != len(expected) {
+		t.Errorf("Expected %d field errors, got %v", len(expected), verr.Fields)
+	}
+	for _, f := range verr.Fields {
+		if want, ok := expected[f.Path]; !ok || !strings.HasPrefix(f.Message, want) {
+			t.Errorf("Expected %s message %q, got %q", f.Path, want, f.Message)
+		}
+	}
+}
@@ -0,0 +1,317 @@
+package configloader
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/katalabut/fast-app/configloader/source"
+	"github.com/katalabut/fast-app/logger"
+	"github.com/pkg/errors"
+)
+
+// watchDelay is how long a watcher waits after the last file event before
+// reloading, so a file written in several steps is only read once complete.
+const watchDelay = 100 * time.Millisecond
+
+// WithWatch makes a Watcher reload the configuration whenever its file, set
+// with WithFile or WithFileFromEnv, or its directory, set with WithDir,
+// changes, and poll the configuration service set with WithHTTP.
+// It has no effect on New.
+func WithWatch() Option {
+	return func(p *Parser) error {
+		p.watch = true
+		return nil
+	}
+}
+
+// Watcher holds the current configuration of type T and reloads it on demand
+// with Reload, or on file changes with WithWatch. Each changed configuration,
+// with defaults and validation applied again, is delivered to the subscribers;
+// an invalid configuration is rejected and the current one is kept.
+//
+// Example:
+//
+//	w, err := configloader.NewWatcher[Config](configloader.WithFile("config.yaml"), configloader.WithWatch())
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer w.Close()
+//
+//	w.OnChange(func(old, new Config) {
+//	    log.Printf("port changed from %d to %d", old.Port, new.Port)
+//	})
+type Watcher[T any] struct {
+	opts []Option
+
+	mu        sync.Mutex
+	cfg       T
+	listeners []watchListener[T]
+	nextID    int
+	channels  []chan T
+	closed    bool
+
+	reloadMu  sync.Mutex
+	fsw       *fsnotify.Watcher
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+type watchListener[T any] struct {
+	id int
+	fn func(old, new T)
+}
+
+// NewWatcher loads a configuration of type T like New and returns a watcher
+// holding it. With WithWatch the configuration file and directory are watched,
+// and the configuration service polled, until Close.
+func NewWatcher[T any](opts ...Option) (*Watcher[T], error) {
+	cfg, p, err := load[T](opts)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Watcher[T]{
+		opts: opts,
+		cfg:  *cfg,
+		done: make(chan struct{}),
+	}
+
+	if p.watch {
+		var targets []*watchTarget
+		if file, ok := p.sources[source.FileSourceName].(*source.File); ok {
+			targets = append(targets, &watchTarget{dir: filepath.Dir(file.Path()), file: file.Path()})
+			if profilePath := file.ProfilePath(); profilePath != "" {
+				targets = append(targets, &watchTarget{dir: filepath.Dir(profilePath), file: profilePath})
+			}
+		}
+		if dir, ok := p.sources[source.DirSourceName].(*source.Dir); ok {
+			targets = append(targets, &watchTarget{dir: dir.Path()})
+		}
+		remote, polled := p.sources[source.HTTPSourceName].(*source.HTTP)
+		if len(targets) == 0 && !polled {
+			return nil, errors.New("watching requires a configuration file, directory or service, see WithFile, WithDir and WithHTTP")
+		}
+		if len(targets) > 0 {
+			if err := w.watch(targets); err != nil {
+				return nil, err
+			}
+		}
+		if polled {
+			go w.poll(remote.PollInterval())
+		}
+	}
+
+	return w, nil
+}
+
+// Config returns the current configuration.
+func (w *Watcher[T]) Config() T {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.cfg
+}
+
+// OnChange registers a listener called with the previous and the new
+// configuration after each change. It returns a function removing the listener.
+func (w *Watcher[T]) OnChange(fn func(old, new T)) (remove func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.nextID++
+	id := w.nextID
+	w.listeners = append(w.listeners, watchListener[T]{id: id, fn: fn})
+
+	return func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		for i, l := range w.listeners {
+			if l.id == id {
+				w.listeners = append(w.listeners[:i:i], w.listeners[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Subscribe returns a channel receiving the new configuration after each
+// change. A configuration not received before the next change is replaced
+// by it. The channel is closed by Close.
+func (w *Watcher[T]) Subscribe() <-chan T {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	ch := make(chan T, 1)
+	if w.closed {
+		close(ch)
+		return ch
+	}
+	w.channels = append(w.channels, ch)
+	return ch
+}
+
+// Reload loads the configuration again and notifies the subscribers if it
+// changed. If loading or validation fails, the current configuration is kept
+// and the error is returned.
+func (w *Watcher[T]) Reload() error {
+	w.reloadMu.Lock()
+	defer w.reloadMu.Unlock()
+
+	cfg, _, err := load[T](w.opts)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	old := w.cfg
+	if reflect.DeepEqual(old, *cfg) {
+		w.mu.Unlock()
+		return nil
+	}
+	w.cfg = *cfg
+
+	for _, ch := range w.channels {
+		// Replace a configuration not received yet with the new one.
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- *cfg
+	}
+	listeners := append([]watchListener[T](nil), w.listeners...)
+	w.mu.Unlock()
+
+	for _, l := range listeners {
+		l.fn(old, *cfg)
+	}
+	return nil
+}
+
+// Close stops watching and polling the configuration sources and closes the
+// subscribed channels.
+func (w *Watcher[T]) Close() error {
+	var err error
+	w.closeOnce.Do(func() {
+		close(w.done)
+		if w.fsw != nil {
+			err = w.fsw.Close()
+		}
+
+		w.mu.Lock()
+		w.closed = true
+		for _, ch := range w.channels {
+			close(ch)
+		}
+		w.channels = nil
+		w.mu.Unlock()
+	})
+	return err
+}
+
+// watchTarget is a watched directory, with the configuration file in it if
+// only changes to that file matter.
+type watchTarget struct {
+	dir      string
+	file     string
+	realPath string
+}
+
+// changed reports whether an event affects the target.
+func (t *watchTarget) changed(event fsnotify.Event) bool {
+	if filepath.Dir(filepath.Clean(event.Name)) != t.dir {
+		return false
+	}
+	if t.file == "" || filepath.Clean(event.Name) == t.file {
+		return true
+	}
+
+	// A config map swaps the directory the file links to.
+	current, _ := filepath.EvalSymlinks(t.file)
+	return current != t.realPath
+}
+
+func (w *Watcher[T]) watch(targets []*watchTarget) error {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return errors.Wrap(err, "failed to create file watcher")
+	}
+
+	// Directories are watched rather than files, since editors and
+	// Kubernetes volumes replace files instead of writing to them.
+	for _, t := range targets {
+		if t.file != "" {
+			t.realPath, _ = filepath.EvalSymlinks(t.file)
+		}
+		if err := fsw.Add(t.dir); err != nil {
+			fsw.Close()
+			return errors.Wrapf(err, "failed to watch %s", t.dir)
+		}
+	}
+
+	w.fsw = fsw
+	go w.loop(targets)
+	return nil
+}
+
+func (w *Watcher[T]) loop(targets []*watchTarget) {
+	ctx := context.Background()
+
+	var reload <-chan time.Time
+	for {
+		select {
+		case event, ok := <-w.fsw.Events:
+			if !ok {
+				return
+			}
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+			for _, t := range targets {
+				if t.changed(event) {
+					reload = time.After(watchDelay)
+					break
+				}
+			}
+
+		case <-reload:
+			reload = nil
+			for _, t := range targets {
+				if t.file != "" {
+					t.realPath, _ = filepath.EvalSymlinks(t.file)
+				}
+			}
+			if err := w.Reload(); err != nil {
+				logger.ErrorKV(ctx, "Failed to reload configuration", "error", err)
+			}
+
+		case err, ok := <-w.fsw.Errors:
+			if !ok {
+				return
+			}
+			logger.WarnKV(ctx, "Configuration watcher error", "error", err)
+
+		case <-w.done:
+			return
+		}
+	}
+}
+
+// poll reloads the configuration every interval until Close.
+func (w *Watcher[T]) poll(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := w.Reload(); err != nil {
+				logger.ErrorKV(context.Background(), "Failed to reload configuration", "error", err)
+			}
+		case <-w.done:
+			return
+		}
+	}
+}
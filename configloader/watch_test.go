@@ -0,0 +1,135 @@
+package configloader
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type watchConfig struct {
+	Port int    `default:"8080"`
+	Host string `default:"localhost"`
+}
+
+func (c watchConfig) Validate() error {
+	if c.Port < 0 {
+		return errors.New("port must not be negative")
+	}
+	return nil
+}
+
+func writeConfig(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWatcher(t *testing.T) {
+	t.Run("Reload", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		writeConfig(t, path, "port: 9000\n")
+
+		w, err := NewWatcher[watchConfig](WithoutEnv(), WithFile(path))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		defer w.Close()
+
+		if cfg := w.Config(); cfg.Port != 9000 || cfg.Host != "localhost" {
+			t.Errorf("Expected port 9000 and default host, got %+v", cfg)
+		}
+
+		var changes [][2]watchConfig
+		remove := w.OnChange(func(old, new watchConfig) {
+			changes = append(changes, [2]watchConfig{old, new})
+		})
+		ch := w.Subscribe()
+
+		writeConfig(t, path, "port: 9001\n")
+		if err := w.Reload(); err != nil {
+			t.Fatalf("Unexpected reload error: %v", err)
+		}
+		if len(changes) != 1 || changes[0][0].Port != 9000 || changes[0][1].Port != 9001 {
+			t.Errorf("Expected a change from 9000 to 9001, got %+v", changes)
+		}
+		if cfg := <-ch; cfg.Port != 9001 {
+			t.Errorf("Expected port 9001 on the channel, got %d", cfg.Port)
+		}
+
+		// Unchanged configurations are not delivered.
+		if err := w.Reload(); err != nil {
+			t.Fatalf("Unexpected reload error: %v", err)
+		}
+		if len(changes) != 1 {
+			t.Errorf("Expected no change, got %d changes", len(changes))
+		}
+
+		remove()
+		writeConfig(t, path, "port: 9002\n")
+		if err := w.Reload(); err != nil {
+			t.Fatalf("Unexpected reload error: %v", err)
+		}
+		if len(changes) != 1 {
+			t.Errorf("Expected removed listener not to be called, got %d changes", len(changes))
+		}
+
+		w.Close()
+		if cfg := <-ch; cfg.Port != 9002 {
+			t.Errorf("Expected pending port 9002 on the channel, got %d", cfg.Port)
+		}
+		if _, ok := <-ch; ok {
+			t.Error("Expected channel to be closed")
+		}
+	})
+
+	t.Run("InvalidKeepsCurrent", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		writeConfig(t, path, "port: 9000\n")
+
+		w, err := NewWatcher[watchConfig](WithoutEnv(), WithFile(path))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		defer w.Close()
+
+		writeConfig(t, path, "port: -1\n")
+		if err := w.Reload(); err == nil {
+			t.Error("Expected validation error")
+		}
+		if cfg := w.Config(); cfg.Port != 9000 {
+			t.Errorf("Expected current port 9000 to be kept, got %d", cfg.Port)
+		}
+	})
+
+	t.Run("WatchFile", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		writeConfig(t, path, "port: 9000\n")
+
+		w, err := NewWatcher[watchConfig](WithoutEnv(), WithFile(path), WithWatch())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		defer w.Close()
+
+		ch := w.Subscribe()
+		writeConfig(t, path, "port: 9001\n")
+
+		select {
+		case cfg := <-ch:
+			if cfg.Port != 9001 {
+				t.Errorf("Expected port 9001, got %d", cfg.Port)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Configuration change was not detected")
+		}
+	})
+
+	t.Run("WatchWithoutFile", func(t *testing.T) {
+		if _, err := NewWatcher[watchConfig](WithoutEnv(), WithWatch()); err == nil {
+			t.Error("Expected error when watching without a file")
+		}
+	})
+}
@@ -0,0 +1,236 @@
+// Package di provides a lightweight dependency injection container.
+// Constructors are registered with Provide and called lazily, at most once,
+// when a value of their result type is needed by Invoke or by another constructor.
+// Constructed values that hold resources are closed in reverse construction order.
+//
+// Example:
+//
+//	c := di.New()
+//	_ = c.Provide(NewConfig, NewDBPool, NewAPI)
+//	err := c.Invoke(func(api *API) {
+//	    app.Add(api)
+//	})
+//	...
+//	_ = c.Close()
+package di
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// Container holds constructors and the values they have produced.
+// It is safe for concurrent use.
+type Container struct {
+	mu        sync.Mutex
+	providers map[reflect.Type]*provider
+	values    map[reflect.Type]reflect.Value
+	built     []reflect.Value // in construction order
+}
+
+type provider struct {
+	ctor     reflect.Value
+	building bool
+}
+
+// New creates an empty container.
+func New() *Container {
+	return &Container{
+		providers: make(map[reflect.Type]*provider),
+		values:    make(map[reflect.Type]reflect.Value),
+	}
+}
+
+// Provide registers constructors. A constructor is a function whose parameters
+// are resolved from the container and which returns one or more values,
+// optionally followed by an error. Each result type may only be provided once.
+func (c *Container) Provide(constructors ...interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, ctor := range constructors {
+		v := reflect.ValueOf(ctor)
+		if v.Kind() != reflect.Func || v.IsNil() {
+			return errors.Errorf("constructor must be a function, got %T", ctor)
+		}
+
+		outs := results(v.Type())
+		if len(outs) == 0 {
+			return errors.Errorf("constructor %s must return at least one value", v.Type())
+		}
+
+		p := &provider{ctor: v}
+		for _, t := range outs {
+			if _, exists := c.providers[t]; exists {
+				return errors.Errorf("type %s is already provided", t)
+			}
+			c.providers[t] = p
+		}
+	}
+
+	return nil
+}
+
+// Invoke calls fn with its parameters resolved from the container, constructing
+// them as needed. If fn returns an error as its last result, it is returned.
+func (c *Container) Invoke(fn interface{}) error {
+	v := reflect.ValueOf(fn)
+	if v.Kind() != reflect.Func || v.IsNil() {
+		return errors.Errorf("invoke target must be a function, got %T", fn)
+	}
+
+	c.mu.Lock()
+	args, err := c.args(v.Type(), nil)
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	return callError(v.Call(args))
+}
+
+// Close closes the constructed values implementing io.Closer or a Close() method
+// without results in reverse construction order, so values are closed before
+// their dependencies. All values are closed even if some fail; the first error is returned.
+func (c *Container) Close() error {
+	c.mu.Lock()
+	built := c.built
+	c.built = nil
+	c.values = make(map[reflect.Type]reflect.Value)
+	c.mu.Unlock()
+
+	var firstErr error
+	for i := len(built) - 1; i >= 0; i-- {
+		if err := closeValue(built[i]); err != nil && firstErr == nil {
+			firstErr = errors.Wrapf(err, "failed to close %s", built[i].Type())
+		}
+	}
+
+	return firstErr
+}
+
+// resolve returns the value of type t, constructing it if needed.
+// It must be called with c.mu held.
+func (c *Container) resolve(t reflect.Type, path []reflect.Type) (reflect.Value, error) {
+	if v, ok := c.values[t]; ok {
+		return v, nil
+	}
+
+	p, ok := c.providers[t]
+	if !ok {
+		return reflect.Value{}, errors.Errorf("no constructor provided for %s%s", t, describePath(path))
+	}
+	if p.building {
+		return reflect.Value{}, errors.Errorf("dependency cycle detected: %s", describeCycle(append(path, t)))
+	}
+
+	p.building = true
+	defer func() { p.building = false }()
+
+	args, err := c.args(p.ctor.Type(), append(path, t))
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	out := p.ctor.Call(args)
+	if err := callError(out); err != nil {
+		return reflect.Value{}, errors.Wrapf(err, "failed to construct %s", t)
+	}
+
+	for _, v := range out {
+		if v.Type() == errorType {
+			continue
+		}
+		c.values[v.Type()] = v
+		c.built = append(c.built, v)
+	}
+
+	return c.values[t], nil
+}
+
+// args resolves the parameters of a function type.
+func (c *Container) args(fn reflect.Type, path []reflect.Type) ([]reflect.Value, error) {
+	args := make([]reflect.Value, fn.NumIn())
+	for i := range args {
+		v, err := c.resolve(fn.In(i), path)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = v
+	}
+
+	return args, nil
+}
+
+// results returns the non-error result types of a function type.
+func results(fn reflect.Type) []reflect.Type {
+	var outs []reflect.Type
+	for i := 0; i < fn.NumOut(); i++ {
+		if t := fn.Out(i); t != errorType {
+			outs = append(outs, t)
+		}
+	}
+
+	return outs
+}
+
+// callError returns the error returned by a function call, if any.
+func callError(out []reflect.Value) error {
+	if len(out) == 0 {
+		return nil
+	}
+
+	last := out[len(out)-1]
+	if last.Type() != errorType || last.IsNil() {
+		return nil
+	}
+
+	return last.Interface().(error)
+}
+
+// closeValue releases the resources held by a constructed value.
+func closeValue(v reflect.Value) error {
+	if !v.IsValid() || !v.CanInterface() {
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Chan, reflect.Func, reflect.Slice:
+		if v.IsNil() {
+			return nil
+		}
+	}
+
+	switch x := v.Interface().(type) {
+	case io.Closer:
+		return x.Close()
+	case interface{ Close() }:
+		x.Close()
+	}
+
+	return nil
+}
+
+func describePath(path []reflect.Type) string {
+	if len(path) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(" (required by %s)", path[len(path)-1])
+}
+
+func describeCycle(path []reflect.Type) string {
+	names := make([]string, len(path))
+	for i, t := range path {
+		names[i] = t.String()
+	}
+
+	return strings.Join(names, " -> ")
+}
@@ -0,0 +1,127 @@
+package di
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type config struct{ dsn string }
+
+type pool struct {
+	dsn    string
+	closed *[]string
+}
+
+func (p *pool) Close() error {
+	*p.closed = append(*p.closed, "pool")
+	return nil
+}
+
+type repo struct {
+	pool   *pool
+	closed *[]string
+}
+
+func (r *repo) Close() {
+	*r.closed = append(*r.closed, "repo")
+}
+
+func TestContainer(t *testing.T) {
+	t.Run("InvokeResolvesDependencies", func(t *testing.T) {
+		var closed []string
+		calls := 0
+
+		c := New()
+		err := c.Provide(
+			func() config { return config{dsn: "postgres://"} },
+			func(cfg config) (*pool, error) {
+				calls++
+				return &pool{dsn: cfg.dsn, closed: &closed}, nil
+			},
+			func(p *pool) *repo { return &repo{pool: p, closed: &closed} },
+		)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		var got *repo
+		if err := c.Invoke(func(r *repo, p *pool) { got = r }); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if got == nil || got.pool.dsn != "postgres://" {
+			t.Fatalf("Expected repo to be constructed, got %+v", got)
+		}
+
+		if err := c.Invoke(func(p *pool) {}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if calls != 1 {
+			t.Errorf("Expected constructor to be called once, got %d", calls)
+		}
+
+		if err := c.Close(); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if strings.Join(closed, ",") != "repo,pool" {
+			t.Errorf("Expected values to be closed in reverse order, got %v", closed)
+		}
+	})
+
+	t.Run("MissingConstructor", func(t *testing.T) {
+		c := New()
+		_ = c.Provide(func(cfg config) *pool { return &pool{} })
+
+		err := c.Invoke(func(p *pool) {})
+		if err == nil || !strings.Contains(err.Error(), "di.config") {
+			t.Errorf("Expected missing constructor error, got %v", err)
+		}
+	})
+
+	t.Run("ConstructorError", func(t *testing.T) {
+		c := New()
+		_ = c.Provide(func() (*pool, error) { return nil, errors.New("connection refused") })
+
+		err := c.Invoke(func(p *pool) {})
+		if err == nil || !strings.Contains(err.Error(), "connection refused") {
+			t.Errorf("Expected constructor error, got %v", err)
+		}
+	})
+
+	t.Run("InvokeError", func(t *testing.T) {
+		c := New()
+		want := errors.New("invoke failed")
+
+		if err := c.Invoke(func() error { return want }); err != want {
+			t.Errorf("Expected %v, got %v", want, err)
+		}
+	})
+
+	t.Run("Cycle", func(t *testing.T) {
+		c := New()
+		_ = c.Provide(
+			func(r *repo) *pool { return &pool{} },
+			func(p *pool) *repo { return &repo{} },
+		)
+
+		err := c.Invoke(func(r *repo) {})
+		if err == nil || !strings.Contains(err.Error(), "cycle") {
+			t.Errorf("Expected cycle error, got %v", err)
+		}
+	})
+
+	t.Run("InvalidProvide", func(t *testing.T) {
+		c := New()
+
+		if err := c.Provide("not a function"); err == nil {
+			t.Error("Expected error for non-function constructor")
+		}
+		if err := c.Provide(func() error { return nil }); err == nil {
+			t.Error("Expected error for constructor without results")
+		}
+		_ = c.Provide(func() config { return config{} })
+		if err := c.Provide(func() config { return config{} }); err == nil {
+			t.Error("Expected error for duplicate type")
+		}
+	})
+}
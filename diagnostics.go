@@ -0,0 +1,81 @@
+package fastapp
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"runtime"
+	"runtime/debug"
+	"time"
+)
+
+// diagnosticsCheckTimeout bounds the health checks run for a diagnostic dump.
+const diagnosticsCheckTimeout = 5 * time.Second
+
+// notifyDiagnostics logs a diagnostic dump each time a diagnostic signal is received until ctx is done.
+func (a *App) notifyDiagnostics(ctx context.Context) {
+	if len(diagnosticSignals) == 0 {
+		return
+	}
+
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, diagnosticSignals...)
+
+	go func() {
+		defer signal.Stop(ch)
+
+		for {
+			select {
+			case sig := <-ch:
+				a.logger.Warnw("Received diagnostic signal", "signal", sig.String())
+				a.dumpDiagnostics(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// dumpDiagnostics logs the goroutine stacks, memory and GC statistics and the
+// current health snapshot, to capture the state of a wedged process without pprof.
+func (a *App) dumpDiagnostics(ctx context.Context) {
+	a.dumpGoroutines()
+
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	a.logger.Warnw("Memory stats",
+		"goroutines", runtime.NumGoroutine(),
+		"alloc_bytes", mem.Alloc,
+		"total_alloc_bytes", mem.TotalAlloc,
+		"sys_bytes", mem.Sys,
+		"heap_inuse_bytes", mem.HeapInuse,
+		"heap_objects", mem.HeapObjects,
+		"stack_inuse_bytes", mem.StackInuse,
+	)
+
+	var gc debug.GCStats
+	debug.ReadGCStats(&gc)
+	var lastPause time.Duration
+	if len(gc.Pause) > 0 {
+		lastPause = gc.Pause[0]
+	}
+	a.logger.Warnw("GC stats",
+		"num_gc", gc.NumGC,
+		"last_gc", gc.LastGC,
+		"last_pause", lastPause,
+		"pause_total", gc.PauseTotal,
+		"next_gc_bytes", mem.NextGC,
+		"gc_cpu_fraction", mem.GCCPUFraction,
+	)
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), diagnosticsCheckTimeout)
+	defer cancel()
+
+	checks, status := a.healthManager.CheckAllWithStatus(ctx)
+	a.logger.Warnw("Health snapshot",
+		"status", status,
+		"ready", a.IsReady(),
+		"checks", checks,
+		"services", a.Services(),
+	)
+}
@@ -0,0 +1,11 @@
+//go:build !windows
+
+package fastapp
+
+import (
+	"os"
+	"syscall"
+)
+
+// diagnosticSignals are the signals that trigger a diagnostic dump.
+var diagnosticSignals = []os.Signal{syscall.SIGUSR1}
@@ -0,0 +1,9 @@
+//go:build windows
+
+package fastapp
+
+import "os"
+
+// diagnosticSignals are the signals that trigger a diagnostic dump.
+// Windows has no user-defined signals, so diagnostic dumps are not available.
+var diagnosticSignals []os.Signal
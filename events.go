@@ -0,0 +1,155 @@
+package fastapp
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/katalabut/fast-app/health"
+	"go.uber.org/zap"
+)
+
+// EventType identifies a kind of application event.
+type EventType string
+
+const (
+	// EventServiceStarted is emitted when a service Run method is invoked.
+	EventServiceStarted EventType = "service_started"
+	// EventServiceStopped is emitted when a service Run method returns, see Event.State and Event.Err.
+	EventServiceStopped EventType = "service_stopped"
+	// EventReadinessChanged is emitted when the application readiness changes, see Event.Ready.
+	// Readiness is re-evaluated on readiness probes, SetReady and service start and stop.
+	EventReadinessChanged EventType = "readiness_changed"
+	// EventHealthChanged is emitted when the overall health status changes, see Event.Status.
+	// The status is evaluated when health checks are run, e.g. by the health endpoints.
+	EventHealthChanged EventType = "health_changed"
+	// EventConfigReloaded is emitted when the configuration has been reloaded.
+	EventConfigReloaded EventType = "config_reloaded"
+)
+
+// Event is an application lifecycle or health event.
+type Event struct {
+	Type EventType
+	Time time.Time
+
+	// Service and State are set for service events.
+	Service string
+	State   ServiceState
+
+	// Err is the error a service failed with or a configuration reload error.
+	Err error
+
+	// Ready is set for readiness events.
+	Ready bool
+
+	// Status and PreviousStatus are set for health events.
+	Status         health.HealthStatus
+	PreviousStatus health.HealthStatus
+}
+
+// EventBus delivers application events to subscribers. Handlers are called
+// synchronously in the order they were subscribed and must not block;
+// long-running work should be moved to a separate goroutine.
+type EventBus struct {
+	logger *zap.SugaredLogger
+
+	mu     sync.RWMutex
+	nextID int
+	subs   map[EventType][]subscription
+}
+
+type subscription struct {
+	id int
+	fn func(Event)
+}
+
+func newEventBus(logger *zap.SugaredLogger) *EventBus {
+	return &EventBus{
+		logger: logger,
+		subs:   make(map[EventType][]subscription),
+	}
+}
+
+// Subscribe registers a handler for the given event type. The returned
+// function removes the handler.
+//
+// Example:
+//
+//	app.Events().Subscribe(fastapp.EventServiceStopped, func(e fastapp.Event) {
+//	    alerting.Notify(e.Service, e.Err)
+//	})
+func (b *EventBus) Subscribe(t EventType, fn func(Event)) (unsubscribe func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	id := b.nextID
+	b.subs[t] = append(b.subs[t], subscription{id: id, fn: fn})
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		subs := b.subs[t]
+		for i, s := range subs {
+			if s.id == id {
+				b.subs[t] = append(subs[:i:i], subs[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Publish delivers an event to its subscribers. A zero Time is set to the current time.
+// A panicking handler is logged and does not affect other handlers.
+func (b *EventBus) Publish(e Event) {
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+
+	b.mu.RLock()
+	subs := b.subs[e.Type]
+	b.mu.RUnlock()
+
+	for _, s := range subs {
+		b.deliver(s.fn, e)
+	}
+}
+
+// deliver calls a single handler, recovering from panics.
+func (b *EventBus) deliver(fn func(Event), e Event) {
+	defer func() {
+		if ec := recover(); ec != nil {
+			b.logger.Errorw("Event handler panicked",
+				"event", string(e.Type),
+				zap.String("panic", fmt.Sprintf("%v", ec)),
+			)
+		}
+	}()
+
+	fn(e)
+}
+
+// Events returns the application event bus.
+func (a *App) Events() *EventBus {
+	return a.events
+}
+
+// publishServiceEvent publishes a service lifecycle event and re-evaluates
+// the application readiness, which may emit EventReadinessChanged.
+func (a *App) publishServiceEvent(t EventType, r *Runner) {
+	st := r.status()
+
+	e := Event{
+		Type:    t,
+		Time:    a.opts.clock.Now(),
+		Service: r.name,
+		State:   st.state,
+	}
+	if t == EventServiceStopped {
+		e.Err = st.lastErr
+	}
+	a.events.Publish(e)
+
+	a.healthManager.IsReady()
+}
@@ -0,0 +1,101 @@
+package fastapp
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/katalabut/fast-app/health"
+	"github.com/katalabut/fast-app/health/healthtest"
+)
+
+func TestEvents(t *testing.T) {
+	t.Run("ServiceLifecycle", func(t *testing.T) {
+		app, cancel, exitCode := newTestApp()
+
+		var (
+			mu     sync.Mutex
+			events []Event
+		)
+		record := func(e Event) {
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, e)
+		}
+		for _, typ := range []EventType{EventServiceStarted, EventServiceStopped, EventReadinessChanged} {
+			app.Events().Subscribe(typ, record)
+		}
+
+		svc := newTestService()
+		app.Add(svc, WithName("api"))
+		stop := startTestApp(t, app, cancel, exitCode)
+		<-svc.started
+
+		svc.SetReady(true)
+		app.IsReady()
+
+		if code := stop(); code != exitCodeOk {
+			t.Errorf("Expected exit code %d, got %d", exitCodeOk, code)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		// Readiness is flipped before services are stopped.
+		want := []EventType{EventServiceStarted, EventReadinessChanged, EventReadinessChanged, EventServiceStopped}
+		if len(events) != len(want) {
+			t.Fatalf("Expected %d events, got %+v", len(want), events)
+		}
+		for i := range want {
+			if events[i].Type != want[i] {
+				t.Errorf("Expected event %d to be %s, got %s", i, want[i], events[i].Type)
+			}
+		}
+		if events[0].Service != "api" || events[0].State != StateRunning {
+			t.Errorf("Unexpected start event %+v", events[0])
+		}
+		if !events[1].Ready || events[2].Ready {
+			t.Errorf("Expected readiness to change to true and back to false, got %v and %v", events[1].Ready, events[2].Ready)
+		}
+		if events[3].State != StateStopped || events[3].Err != nil {
+			t.Errorf("Unexpected stop event %+v", events[3])
+		}
+	})
+
+	t.Run("HealthChanged", func(t *testing.T) {
+		app, _, _ := newTestApp()
+		check := healthtest.Sequence("db", health.NewHealthyResult("ok"), health.NewUnhealthyResult("down"))
+		app.WithHealthChecks(check)
+
+		var got []Event
+		app.Events().Subscribe(EventHealthChanged, func(e Event) { got = append(got, e) })
+
+		app.HealthManager().GetOverallStatus(context.Background())
+		app.HealthManager().ClearCache()
+		app.HealthManager().GetOverallStatus(context.Background())
+
+		if len(got) != 1 {
+			t.Fatalf("Expected 1 event, got %d", len(got))
+		}
+		if got[0].PreviousStatus != health.StatusHealthy || got[0].Status != health.StatusUnhealthy {
+			t.Errorf("Unexpected transition %s -> %s", got[0].PreviousStatus, got[0].Status)
+		}
+	})
+
+	t.Run("UnsubscribeAndPanic", func(t *testing.T) {
+		app, _, _ := newTestApp()
+
+		calls := 0
+		unsubscribe := app.Events().Subscribe(EventConfigReloaded, func(e Event) { calls++ })
+		app.Events().Subscribe(EventConfigReloaded, func(e Event) { panic(errors.New("boom")) })
+
+		app.Events().Publish(Event{Type: EventConfigReloaded})
+		unsubscribe()
+		app.Events().Publish(Event{Type: EventConfigReloaded})
+
+		if calls != 1 {
+			t.Errorf("Expected 1 call, got %d", calls)
+		}
+	})
+}
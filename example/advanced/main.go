@@ -198,9 +198,6 @@ func main() {
 	}
 	app.Add(workerService)
 
-	// Set application as ready
-	app.SetReady(true)
-	
 	logger.Info(context.Background(), "Starting application with health checks enabled")
 	logger.Info(context.Background(), "Health endpoints available at:")
 	logger.Info(context.Background(), "  - Liveness:  http://localhost:8080/health/live")
@@ -80,9 +80,6 @@ func main() {
 		WithHealthChecks(httpCheck).
 		Add(apiService)
 
-	// Set application as ready after all services are added
-	app.SetReady(true)
-
 	logger.Info(context.Background(), "🚀 Starting FastApp Basic Example")
 	logger.Info(context.Background(), "📊 All endpoints available on port 9090:")
 	logger.Info(context.Background(), "   • Liveness:  http://localhost:9090/health/live")
@@ -472,9 +472,6 @@ func main() {
 	// Add service (its health checks will be automatically registered)
 	app.Add(completeService)
 
-	// Set application as ready
-	app.SetReady(true)
-
 	// Display startup information
 	logger.Info(context.Background(), "🎯 Complete FastApp Demo Application")
 	logger.Info(context.Background(), "This demo showcases ALL FastApp capabilities:")
@@ -37,7 +37,7 @@ type DatabaseConfig struct {
 // RedisConfig demonstrates Redis configuration
 type RedisConfig struct {
 	Address     string        `default:"localhost:6379"`
-	Password    string        `default:""`
+	Password    config.Secret `default:""`
 	Database    int           `default:"0"`
 	PoolSize    int           `default:"10"`
 	DialTimeout time.Duration `default:"5s"`
@@ -51,8 +51,8 @@ type APIConfig struct {
 	Timeout       time.Duration     `default:"30s"`
 	RetryAttempts int               `default:"3"`
 	RetryDelay    time.Duration     `default:"1s"`
-	APIKey        string            `default:""`
-	Headers       map[string]string `default:"{}"`
+	APIKey        config.Secret     `default:""`
+	Headers       map[string]string `default:"{\"Accept\": \"application/json\"}"`
 	RateLimit     int               `default:"100"`
 }
 
@@ -62,7 +62,7 @@ type FeatureFlags struct {
 	EnableBetaAPI    bool `default:"false"`
 	EnableCaching    bool `default:"true"`
 	EnableMetrics    bool `default:"true"`
-	MaxFileSize      int  `default:"10485760"` // 10MB
+	MaxFileSize      config.ByteSize `default:"10MiB"`
 	MaintenanceMode  bool `default:"false"`
 }
 
@@ -126,7 +126,7 @@ func (s *ConfigDemoService) displayConfiguration(ctx context.Context) {
 		"database", s.config.Redis.Database,
 		"pool_size", s.config.Redis.PoolSize,
 		"enabled", s.config.Redis.Enabled,
-		"has_password", s.config.Redis.Password != "")
+		"has_password", s.config.Redis.Password.IsSet())
 
 	// API configuration
 	logger.InfoKV(ctx, "External API Configuration",
@@ -134,7 +134,7 @@ func (s *ConfigDemoService) displayConfiguration(ctx context.Context) {
 		"timeout", s.config.API.Timeout,
 		"retry_attempts", s.config.API.RetryAttempts,
 		"rate_limit", s.config.API.RateLimit,
-		"has_api_key", s.config.API.APIKey != "")
+		"has_api_key", s.config.API.APIKey.IsSet())
 
 	// Feature flags
 	logger.InfoKV(ctx, "Feature Flags",
@@ -143,7 +143,7 @@ func (s *ConfigDemoService) displayConfiguration(ctx context.Context) {
 		"caching", s.config.Features.EnableCaching,
 		"metrics", s.config.Features.EnableMetrics,
 		"maintenance_mode", s.config.Features.MaintenanceMode,
-		"max_file_size_mb", s.config.Features.MaxFileSize/1024/1024)
+		"max_file_size", s.config.Features.MaxFileSize.String())
 }
 
 func (s *ConfigDemoService) validateConfiguration(ctx context.Context) {
@@ -296,9 +296,6 @@ func main() {
 	// Add service (its health checks will be automatically registered)
 	app.Add(demoService)
 
-	// Set application as ready (this affects the readiness probe)
-	app.SetReady(true)
-
 	logger.Info(context.Background(), "🎯 Health Checks Demo Application")
 	logger.Info(context.Background(), "This demo shows comprehensive health check patterns:")
 	logger.Info(context.Background(), "• Service-specific health checks")
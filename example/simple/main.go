@@ -131,9 +131,6 @@ func main() {
 		Add(workerService).
 		Add(schedulerService)
 
-	// Set application as ready
-	app.SetReady(true)
-
 	logger.Info(context.Background(), "🚀 Starting FastApp with Unified Observability")
 	logger.Info(context.Background(), "📊 All endpoints available on port 9090:")
 	logger.Info(context.Background(), "   • Liveness:  http://localhost:9090/health/live")
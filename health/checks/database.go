@@ -3,6 +3,7 @@ package checks
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"time"
 
 	"github.com/katalabut/fast-app/health"
@@ -14,32 +15,69 @@ type DatabaseOptions struct {
 	Query       string // optional custom query instead of ping
 }
 
+// SQLDB is implemented by database/sql compatible clients, such as *sql.DB,
+// *sqlx.DB or the *sql.DB returned by GORM's DB method.
+type SQLDB interface {
+	PingContext(ctx context.Context) error
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// Pinger is implemented by database clients that can verify connectivity,
+// such as *pgxpool.Pool or *pgx.Conn.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// PingerFunc adapts a function to the Pinger interface, e.g. to run a custom
+// query with a client that is not database/sql compatible.
+type PingerFunc func(ctx context.Context) error
+
+// Ping calls f(ctx)
+func (f PingerFunc) Ping(ctx context.Context) error {
+	return f(ctx)
+}
+
 // DatabaseCheck checks database connectivity
 type DatabaseCheck struct {
 	name string
-	db   *sql.DB
+	ping func(ctx context.Context) error
+	db   SQLDB // nil for Pinger based checks
 	opts DatabaseOptions
 }
 
 // NewDatabaseCheck creates a new database health check
-func NewDatabaseCheck(name string, db *sql.DB) *DatabaseCheck {
+func NewDatabaseCheck(name string, db SQLDB) *DatabaseCheck {
+	return NewDatabaseCheckWithOptions(name, db, DatabaseOptions{})
+}
+
+// NewPingerCheck creates a new database health check for any client implementing Pinger
+func NewPingerCheck(name string, p Pinger) *DatabaseCheck {
+	return NewPingerCheckWithOptions(name, p, DatabaseOptions{})
+}
+
+// NewPingerCheckWithOptions creates a new database health check for a Pinger with options.
+// Custom queries are not supported; use PingerFunc to run one with the client's own API.
+func NewPingerCheckWithOptions(name string, p Pinger, opts DatabaseOptions) *DatabaseCheck {
+	if opts.PingTimeout == 0 {
+		opts.PingTimeout = 5 * time.Second
+	}
+
 	return &DatabaseCheck{
 		name: name,
-		db:   db,
-		opts: DatabaseOptions{
-			PingTimeout: 5 * time.Second,
-		},
+		ping: p.Ping,
+		opts: opts,
 	}
 }
 
 // NewDatabaseCheckWithOptions creates a new database health check with options
-func NewDatabaseCheckWithOptions(name string, db *sql.DB, opts DatabaseOptions) *DatabaseCheck {
+func NewDatabaseCheckWithOptions(name string, db SQLDB, opts DatabaseOptions) *DatabaseCheck {
 	if opts.PingTimeout == 0 {
 		opts.PingTimeout = 5 * time.Second
 	}
 	
 	return &DatabaseCheck{
 		name: name,
+		ping: db.PingContext,
 		db:   db,
 		opts: opts,
 	}
@@ -65,7 +103,7 @@ func (d *DatabaseCheck) Check(ctx context.Context) health.HealthResult {
 		err = d.executeQuery(checkCtx)
 	} else {
 		// Use ping
-		err = d.db.PingContext(checkCtx)
+		err = d.ping(checkCtx)
 	}
 
 	duration := time.Since(start)
@@ -101,6 +139,10 @@ func (d *DatabaseCheck) Check(ctx context.Context) health.HealthResult {
 
 // executeQuery executes a custom query for health check
 func (d *DatabaseCheck) executeQuery(ctx context.Context) error {
+	if d.db == nil {
+		return errors.New("custom query is not supported by pinger")
+	}
+
 	rows, err := d.db.QueryContext(ctx, d.opts.Query)
 	if err != nil {
 		return err
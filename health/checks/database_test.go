@@ -3,8 +3,11 @@ package checks
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"testing"
 	"time"
+
+	"github.com/katalabut/fast-app/health"
 )
 
 // Mock database for testing
@@ -79,6 +82,60 @@ func TestDatabaseCheck(t *testing.T) {
 			t.Errorf("Expected default timeout 5s, got %v", check.opts.PingTimeout)
 		}
 	})
+
+	t.Run("SQLDB", func(t *testing.T) {
+		result := NewDatabaseCheck("test-db", &mockDB{}).Check(context.Background())
+		if result.Status != health.StatusHealthy {
+			t.Errorf("Expected status %s, got %s", health.StatusHealthy, result.Status)
+		}
+
+		result = NewDatabaseCheck("test-db", &mockDB{pingError: errors.New("connection refused")}).Check(context.Background())
+		if result.Status != health.StatusUnhealthy {
+			t.Errorf("Expected status %s, got %s", health.StatusUnhealthy, result.Status)
+		}
+
+		result = NewDatabaseCheckWithOptions("test-db", &mockDB{queryError: errors.New("relation does not exist")}, DatabaseOptions{
+			Query: "SELECT 1 FROM users",
+		}).Check(context.Background())
+		if result.Status != health.StatusUnhealthy {
+			t.Errorf("Expected status %s for failing query, got %s", health.StatusUnhealthy, result.Status)
+		}
+	})
+
+	t.Run("Pinger", func(t *testing.T) {
+		pings := 0
+		pinger := PingerFunc(func(ctx context.Context) error {
+			pings++
+			return nil
+		})
+
+		result := NewPingerCheck("pgx", pinger).Check(context.Background())
+		if result.Status != health.StatusHealthy {
+			t.Errorf("Expected status %s, got %s", health.StatusHealthy, result.Status)
+		}
+		if pings != 1 {
+			t.Errorf("Expected 1 ping, got %d", pings)
+		}
+
+		result = NewPingerCheckWithOptions("pgx", pinger, DatabaseOptions{Query: "SELECT 1"}).Check(context.Background())
+		if result.Status != health.StatusUnhealthy {
+			t.Errorf("Expected status %s for unsupported query, got %s", health.StatusUnhealthy, result.Status)
+		}
+	})
+
+	t.Run("PingTimeout", func(t *testing.T) {
+		check := NewDatabaseCheckWithOptions("test-db", &mockDB{pingDelay: time.Second}, DatabaseOptions{
+			PingTimeout: 10 * time.Millisecond,
+		})
+
+		result := check.Check(context.Background())
+		if result.Status != health.StatusUnhealthy {
+			t.Errorf("Expected status %s, got %s", health.StatusUnhealthy, result.Status)
+		}
+		if result.Message != "database ping timeout" {
+			t.Errorf("Expected message 'database ping timeout', got '%s'", result.Message)
+		}
+	})
 }
 
 // Note: For more comprehensive database testing, we would need:
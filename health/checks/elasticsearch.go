@@ -0,0 +1,186 @@
+package checks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/katalabut/fast-app/health"
+)
+
+// ElasticsearchOptions contains options for Elasticsearch health check
+type ElasticsearchOptions struct {
+	Timeout time.Duration
+	// Indices that must exist for the cluster to be considered healthy.
+	Indices  []string
+	Username string
+	Password string
+	Headers  map[string]string
+	// Client is the HTTP client used for requests, e.g. with a custom TLS configuration.
+	// The timeout is applied per check, so the client timeout is not required.
+	Client *http.Client
+}
+
+// ElasticsearchCheck checks Elasticsearch or OpenSearch cluster health
+type ElasticsearchCheck struct {
+	name string
+	url  string
+	opts ElasticsearchOptions
+}
+
+// clusterHealth is the response of the _cluster/health API.
+type clusterHealth struct {
+	ClusterName                 string  `json:"cluster_name"`
+	Status                      string  `json:"status"`
+	TimedOut                    bool    `json:"timed_out"`
+	NumberOfNodes               int     `json:"number_of_nodes"`
+	NumberOfDataNodes           int     `json:"number_of_data_nodes"`
+	ActivePrimaryShards         int     `json:"active_primary_shards"`
+	ActiveShards                int     `json:"active_shards"`
+	RelocatingShards            int     `json:"relocating_shards"`
+	InitializingShards          int     `json:"initializing_shards"`
+	UnassignedShards            int     `json:"unassigned_shards"`
+	ActiveShardsPercentAsNumber float64 `json:"active_shards_percent_as_number"`
+}
+
+// NewElasticsearchCheck creates a new Elasticsearch health check for the cluster
+// at the given base URL, e.g. "http://localhost:9200"
+func NewElasticsearchCheck(name, url string) *ElasticsearchCheck {
+	return NewElasticsearchCheckWithOptions(name, url, ElasticsearchOptions{})
+}
+
+// NewElasticsearchCheckWithOptions creates a new Elasticsearch health check with options
+func NewElasticsearchCheckWithOptions(name, url string, opts ElasticsearchOptions) *ElasticsearchCheck {
+	if opts.Timeout == 0 {
+		opts.Timeout = 10 * time.Second
+	}
+	if opts.Client == nil {
+		opts.Client = http.DefaultClient
+	}
+
+	return &ElasticsearchCheck{
+		name: name,
+		url:  strings.TrimRight(url, "/"),
+		opts: opts,
+	}
+}
+
+// Name returns the name of the health check
+func (e *ElasticsearchCheck) Name() string {
+	return e.name
+}
+
+// Check executes the Elasticsearch health check. Cluster status green is reported
+// healthy, yellow degraded and red unhealthy.
+func (e *ElasticsearchCheck) Check(ctx context.Context) health.HealthResult {
+	start := time.Now()
+
+	ctx, cancel := context.WithTimeout(ctx, e.opts.Timeout)
+	defer cancel()
+
+	var ch clusterHealth
+	resp, err := e.do(ctx, http.MethodGet, "/_cluster/health")
+	if err == nil {
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			err = fmt.Errorf("unexpected HTTP status code %d", resp.StatusCode)
+		} else {
+			err = json.NewDecoder(resp.Body).Decode(&ch)
+		}
+	}
+	if err != nil {
+		duration := time.Since(start)
+		return health.NewUnhealthyResult("Elasticsearch cluster health request failed").
+			WithDetails("error", err.Error()).
+			WithDetails("url", e.url).
+			WithDetails("duration", duration.String()).
+			WithDuration(duration)
+	}
+
+	var missing []string
+	for _, index := range e.opts.Indices {
+		exists, err := e.indexExists(ctx, index)
+		if err != nil {
+			duration := time.Since(start)
+			return health.NewUnhealthyResult("Elasticsearch index check failed").
+				WithDetails("error", err.Error()).
+				WithDetails("index", index).
+				WithDetails("url", e.url).
+				WithDetails("duration", duration.String()).
+				WithDuration(duration)
+		}
+		if !exists {
+			missing = append(missing, index)
+		}
+	}
+	duration := time.Since(start)
+
+	var result health.HealthResult
+	switch {
+	case len(missing) > 0:
+		result = health.NewUnhealthyResult("Elasticsearch indices are missing").
+			WithDetails("missing_indices", missing)
+	case ch.Status == "green":
+		result = health.NewHealthyResult("Elasticsearch cluster is healthy")
+	case ch.Status == "yellow":
+		result = health.NewDegradedResult("Elasticsearch cluster has unassigned replicas")
+	case ch.Status == "red":
+		result = health.NewUnhealthyResult("Elasticsearch cluster has unassigned primary shards")
+	default:
+		result = health.NewUnhealthyResult("Elasticsearch cluster status is unknown")
+	}
+
+	return result.
+		WithDetails("cluster_name", ch.ClusterName).
+		WithDetails("cluster_status", ch.Status).
+		WithDetails("number_of_nodes", ch.NumberOfNodes).
+		WithDetails("number_of_data_nodes", ch.NumberOfDataNodes).
+		WithDetails("active_primary_shards", ch.ActivePrimaryShards).
+		WithDetails("active_shards", ch.ActiveShards).
+		WithDetails("relocating_shards", ch.RelocatingShards).
+		WithDetails("initializing_shards", ch.InitializingShards).
+		WithDetails("unassigned_shards", ch.UnassignedShards).
+		WithDetails("active_shards_percent", ch.ActiveShardsPercentAsNumber).
+		WithDetails("url", e.url).
+		WithDetails("duration", duration.String()).
+		WithDuration(duration)
+}
+
+// indexExists reports whether the index (or alias) exists.
+func (e *ElasticsearchCheck) indexExists(ctx context.Context, index string) (bool, error) {
+	resp, err := e.do(ctx, http.MethodHead, "/"+url.PathEscape(index))
+	if err != nil {
+		return false, err
+	}
+	resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("unexpected HTTP status code %d", resp.StatusCode)
+	}
+}
+
+// do sends a request to the cluster.
+func (e *ElasticsearchCheck) do(ctx context.Context, method, path string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, e.url+path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if e.opts.Username != "" {
+		req.SetBasicAuth(e.opts.Username, e.opts.Password)
+	}
+	for key, value := range e.opts.Headers {
+		req.Header.Set(key, value)
+	}
+
+	return e.opts.Client.Do(req)
+}
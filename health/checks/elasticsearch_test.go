@@ -0,0 +1,130 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/katalabut/fast-app/health"
+)
+
+func newElasticsearchServer(t *testing.T, status string, indices ...string) *httptest.Server {
+	t.Helper()
+
+	existing := make(map[string]bool)
+	for _, index := range indices {
+		existing["/"+index] = true
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/_cluster/health" {
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"cluster_name":"test","status":%q,"number_of_nodes":3,"active_shards":10,"unassigned_shards":2}`, status)
+			return
+		}
+		if r.Method == http.MethodHead && existing[r.URL.Path] {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestElasticsearchCheck(t *testing.T) {
+	t.Run("NewElasticsearchCheck", func(t *testing.T) {
+		check := NewElasticsearchCheck("test", "http://localhost:9200/")
+		if check.Name() != "test" {
+			t.Errorf("Expected name 'test', got '%s'", check.Name())
+		}
+		if check.url != "http://localhost:9200" {
+			t.Errorf("Expected trailing slash to be trimmed, got '%s'", check.url)
+		}
+	})
+
+	t.Run("ClusterStatus", func(t *testing.T) {
+		tests := []struct {
+			status string
+			want   health.HealthStatus
+		}{
+			{"green", health.StatusHealthy},
+			{"yellow", health.StatusDegraded},
+			{"red", health.StatusUnhealthy},
+			{"", health.StatusUnhealthy},
+		}
+
+		for _, tt := range tests {
+			server := newElasticsearchServer(t, tt.status)
+
+			result := NewElasticsearchCheck("test", server.URL).Check(context.Background())
+			if result.Status != tt.want {
+				t.Errorf("Expected status %s for cluster %q, got %s", tt.want, tt.status, result.Status)
+			}
+		}
+	})
+
+	t.Run("ShardDetails", func(t *testing.T) {
+		server := newElasticsearchServer(t, "yellow")
+
+		result := NewElasticsearchCheck("test", server.URL).Check(context.Background())
+		if result.Details["cluster_name"] != "test" {
+			t.Errorf("Expected cluster name 'test', got %v", result.Details["cluster_name"])
+		}
+		if result.Details["active_shards"] != 10 {
+			t.Errorf("Expected 10 active shards, got %v", result.Details["active_shards"])
+		}
+		if result.Details["unassigned_shards"] != 2 {
+			t.Errorf("Expected 2 unassigned shards, got %v", result.Details["unassigned_shards"])
+		}
+	})
+
+	t.Run("Indices", func(t *testing.T) {
+		server := newElasticsearchServer(t, "green", "orders")
+
+		result := NewElasticsearchCheckWithOptions("test", server.URL, ElasticsearchOptions{
+			Indices: []string{"orders"},
+		}).Check(context.Background())
+		if result.Status != health.StatusHealthy {
+			t.Errorf("Expected status %s, got %s", health.StatusHealthy, result.Status)
+		}
+
+		result = NewElasticsearchCheckWithOptions("test", server.URL, ElasticsearchOptions{
+			Indices: []string{"orders", "customers"},
+		}).Check(context.Background())
+		if result.Status != health.StatusUnhealthy {
+			t.Errorf("Expected status %s, got %s", health.StatusUnhealthy, result.Status)
+		}
+		missing, _ := result.Details["missing_indices"].([]string)
+		if len(missing) != 1 || missing[0] != "customers" {
+			t.Errorf("Expected missing indices [customers], got %v", result.Details["missing_indices"])
+		}
+	})
+
+	t.Run("BasicAuth", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if user, pass, ok := r.BasicAuth(); !ok || user != "elastic" || pass != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`{"status":"green"}`))
+		}))
+		defer server.Close()
+
+		result := NewElasticsearchCheck("test", server.URL).Check(context.Background())
+		if result.Status != health.StatusUnhealthy {
+			t.Errorf("Expected status %s without credentials, got %s", health.StatusUnhealthy, result.Status)
+		}
+
+		result = NewElasticsearchCheckWithOptions("test", server.URL, ElasticsearchOptions{
+			Username: "elastic",
+			Password: "secret",
+		}).Check(context.Background())
+		if result.Status != health.StatusHealthy {
+			t.Errorf("Expected status %s, got %s", health.StatusHealthy, result.Status)
+		}
+	})
+}
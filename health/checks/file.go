@@ -0,0 +1,89 @@
+package checks
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/katalabut/fast-app/health"
+)
+
+// FileOptions contains options for file health check
+type FileOptions struct {
+	// MaxAge is the maximum time since the file was last modified, e.g. the
+	// interval of the cron job writing a heartbeat file. Zero disables the check.
+	MaxAge time.Duration
+}
+
+// FileCheck checks that a file exists, is readable and is fresh
+type FileCheck struct {
+	name string
+	path string
+	opts FileOptions
+}
+
+// NewFileCheck creates a new file health check
+func NewFileCheck(name, path string, opts FileOptions) *FileCheck {
+	return &FileCheck{
+		name: name,
+		path: path,
+		opts: opts,
+	}
+}
+
+// Name returns the name of the health check
+func (f *FileCheck) Name() string {
+	return f.name
+}
+
+// Check executes the file health check
+func (f *FileCheck) Check(ctx context.Context) health.HealthResult {
+	start := time.Now()
+
+	file, err := os.Open(f.path)
+	if err != nil {
+		duration := time.Since(start)
+		message := "file is not readable"
+		if os.IsNotExist(err) {
+			message = "file does not exist"
+		}
+
+		return health.NewUnhealthyResult(message).
+			WithDetails("error", err.Error()).
+			WithDetails("path", f.path).
+			WithDuration(duration)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	duration := time.Since(start)
+	if err != nil {
+		return health.NewUnhealthyResult("failed to stat file").
+			WithDetails("error", err.Error()).
+			WithDetails("path", f.path).
+			WithDuration(duration)
+	}
+
+	if info.IsDir() {
+		return health.NewUnhealthyResult("path is a directory").
+			WithDetails("path", f.path).
+			WithDuration(duration)
+	}
+
+	age := start.Sub(info.ModTime())
+	if f.opts.MaxAge > 0 && age > f.opts.MaxAge {
+		return health.NewUnhealthyResult("file is stale").
+			WithDetails("path", f.path).
+			WithDetails("modified_at", info.ModTime().UTC().Format(time.RFC3339)).
+			WithDetails("age", age.String()).
+			WithDetails("max_age", f.opts.MaxAge.String()).
+			WithDuration(duration)
+	}
+
+	return health.NewHealthyResult("file is fresh").
+		WithDetails("path", f.path).
+		WithDetails("size", info.Size()).
+		WithDetails("modified_at", info.ModTime().UTC().Format(time.RFC3339)).
+		WithDetails("age", age.String()).
+		WithDuration(duration)
+}
@@ -0,0 +1,74 @@
+package checks
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/katalabut/fast-app/health"
+)
+
+func TestFileCheck(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "heartbeat")
+	if err := os.WriteFile(path, []byte("ok"), 0o644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	t.Run("NewFileCheck", func(t *testing.T) {
+		check := NewFileCheck("test", path, FileOptions{})
+		if check.Name() != "test" {
+			t.Errorf("Expected name 'test', got '%s'", check.Name())
+		}
+	})
+
+	t.Run("Fresh", func(t *testing.T) {
+		result := NewFileCheck("test", path, FileOptions{MaxAge: time.Minute}).Check(context.Background())
+		if result.Status != health.StatusHealthy {
+			t.Errorf("Expected status %s, got %s: %s", health.StatusHealthy, result.Status, result.Message)
+		}
+		if result.Details["size"] != int64(2) {
+			t.Errorf("Expected size 2, got %v", result.Details["size"])
+		}
+	})
+
+	t.Run("Stale", func(t *testing.T) {
+		stale := filepath.Join(dir, "stale")
+		if err := os.WriteFile(stale, nil, 0o644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+		old := time.Now().Add(-time.Hour)
+		if err := os.Chtimes(stale, old, old); err != nil {
+			t.Fatalf("Failed to change file times: %v", err)
+		}
+
+		result := NewFileCheck("test", stale, FileOptions{MaxAge: time.Minute}).Check(context.Background())
+		if result.Status != health.StatusUnhealthy {
+			t.Errorf("Expected status %s, got %s", health.StatusUnhealthy, result.Status)
+		}
+
+		result = NewFileCheck("test", stale, FileOptions{}).Check(context.Background())
+		if result.Status != health.StatusHealthy {
+			t.Errorf("Expected status %s without max age, got %s", health.StatusHealthy, result.Status)
+		}
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		result := NewFileCheck("test", filepath.Join(dir, "missing"), FileOptions{}).Check(context.Background())
+		if result.Status != health.StatusUnhealthy {
+			t.Errorf("Expected status %s, got %s", health.StatusUnhealthy, result.Status)
+		}
+		if result.Message != "file does not exist" {
+			t.Errorf("Expected message 'file does not exist', got '%s'", result.Message)
+		}
+	})
+
+	t.Run("Directory", func(t *testing.T) {
+		result := NewFileCheck("test", dir, FileOptions{}).Check(context.Background())
+		if result.Status != health.StatusUnhealthy {
+			t.Errorf("Expected status %s, got %s", health.StatusUnhealthy, result.Status)
+		}
+	})
+}
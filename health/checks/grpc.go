@@ -0,0 +1,125 @@
+package checks
+
+import (
+	"context"
+	"crypto/tls"
+	"time"
+
+	"github.com/katalabut/fast-app/health"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCOptions contains options for gRPC health check
+type GRPCOptions struct {
+	// Service is the service name sent in the health check request.
+	// An empty name checks the overall health of the server.
+	Service string
+	// Timeout bounds the connection and the health check RPC.
+	Timeout time.Duration
+	// SlowThreshold is the latency above which the endpoint is reported degraded.
+	// Defaults to half of the timeout.
+	SlowThreshold time.Duration
+	// TLS enables transport security. Plaintext is used when nil.
+	TLS *tls.Config
+	// DialOptions are additional options used to create the client connection.
+	DialOptions []grpc.DialOption
+}
+
+// GRPCCheck checks gRPC endpoint health using the standard grpc.health.v1 protocol
+type GRPCCheck struct {
+	name   string
+	target string
+	opts   GRPCOptions
+}
+
+// NewGRPCCheck creates a new gRPC health check for the given target, e.g. "localhost:50051"
+func NewGRPCCheck(name, target string, opts GRPCOptions) *GRPCCheck {
+	if opts.Timeout == 0 {
+		opts.Timeout = 10 * time.Second
+	}
+	if opts.SlowThreshold == 0 {
+		opts.SlowThreshold = opts.Timeout / 2
+	}
+
+	return &GRPCCheck{
+		name:   name,
+		target: target,
+		opts:   opts,
+	}
+}
+
+// Name returns the name of the health check
+func (g *GRPCCheck) Name() string {
+	return g.name
+}
+
+// Check executes the gRPC health check
+func (g *GRPCCheck) Check(ctx context.Context) health.HealthResult {
+	start := time.Now()
+
+	ctx, cancel := context.WithTimeout(ctx, g.opts.Timeout)
+	defer cancel()
+
+	creds := insecure.NewCredentials()
+	if g.opts.TLS != nil {
+		creds = credentials.NewTLS(g.opts.TLS)
+	}
+
+	dialOpts := append([]grpc.DialOption{grpc.WithTransportCredentials(creds)}, g.opts.DialOptions...)
+	conn, err := grpc.NewClient(g.target, dialOpts...)
+	if err != nil {
+		return health.NewUnhealthyResult("failed to create gRPC client").
+			WithDetails("error", err.Error()).
+			WithDetails("target", g.target)
+	}
+	defer conn.Close()
+
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: g.opts.Service})
+	duration := time.Since(start)
+
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return health.NewUnhealthyResult("gRPC health check timeout").
+				WithDetails("timeout", g.opts.Timeout.String()).
+				WithDetails("target", g.target).
+				WithDetails("duration", duration.String()).
+				WithDuration(duration)
+		}
+
+		return health.NewUnhealthyResult("gRPC health check failed").
+			WithDetails("error", err.Error()).
+			WithDetails("code", status.Code(err).String()).
+			WithDetails("target", g.target).
+			WithDetails("service", g.opts.Service).
+			WithDetails("duration", duration.String()).
+			WithDuration(duration)
+	}
+
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		return health.NewUnhealthyResult("gRPC service is not serving").
+			WithDetails("serving_status", resp.GetStatus().String()).
+			WithDetails("target", g.target).
+			WithDetails("service", g.opts.Service).
+			WithDetails("duration", duration.String()).
+			WithDuration(duration)
+	}
+
+	if duration > g.opts.SlowThreshold {
+		return health.NewDegradedResult("gRPC endpoint is slow").
+			WithDetails("serving_status", resp.GetStatus().String()).
+			WithDetails("target", g.target).
+			WithDetails("duration", duration.String()).
+			WithDetails("threshold", g.opts.SlowThreshold.String()).
+			WithDuration(duration)
+	}
+
+	return health.NewHealthyResult("gRPC endpoint is healthy").
+		WithDetails("serving_status", resp.GetStatus().String()).
+		WithDetails("target", g.target).
+		WithDetails("duration", duration.String()).
+		WithDuration(duration)
+}
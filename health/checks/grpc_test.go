@@ -0,0 +1,103 @@
+package checks
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/katalabut/fast-app/health"
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func startGRPCHealthServer(t *testing.T) (string, *grpchealth.Server) {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+
+	hs := grpchealth.NewServer()
+	srv := grpc.NewServer()
+	healthpb.RegisterHealthServer(srv, hs)
+
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	return lis.Addr().String(), hs
+}
+
+func TestGRPCCheck(t *testing.T) {
+	t.Run("NewGRPCCheck", func(t *testing.T) {
+		check := NewGRPCCheck("test", "localhost:50051", GRPCOptions{})
+		if check.Name() != "test" {
+			t.Errorf("Expected name 'test', got '%s'", check.Name())
+		}
+		if check.opts.Timeout != 10*time.Second {
+			t.Errorf("Expected default timeout 10s, got %v", check.opts.Timeout)
+		}
+		if check.opts.SlowThreshold != 5*time.Second {
+			t.Errorf("Expected default slow threshold 5s, got %v", check.opts.SlowThreshold)
+		}
+	})
+
+	t.Run("Serving", func(t *testing.T) {
+		addr, _ := startGRPCHealthServer(t)
+
+		result := NewGRPCCheck("test", addr, GRPCOptions{}).Check(context.Background())
+		if result.Status != health.StatusHealthy {
+			t.Errorf("Expected status %s, got %s: %s", health.StatusHealthy, result.Status, result.Message)
+		}
+		if result.Details["serving_status"] != "SERVING" {
+			t.Errorf("Expected serving status SERVING, got %v", result.Details["serving_status"])
+		}
+	})
+
+	t.Run("NotServing", func(t *testing.T) {
+		addr, hs := startGRPCHealthServer(t)
+		hs.SetServingStatus("orders", healthpb.HealthCheckResponse_NOT_SERVING)
+
+		result := NewGRPCCheck("test", addr, GRPCOptions{Service: "orders"}).Check(context.Background())
+		if result.Status != health.StatusUnhealthy {
+			t.Errorf("Expected status %s, got %s", health.StatusUnhealthy, result.Status)
+		}
+	})
+
+	t.Run("UnknownService", func(t *testing.T) {
+		addr, _ := startGRPCHealthServer(t)
+
+		result := NewGRPCCheck("test", addr, GRPCOptions{Service: "unknown"}).Check(context.Background())
+		if result.Status != health.StatusUnhealthy {
+			t.Errorf("Expected status %s, got %s", health.StatusUnhealthy, result.Status)
+		}
+		if result.Details["code"] != "NotFound" {
+			t.Errorf("Expected code NotFound, got %v", result.Details["code"])
+		}
+	})
+
+	t.Run("Slow", func(t *testing.T) {
+		addr, _ := startGRPCHealthServer(t)
+
+		result := NewGRPCCheck("test", addr, GRPCOptions{SlowThreshold: time.Nanosecond}).Check(context.Background())
+		if result.Status != health.StatusDegraded {
+			t.Errorf("Expected status %s, got %s", health.StatusDegraded, result.Status)
+		}
+	})
+
+	t.Run("Unreachable", func(t *testing.T) {
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("Failed to listen: %v", err)
+		}
+		addr := lis.Addr().String()
+		lis.Close()
+
+		result := NewGRPCCheck("test", addr, GRPCOptions{Timeout: 500 * time.Millisecond}).Check(context.Background())
+		if result.Status != health.StatusUnhealthy {
+			t.Errorf("Expected status %s, got %s", health.StatusUnhealthy, result.Status)
+		}
+	})
+}
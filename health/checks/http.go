@@ -1,9 +1,14 @@
 package checks
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -17,6 +22,37 @@ type HTTPOptions struct {
 	ExpectedBody   string
 	Method         string
 	Headers        map[string]string
+
+	// ExpectedStatusRange accepts any status code in the range, e.g. {Min: 200, Max: 299}.
+	// When set, ExpectedStatus is ignored.
+	ExpectedStatusRange StatusRange
+	// ExpectedBodyPattern is a regular expression the response body must match.
+	ExpectedBodyPattern *regexp.Regexp
+	// ExpectedJSON maps paths in the JSON response body to their expected values,
+	// e.g. {"status": "ok", "checks.0.state": "up"}. Paths are dot-separated
+	// object keys and array indices, optionally prefixed with "$.".
+	ExpectedJSON map[string]interface{}
+
+	// Body is sent as the request body, e.g. with Method set to POST.
+	Body string
+	// TokenProvider returns a bearer token for the Authorization header on every
+	// check, which allows refreshable tokens such as OAuth access tokens.
+	TokenProvider func(ctx context.Context) (string, error)
+}
+
+// StatusRange is an inclusive range of HTTP status codes
+type StatusRange struct {
+	Min int
+	Max int
+}
+
+// Contains reports whether the status code is in the range
+func (r StatusRange) Contains(code int) bool {
+	return code >= r.Min && code <= r.Max
+}
+
+func (r StatusRange) isZero() bool {
+	return r.Min == 0 && r.Max == 0
 }
 
 // HTTPCheck checks HTTP endpoint health
@@ -73,7 +109,11 @@ func (h *HTTPCheck) Check(ctx context.Context) health.HealthResult {
 	}
 
 	// Create request
-	req, err := http.NewRequestWithContext(ctx, h.opts.Method, h.url, nil)
+	var reqBody io.Reader
+	if h.opts.Body != "" {
+		reqBody = strings.NewReader(h.opts.Body)
+	}
+	req, err := http.NewRequestWithContext(ctx, h.opts.Method, h.url, reqBody)
 	if err != nil {
 		return health.NewUnhealthyResult("failed to create HTTP request").
 			WithDetails("error", err.Error()).
@@ -85,6 +125,16 @@ func (h *HTTPCheck) Check(ctx context.Context) health.HealthResult {
 		req.Header.Set(key, value)
 	}
 
+	if h.opts.TokenProvider != nil {
+		token, err := h.opts.TokenProvider(ctx)
+		if err != nil {
+			return health.NewUnhealthyResult("failed to obtain HTTP auth token").
+				WithDetails("error", err.Error()).
+				WithDetails("url", h.url)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
 	// Execute request
 	resp, err := client.Do(req)
 	duration := time.Since(start)
@@ -107,7 +157,16 @@ func (h *HTTPCheck) Check(ctx context.Context) health.HealthResult {
 	defer resp.Body.Close()
 
 	// Check status code
-	if resp.StatusCode != h.opts.ExpectedStatus {
+	if !h.opts.ExpectedStatusRange.isZero() {
+		if !h.opts.ExpectedStatusRange.Contains(resp.StatusCode) {
+			return health.NewUnhealthyResult("unexpected HTTP status code").
+				WithDetails("expected_status", fmt.Sprintf("%d-%d", h.opts.ExpectedStatusRange.Min, h.opts.ExpectedStatusRange.Max)).
+				WithDetails("actual_status", resp.StatusCode).
+				WithDetails("url", h.url).
+				WithDetails("duration", duration.String()).
+				WithDuration(duration)
+		}
+	} else if resp.StatusCode != h.opts.ExpectedStatus {
 		return health.NewUnhealthyResult("unexpected HTTP status code").
 			WithDetails("expected_status", h.opts.ExpectedStatus).
 			WithDetails("actual_status", resp.StatusCode).
@@ -117,7 +176,7 @@ func (h *HTTPCheck) Check(ctx context.Context) health.HealthResult {
 	}
 
 	// Check response body if expected
-	if h.opts.ExpectedBody != "" {
+	if h.opts.ExpectedBody != "" || h.opts.ExpectedBodyPattern != nil || len(h.opts.ExpectedJSON) > 0 {
 		body, err := io.ReadAll(resp.Body)
 		if err != nil {
 			return health.NewUnhealthyResult("failed to read HTTP response body").
@@ -135,6 +194,26 @@ func (h *HTTPCheck) Check(ctx context.Context) health.HealthResult {
 				WithDetails("duration", duration.String()).
 				WithDuration(duration)
 		}
+
+		if h.opts.ExpectedBodyPattern != nil && !h.opts.ExpectedBodyPattern.Match(body) {
+			return health.NewUnhealthyResult("HTTP response body does not match expected pattern").
+				WithDetails("expected_pattern", h.opts.ExpectedBodyPattern.String()).
+				WithDetails("actual_body", string(body)).
+				WithDetails("url", h.url).
+				WithDetails("duration", duration.String()).
+				WithDuration(duration)
+		}
+
+		if len(h.opts.ExpectedJSON) > 0 {
+			if err := matchJSON(body, h.opts.ExpectedJSON); err != nil {
+				return health.NewUnhealthyResult("HTTP response body does not match expected JSON").
+					WithDetails("error", err.Error()).
+					WithDetails("actual_body", string(body)).
+					WithDetails("url", h.url).
+					WithDetails("duration", duration.String()).
+					WithDuration(duration)
+			}
+		}
 	}
 
 	result := health.NewHealthyResult("HTTP endpoint is healthy").
@@ -155,3 +234,57 @@ func (h *HTTPCheck) Check(ctx context.Context) health.HealthResult {
 
 	return result
 }
+
+// matchJSON checks that the values at the given paths of a JSON document equal
+// the expected values. Values are compared by their string representation, so
+// that numbers match regardless of their Go type.
+func matchJSON(body []byte, expected map[string]interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return fmt.Errorf("invalid JSON: %v", err)
+	}
+
+	for path, want := range expected {
+		got, ok := lookupJSON(doc, path)
+		if !ok {
+			return fmt.Errorf("path %q not found", path)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			return fmt.Errorf("path %q: expected %v, got %v", path, want, got)
+		}
+	}
+
+	return nil
+}
+
+// lookupJSON returns the value at a dot-separated path of a decoded JSON document.
+func lookupJSON(doc interface{}, path string) (interface{}, bool) {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	if path == "" {
+		return doc, true
+	}
+
+	for _, key := range strings.Split(path, ".") {
+		switch node := doc.(type) {
+		case map[string]interface{}:
+			v, ok := node[key]
+			if !ok {
+				return nil, false
+			}
+			doc = v
+		case []interface{}:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, false
+			}
+			doc = node[i]
+		default:
+			return nil, false
+		}
+	}
+
+	return doc, true
+}
@@ -2,8 +2,11 @@ package checks
 
 import (
 	"context"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
 	"testing"
 	"time"
 
@@ -135,3 +138,120 @@ func TestHTTPCheck(t *testing.T) {
 		}
 	})
 }
+
+func TestHTTPCheckAssertions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Method == http.MethodPost {
+			body, _ := io.ReadAll(r.Body)
+			if string(body) != `{"ping":true}` {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"status":"ok","version":"1.4.2","checks":[{"name":"db","latency_ms":12}]}`))
+	}))
+	defer server.Close()
+
+	token := func(ctx context.Context) (string, error) {
+		return "fresh", nil
+	}
+	accepted := StatusRange{Min: 200, Max: 299}
+
+	t.Run("TokenProvider", func(t *testing.T) {
+		result := NewHTTPCheckWithOptions("test", server.URL, HTTPOptions{
+			TokenProvider:       token,
+			ExpectedStatusRange: accepted,
+		}).Check(context.Background())
+		if result.Status != health.StatusHealthy {
+			t.Errorf("Expected status %s, got %s: %v", health.StatusHealthy, result.Status, result.Details)
+		}
+
+		result = NewHTTPCheckWithOptions("test", server.URL, HTTPOptions{
+			TokenProvider: func(ctx context.Context) (string, error) {
+				return "", errors.New("token expired")
+			},
+		}).Check(context.Background())
+		if result.Status != health.StatusUnhealthy {
+			t.Errorf("Expected status %s, got %s", health.StatusUnhealthy, result.Status)
+		}
+	})
+
+	t.Run("StatusRange", func(t *testing.T) {
+		result := NewHTTPCheckWithOptions("test", server.URL, HTTPOptions{
+			TokenProvider: token,
+		}).Check(context.Background())
+		if result.Status != health.StatusUnhealthy {
+			t.Errorf("Expected status %s for 202 without range, got %s", health.StatusUnhealthy, result.Status)
+		}
+
+		result = NewHTTPCheckWithOptions("test", server.URL, HTTPOptions{
+			ExpectedStatusRange: accepted,
+		}).Check(context.Background())
+		if result.Status != health.StatusUnhealthy {
+			t.Errorf("Expected status %s for 401, got %s", health.StatusUnhealthy, result.Status)
+		}
+	})
+
+	t.Run("BodyPattern", func(t *testing.T) {
+		result := NewHTTPCheckWithOptions("test", server.URL, HTTPOptions{
+			TokenProvider:       token,
+			ExpectedStatusRange: accepted,
+			ExpectedBodyPattern: regexp.MustCompile(`"version":"1\.\d+\.\d+"`),
+		}).Check(context.Background())
+		if result.Status != health.StatusHealthy {
+			t.Errorf("Expected status %s, got %s", health.StatusHealthy, result.Status)
+		}
+
+		result = NewHTTPCheckWithOptions("test", server.URL, HTTPOptions{
+			TokenProvider:       token,
+			ExpectedStatusRange: accepted,
+			ExpectedBodyPattern: regexp.MustCompile(`"version":"2\.`),
+		}).Check(context.Background())
+		if result.Status != health.StatusUnhealthy {
+			t.Errorf("Expected status %s, got %s", health.StatusUnhealthy, result.Status)
+		}
+	})
+
+	t.Run("ExpectedJSON", func(t *testing.T) {
+		result := NewHTTPCheckWithOptions("test", server.URL, HTTPOptions{
+			TokenProvider:       token,
+			ExpectedStatusRange: accepted,
+			ExpectedJSON: map[string]interface{}{
+				"$.status":            "ok",
+				"checks.0.name":       "db",
+				"checks.0.latency_ms": 12,
+			},
+		}).Check(context.Background())
+		if result.Status != health.StatusHealthy {
+			t.Errorf("Expected status %s, got %s: %v", health.StatusHealthy, result.Status, result.Details["error"])
+		}
+
+		for path, want := range map[string]interface{}{"status": "degraded", "checks.1.name": "db", "missing": "x"} {
+			result = NewHTTPCheckWithOptions("test", server.URL, HTTPOptions{
+				TokenProvider:       token,
+				ExpectedStatusRange: accepted,
+				ExpectedJSON:        map[string]interface{}{path: want},
+			}).Check(context.Background())
+			if result.Status != health.StatusUnhealthy {
+				t.Errorf("Expected status %s for %s, got %s", health.StatusUnhealthy, path, result.Status)
+			}
+		}
+	})
+
+	t.Run("PostBody", func(t *testing.T) {
+		result := NewHTTPCheckWithOptions("test", server.URL, HTTPOptions{
+			Method:              http.MethodPost,
+			Body:                `{"ping":true}`,
+			TokenProvider:       token,
+			ExpectedStatusRange: accepted,
+		}).Check(context.Background())
+		if result.Status != health.StatusHealthy {
+			t.Errorf("Expected status %s, got %s", health.StatusHealthy, result.Status)
+		}
+	})
+}
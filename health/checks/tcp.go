@@ -0,0 +1,102 @@
+package checks
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"time"
+
+	"github.com/katalabut/fast-app/health"
+)
+
+// TCPOptions contains options for TCP health check
+type TCPOptions struct {
+	Timeout time.Duration
+	// TLS enables a TLS handshake after the connection is established.
+	TLS *tls.Config
+}
+
+// TCPCheck checks that a TCP endpoint accepts connections
+type TCPCheck struct {
+	name string
+	addr string
+	opts TCPOptions
+}
+
+// NewTCPCheck creates a new TCP health check for the given address, e.g. "memcached:11211"
+func NewTCPCheck(name, addr string) *TCPCheck {
+	return NewTCPCheckWithOptions(name, addr, TCPOptions{})
+}
+
+// NewTCPCheckWithOptions creates a new TCP health check with options
+func NewTCPCheckWithOptions(name, addr string, opts TCPOptions) *TCPCheck {
+	if opts.Timeout == 0 {
+		opts.Timeout = 5 * time.Second
+	}
+
+	return &TCPCheck{
+		name: name,
+		addr: addr,
+		opts: opts,
+	}
+}
+
+// Name returns the name of the health check
+func (c *TCPCheck) Name() string {
+	return c.name
+}
+
+// Check executes the TCP health check
+func (c *TCPCheck) Check(ctx context.Context) health.HealthResult {
+	start := time.Now()
+
+	ctx, cancel := context.WithTimeout(ctx, c.opts.Timeout)
+	defer cancel()
+
+	var (
+		conn net.Conn
+		err  error
+	)
+	if c.opts.TLS != nil {
+		dialer := &tls.Dialer{Config: c.opts.TLS}
+		conn, err = dialer.DialContext(ctx, "tcp", c.addr)
+	} else {
+		var dialer net.Dialer
+		conn, err = dialer.DialContext(ctx, "tcp", c.addr)
+	}
+	duration := time.Since(start)
+
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return health.NewUnhealthyResult("TCP connection timeout").
+				WithDetails("timeout", c.opts.Timeout.String()).
+				WithDetails("address", c.addr).
+				WithDetails("duration", duration.String()).
+				WithDuration(duration)
+		}
+
+		return health.NewUnhealthyResult("TCP connection failed").
+			WithDetails("error", err.Error()).
+			WithDetails("address", c.addr).
+			WithDetails("tls", c.opts.TLS != nil).
+			WithDetails("duration", duration.String()).
+			WithDuration(duration)
+	}
+	conn.Close()
+
+	// Check if connect latency is concerning (more than half of timeout)
+	if duration > c.opts.Timeout/2 {
+		return health.NewDegradedResult("TCP endpoint is slow").
+			WithDetails("address", c.addr).
+			WithDetails("tls", c.opts.TLS != nil).
+			WithDetails("duration", duration.String()).
+			WithDetails("threshold", (c.opts.Timeout / 2).String()).
+			WithDuration(duration)
+	}
+
+	return health.NewHealthyResult("TCP endpoint is reachable").
+		WithDetails("address", c.addr).
+		WithDetails("tls", c.opts.TLS != nil).
+		WithDetails("duration", duration.String()).
+		WithDuration(duration)
+}
@@ -0,0 +1,75 @@
+package checks
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/katalabut/fast-app/health"
+)
+
+func TestTCPCheck(t *testing.T) {
+	t.Run("NewTCPCheck", func(t *testing.T) {
+		check := NewTCPCheck("test", "localhost:11211")
+		if check.Name() != "test" {
+			t.Errorf("Expected name 'test', got '%s'", check.Name())
+		}
+		if check.opts.Timeout != 5*time.Second {
+			t.Errorf("Expected default timeout 5s, got %v", check.opts.Timeout)
+		}
+	})
+
+	t.Run("Reachable", func(t *testing.T) {
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("Failed to listen: %v", err)
+		}
+		defer lis.Close()
+
+		result := NewTCPCheck("test", lis.Addr().String()).Check(context.Background())
+		if result.Status != health.StatusHealthy {
+			t.Errorf("Expected status %s, got %s", health.StatusHealthy, result.Status)
+		}
+		if result.Details["address"] != lis.Addr().String() {
+			t.Errorf("Expected address %s, got %v", lis.Addr(), result.Details["address"])
+		}
+	})
+
+	t.Run("Unreachable", func(t *testing.T) {
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("Failed to listen: %v", err)
+		}
+		addr := lis.Addr().String()
+		lis.Close()
+
+		result := NewTCPCheck("test", addr).Check(context.Background())
+		if result.Status != health.StatusUnhealthy {
+			t.Errorf("Expected status %s, got %s", health.StatusUnhealthy, result.Status)
+		}
+	})
+
+	t.Run("TLS", func(t *testing.T) {
+		server := httptest.NewTLSServer(http.NotFoundHandler())
+		defer server.Close()
+		addr := server.Listener.Addr().String()
+
+		result := NewTCPCheckWithOptions("test", addr, TCPOptions{
+			TLS: &tls.Config{RootCAs: server.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs},
+		}).Check(context.Background())
+		if result.Status != health.StatusHealthy {
+			t.Errorf("Expected status %s, got %s: %v", health.StatusHealthy, result.Status, result.Details["error"])
+		}
+
+		result = NewTCPCheckWithOptions("test", addr, TCPOptions{
+			TLS: &tls.Config{},
+		}).Check(context.Background())
+		if result.Status != health.StatusUnhealthy {
+			t.Errorf("Expected status %s for untrusted certificate, got %s", health.StatusUnhealthy, result.Status)
+		}
+	})
+}
@@ -0,0 +1,105 @@
+package checks
+
+import (
+	"context"
+	"time"
+
+	"github.com/katalabut/fast-app/health"
+)
+
+// ThresholdOptions contains options for threshold health check
+type ThresholdOptions struct {
+	// Warn is the value at which the check is reported degraded.
+	Warn float64
+	// Critical is the value at which the check is reported unhealthy.
+	Critical float64
+	// LowerIsWorse inverts the thresholds for probes where small values are bad,
+	// e.g. free disk space: the check is degraded at or below Warn and unhealthy
+	// at or below Critical.
+	LowerIsWorse bool
+	// Unit is reported in the details along with the value, e.g. "messages".
+	Unit    string
+	Timeout time.Duration
+}
+
+// ThresholdCheck maps a numeric probe to a health status using warn and critical thresholds
+type ThresholdCheck struct {
+	name  string
+	probe func(ctx context.Context) (float64, error)
+	opts  ThresholdOptions
+}
+
+// NewThresholdCheck creates a new threshold health check for a numeric probe,
+// such as a queue depth or consumer lag.
+//
+// Example:
+//
+//	check := checks.NewThresholdCheck("queue-depth", queue.Depth, checks.ThresholdOptions{
+//	    Warn:     1000,
+//	    Critical: 10000,
+//	    Unit:     "messages",
+//	})
+func NewThresholdCheck(name string, probe func(ctx context.Context) (float64, error), opts ThresholdOptions) *ThresholdCheck {
+	if opts.Timeout == 0 {
+		opts.Timeout = 5 * time.Second
+	}
+
+	return &ThresholdCheck{
+		name:  name,
+		probe: probe,
+		opts:  opts,
+	}
+}
+
+// Name returns the name of the health check
+func (c *ThresholdCheck) Name() string {
+	return c.name
+}
+
+// Check executes the threshold health check
+func (c *ThresholdCheck) Check(ctx context.Context) health.HealthResult {
+	start := time.Now()
+
+	ctx, cancel := context.WithTimeout(ctx, c.opts.Timeout)
+	defer cancel()
+
+	value, err := c.probe(ctx)
+	duration := time.Since(start)
+
+	if err != nil {
+		return health.NewUnhealthyResult("failed to probe value").
+			WithDetails("error", err.Error()).
+			WithDetails("duration", duration.String()).
+			WithDuration(duration)
+	}
+
+	var result health.HealthResult
+	switch {
+	case c.reached(value, c.opts.Critical):
+		result = health.NewUnhealthyResult("value reached critical threshold")
+	case c.reached(value, c.opts.Warn):
+		result = health.NewDegradedResult("value reached warning threshold")
+	default:
+		result = health.NewHealthyResult("value is within thresholds")
+	}
+
+	result = result.
+		WithDetails("value", value).
+		WithDetails("warn", c.opts.Warn).
+		WithDetails("critical", c.opts.Critical).
+		WithDetails("duration", duration.String()).
+		WithDuration(duration)
+	if c.opts.Unit != "" {
+		result = result.WithDetails("unit", c.opts.Unit)
+	}
+
+	return result
+}
+
+// reached reports whether the value has reached the threshold.
+func (c *ThresholdCheck) reached(value, threshold float64) bool {
+	if c.opts.LowerIsWorse {
+		return value <= threshold
+	}
+	return value >= threshold
+}
@@ -0,0 +1,85 @@
+package checks
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/katalabut/fast-app/health"
+)
+
+func constantProbe(value float64) func(ctx context.Context) (float64, error) {
+	return func(ctx context.Context) (float64, error) {
+		return value, nil
+	}
+}
+
+func TestThresholdCheck(t *testing.T) {
+	t.Run("NewThresholdCheck", func(t *testing.T) {
+		check := NewThresholdCheck("test", constantProbe(0), ThresholdOptions{})
+		if check.Name() != "test" {
+			t.Errorf("Expected name 'test', got '%s'", check.Name())
+		}
+	})
+
+	t.Run("HigherIsWorse", func(t *testing.T) {
+		opts := ThresholdOptions{Warn: 100, Critical: 1000, Unit: "messages"}
+
+		tests := []struct {
+			value float64
+			want  health.HealthStatus
+		}{
+			{10, health.StatusHealthy},
+			{100, health.StatusDegraded},
+			{999, health.StatusDegraded},
+			{1000, health.StatusUnhealthy},
+		}
+
+		for _, tt := range tests {
+			result := NewThresholdCheck("queue", constantProbe(tt.value), opts).Check(context.Background())
+			if result.Status != tt.want {
+				t.Errorf("Expected status %s for %v, got %s", tt.want, tt.value, result.Status)
+			}
+			if result.Details["value"] != tt.value {
+				t.Errorf("Expected value %v in details, got %v", tt.value, result.Details["value"])
+			}
+			if result.Details["unit"] != "messages" {
+				t.Errorf("Expected unit 'messages', got %v", result.Details["unit"])
+			}
+		}
+	})
+
+	t.Run("LowerIsWorse", func(t *testing.T) {
+		opts := ThresholdOptions{Warn: 20, Critical: 5, LowerIsWorse: true}
+
+		tests := []struct {
+			value float64
+			want  health.HealthStatus
+		}{
+			{50, health.StatusHealthy},
+			{20, health.StatusDegraded},
+			{5, health.StatusUnhealthy},
+		}
+
+		for _, tt := range tests {
+			result := NewThresholdCheck("disk-free", constantProbe(tt.value), opts).Check(context.Background())
+			if result.Status != tt.want {
+				t.Errorf("Expected status %s for %v, got %s", tt.want, tt.value, result.Status)
+			}
+		}
+	})
+
+	t.Run("ProbeError", func(t *testing.T) {
+		check := NewThresholdCheck("test", func(ctx context.Context) (float64, error) {
+			return 0, errors.New("broker unavailable")
+		}, ThresholdOptions{Warn: 1, Critical: 2})
+
+		result := check.Check(context.Background())
+		if result.Status != health.StatusUnhealthy {
+			t.Errorf("Expected status %s, got %s", health.StatusUnhealthy, result.Status)
+		}
+		if result.Details["error"] != "broker unavailable" {
+			t.Errorf("Expected error in details, got %v", result.Details["error"])
+		}
+	})
+}
@@ -0,0 +1,87 @@
+package health
+
+import (
+	"context"
+	"strings"
+
+	"github.com/katalabut/fast-app/logger"
+)
+
+// StatusSkipped is reported for a check that was not run because a check it
+// depends on is unhealthy or skipped itself.
+const StatusSkipped HealthStatus = "skipped"
+
+// WithDependsOn returns a checker that depends on the named checks. While one
+// of them is unhealthy, the checker is not run and is reported as skipped
+// instead of piling on timeouts, e.g. a cache check behind a database.
+// Dependencies that are not registered are ignored.
+//
+// Example:
+//
+//	manager.RegisterChecker(health.WithDependsOn(
+//	    checks.NewHTTPCheck("orders-api", ordersURL),
+//	    "database",
+//	))
+func WithDependsOn(checker HealthChecker, parents ...string) HealthChecker {
+	opts := checkOptions(checker)
+	checker = unwrapChecker(checker)
+	opts.DependsOn = append(append([]string(nil), opts.DependsOn...), parents...)
+	return WithCheckOptions(checker, opts)
+}
+
+// registerDependencies records the dependencies of a checker, unless they
+// would introduce a cycle. It must be called with m.mu held.
+func (m *Manager) registerDependencies(name string, parents []string) {
+	delete(m.dependsOn, name)
+	if len(parents) == 0 {
+		return
+	}
+
+	if path, ok := m.dependencyPath(parents, name, nil); ok {
+		logger.Error(context.Background(), "Health checker dependencies form a cycle, ignoring them",
+			"name", name, "cycle", strings.Join(append([]string{name}, path...), " -> "))
+		return
+	}
+	m.dependsOn[name] = parents
+}
+
+// dependencyPath returns the path of dependencies from one of the checks to
+// target, if any. It must be called with m.mu held.
+func (m *Manager) dependencyPath(from []string, target string, path []string) ([]string, bool) {
+	for _, name := range from {
+		if name == target {
+			return append(path, name), true
+		}
+		if p, ok := m.dependencyPath(m.dependsOn[name], target, append(path, name)); ok {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// checkDependencies runs the checks the named check depends on and returns
+// a skipped result if one of them is unhealthy or skipped.
+func (m *Manager) checkDependencies(ctx context.Context, name string) (HealthResult, bool) {
+	m.mu.RLock()
+	parents := m.dependsOn[name]
+	m.mu.RUnlock()
+
+	for _, parent := range parents {
+		m.mu.RLock()
+		checker, ok := m.checkers[parent]
+		m.mu.RUnlock()
+		if !ok {
+			continue
+		}
+
+		result := m.checkWithCache(ctx, parent, checker)
+		if result.Status == StatusUnhealthy || result.Status == StatusSkipped {
+			return HealthResult{
+				Status:  StatusSkipped,
+				Message: "dependency " + parent + " is " + string(result.Status),
+				Details: map[string]interface{}{"dependency": parent},
+			}, true
+		}
+	}
+	return HealthResult{}, false
+}
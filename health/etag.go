@@ -0,0 +1,76 @@
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// ETag returns a weak entity tag of a health snapshot for conditional
+// requests. It changes with the status, message and details of the checks and
+// with the given variant, e.g. the response format, but not with timestamps
+// or durations, so that unchanged health is answered with 304 Not Modified.
+func ETag(status HealthStatus, results map[string]HealthResult, variant ...string) string {
+	h := fnv.New64a()
+	write := func(s string) {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+
+	write(string(status))
+	for _, v := range variant {
+		write(v)
+	}
+
+	names := make([]string, 0, len(results))
+	for name := range results {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		result := results[name]
+		write(name)
+		write(string(result.Status))
+		write(result.Message)
+		// Map keys are sorted by encoding/json, unencodable details are ignored.
+		details, _ := json.Marshal(result.Details)
+		write(string(details))
+	}
+
+	return fmt.Sprintf(`W/"%x"`, h.Sum64())
+}
+
+// MatchesETag reports whether an If-None-Match header matches the entity tag,
+// using the weak comparison of RFC 9110.
+func MatchesETag(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// NotModified sets the ETag header of a response and answers 304 Not Modified
+// if the request's If-None-Match header matches it, reporting whether it did.
+// Clients are asked to revalidate every time with Cache-Control: no-cache.
+func NotModified(w http.ResponseWriter, r *http.Request, etag string) bool {
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "no-cache")
+
+	if !MatchesETag(r.Header.Get("If-None-Match"), etag) {
+		return false
+	}
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
@@ -0,0 +1,57 @@
+package health
+
+import (
+	"testing"
+	"time"
+)
+
+func TestETag(t *testing.T) {
+	results := map[string]HealthResult{
+		"db":    NewHealthyResult("ok").WithDetails("pool", 10).WithDuration(time.Millisecond),
+		"cache": NewDegradedResult("slow"),
+	}
+	etag := ETag(StatusDegraded, results)
+
+	t.Run("IgnoresDurations", func(t *testing.T) {
+		changed := map[string]HealthResult{
+			"db":    results["db"].WithDuration(time.Second),
+			"cache": results["cache"],
+		}
+		if got := ETag(StatusDegraded, changed); got != etag {
+			t.Errorf("Expected ETag %s, got %s", etag, got)
+		}
+	})
+
+	t.Run("ChangesWithResults", func(t *testing.T) {
+		changed := map[string]HealthResult{
+			"db":    NewHealthyResult("ok").WithDetails("pool", 9),
+			"cache": results["cache"],
+		}
+		if got := ETag(StatusDegraded, changed); got == etag {
+			t.Error("Expected ETag to change with the details")
+		}
+		if got := ETag(StatusDegraded, results, "health+json"); got == etag {
+			t.Error("Expected ETag to change with the variant")
+		}
+	})
+}
+
+func TestMatchesETag(t *testing.T) {
+	tests := []struct {
+		ifNoneMatch string
+		expected    bool
+	}{
+		{"", false},
+		{`W/"abc"`, true},
+		{`"abc"`, true},
+		{`"xyz", W/"abc"`, true},
+		{`"xyz"`, false},
+		{"*", true},
+	}
+
+	for _, tt := range tests {
+		if got := MatchesETag(tt.ifNoneMatch, `W/"abc"`); got != tt.expected {
+			t.Errorf("Expected %v for %q, got %v", tt.expected, tt.ifNoneMatch, got)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package health
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// CheckFilter selects a subset of the registered checks, e.g. from the query
+// parameters of the health checks endpoint. A check is selected when it is
+// listed in Checks (or Checks is empty), is not listed in Exclude, has at
+// least one of Tags (or Tags is empty) and has all of Labels.
+type CheckFilter struct {
+	Checks  []string
+	Exclude []string
+	Tags    []string
+	Labels  map[string]string
+}
+
+// ParseCheckFilter returns the filter described by the "check", "exclude",
+// "tag" and "label" query parameters. Each parameter accepts a comma separated
+// list and may be repeated, e.g. "?check=db,redis&tag=critical"; labels are
+// given as key=value pairs, e.g. "?label=team=payments".
+func ParseCheckFilter(query url.Values) CheckFilter {
+	f := CheckFilter{
+		Checks:  splitQuery(query["check"]),
+		Exclude: splitQuery(query["exclude"]),
+		Tags:    splitQuery(query["tag"]),
+	}
+	for _, label := range splitQuery(query["label"]) {
+		key, value, _ := strings.Cut(label, "=")
+		if f.Labels == nil {
+			f.Labels = make(map[string]string)
+		}
+		f.Labels[key] = value
+	}
+	return f
+}
+
+// IsEmpty reports whether the filter selects every check.
+func (f CheckFilter) IsEmpty() bool {
+	return len(f.Checks) == 0 && len(f.Exclude) == 0 && len(f.Tags) == 0 && len(f.Labels) == 0
+}
+
+// Matches reports whether the filter selects a check with the given name and options.
+func (f CheckFilter) Matches(name string, opts CheckOptions) bool {
+	if len(f.Checks) > 0 && !slices.Contains(f.Checks, name) {
+		return false
+	}
+	if slices.Contains(f.Exclude, name) {
+		return false
+	}
+	if len(f.Tags) > 0 && !slices.ContainsFunc(f.Tags, func(tag string) bool {
+		return slices.Contains(opts.Tags, tag)
+	}) {
+		return false
+	}
+	for key, value := range f.Labels {
+		if v, ok := opts.Labels[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckMatching runs the checks selected by the filter and returns their
+// results together with their aggregated status. An empty filter is the same
+// as CheckAllWithStatus; otherwise the status only covers the selected checks
+// and overall status listeners are not notified.
+func (m *Manager) CheckMatching(ctx context.Context, filter CheckFilter) (map[string]HealthResult, HealthStatus) {
+	if filter.IsEmpty() {
+		return m.CheckAllWithStatus(ctx)
+	}
+
+	results := m.checkAll(ctx, func(name string) bool {
+		return filter.Matches(name, checkOptions(m.checkers[name]))
+	})
+	return results, m.aggregate(results)
+}
+
+// LatestMatching returns the latest results of the checks selected by the
+// filter and their aggregated status, as CheckMatching would, without running
+// any check, see Snapshot. It reports false unless every selected check has a
+// result, e.g. before the checks first ran.
+func (m *Manager) LatestMatching(filter CheckFilter) (map[string]HealthResult, HealthStatus, bool) {
+	m.mu.RLock()
+	names := make([]string, 0, len(m.checkers))
+	for name, checker := range m.checkers {
+		if filter.Matches(name, checkOptions(checker)) {
+			names = append(names, name)
+		}
+	}
+	m.mu.RUnlock()
+
+	m.listenersMu.Lock()
+	results := make(map[string]HealthResult, len(names))
+	for _, name := range names {
+		result, ok := m.lastResults[name]
+		if !ok {
+			m.listenersMu.Unlock()
+			return nil, "", false
+		}
+		results[name] = result
+	}
+	m.listenersMu.Unlock()
+
+	status := m.aggregate(results)
+	if filter.IsEmpty() {
+		if o, ok := m.override(""); ok {
+			status = o.Status
+		}
+	}
+	return results, status, true
+}
+
+// splitQuery splits comma separated query values, dropping empty items.
+func splitQuery(values []string) []string {
+	var items []string
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+	return items
+}
@@ -0,0 +1,88 @@
+package health
+
+import (
+	"context"
+	"sort"
+
+	"github.com/katalabut/fast-app/logger"
+)
+
+// Gate is a named readiness gate. The manager is not ready while any of its
+// gates is closed, e.g. until a cache is warmed up or a migration is applied.
+type Gate struct {
+	name    string
+	manager *Manager
+}
+
+// ReadinessGate returns the readiness gate with the given name, registering
+// it closed on the first call. Later calls with the same name return the
+// same gate.
+func (m *Manager) ReadinessGate(name string) *Gate {
+	m.readyMu.Lock()
+	defer m.readyMu.Unlock()
+
+	if m.gates == nil {
+		m.gates = make(map[string]bool)
+	}
+	if _, exists := m.gates[name]; !exists {
+		m.gates[name] = false
+	}
+	return &Gate{name: name, manager: m}
+}
+
+// PendingGates returns the sorted names of the readiness gates not opened yet.
+func (m *Manager) PendingGates() []string {
+	m.readyMu.RLock()
+	defer m.readyMu.RUnlock()
+	return m.pendingGates()
+}
+
+func (m *Manager) pendingGates() []string {
+	var pending []string
+	for name, open := range m.gates {
+		if !open {
+			pending = append(pending, name)
+		}
+	}
+	sort.Strings(pending)
+	return pending
+}
+
+// Name returns the name of the gate.
+func (g *Gate) Name() string {
+	return g.name
+}
+
+// Open opens the gate, letting the manager become ready once every other
+// readiness requirement is met.
+func (g *Gate) Open() {
+	g.set(true)
+}
+
+// Close closes the gate again, making the manager not ready.
+func (g *Gate) Close() {
+	g.set(false)
+}
+
+// IsOpen reports whether the gate is open.
+func (g *Gate) IsOpen() bool {
+	g.manager.readyMu.RLock()
+	defer g.manager.readyMu.RUnlock()
+	return g.manager.gates[g.name]
+}
+
+func (g *Gate) set(open bool) {
+	m := g.manager
+
+	m.readyMu.Lock()
+	changed := m.gates[g.name] != open
+	m.gates[g.name] = open
+	m.readyMu.Unlock()
+
+	if changed {
+		logger.Info(context.Background(), "Readiness gate changed", "gate", g.name, "open", open)
+	}
+
+	// Notify readiness listeners about the resulting readiness.
+	m.IsReady()
+}
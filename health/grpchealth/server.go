@@ -0,0 +1,140 @@
+// Package grpchealth serves the standard gRPC health checking protocol
+// (grpc.health.v1) backed by a health.Manager, so that gRPC load balancers and
+// grpc_health_probe work with the same checks as the HTTP probes.
+package grpchealth
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/katalabut/fast-app/clock"
+	"github.com/katalabut/fast-app/health"
+)
+
+// Options contains options for the gRPC health server
+type Options struct {
+	// Services maps gRPC service names to the health checks they depend on.
+	// A service name that is not mapped reports the check of the same name.
+	Services map[string][]string
+
+	// WatchInterval is how often the status is re-evaluated for Watch streams.
+	// Defaults to 5 seconds.
+	WatchInterval time.Duration
+
+	// Clock drives the Watch interval. Defaults to the system clock.
+	Clock clock.Clock
+}
+
+// Server implements the grpc.health.v1.Health service.
+//
+// The empty service name reports the readiness of the application: it is
+// serving when the manager is ready and the readiness checks are not
+// unhealthy. Other services are serving when none of their checks are
+// unhealthy. Every service is reported not serving while the manager is not
+// ready, e.g. during graceful shutdown.
+type Server struct {
+	healthpb.UnimplementedHealthServer
+
+	manager *health.Manager
+	opts    Options
+}
+
+// NewServer creates a gRPC health server backed by the manager
+func NewServer(manager *health.Manager, opts Options) *Server {
+	if opts.WatchInterval <= 0 {
+		opts.WatchInterval = 5 * time.Second
+	}
+	if opts.Clock == nil {
+		opts.Clock = clock.Real()
+	}
+
+	return &Server{
+		manager: manager,
+		opts:    opts,
+	}
+}
+
+// Register registers the health service on a gRPC server
+func (s *Server) Register(registrar grpc.ServiceRegistrar) {
+	healthpb.RegisterHealthServer(registrar, s)
+}
+
+// Check returns the serving status of a service, or NOT_FOUND if it is unknown
+func (s *Server) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	st, ok := s.status(ctx, req.GetService())
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", req.GetService())
+	}
+	return &healthpb.HealthCheckResponse{Status: st}, nil
+}
+
+// Watch sends the serving status of a service immediately and then whenever it
+// changes. Unknown services are reported as SERVICE_UNKNOWN.
+func (s *Server) Watch(req *healthpb.HealthCheckRequest, stream grpc.ServerStreamingServer[healthpb.HealthCheckResponse]) error {
+	ctx := stream.Context()
+	ticker := s.opts.Clock.NewTicker(s.opts.WatchInterval)
+	defer ticker.Stop()
+
+	last := healthpb.HealthCheckResponse_ServingStatus(-1)
+	for {
+		st, ok := s.status(ctx, req.GetService())
+		if !ok {
+			st = healthpb.HealthCheckResponse_SERVICE_UNKNOWN
+		}
+
+		if st != last {
+			if err := stream.Send(&healthpb.HealthCheckResponse{Status: st}); err != nil {
+				return err
+			}
+			last = st
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-ticker.C():
+		}
+	}
+}
+
+// status evaluates the serving status of a service, reporting false if the
+// service is unknown.
+func (s *Server) status(ctx context.Context, service string) (healthpb.HealthCheckResponse_ServingStatus, bool) {
+	var unhealthy, known bool
+
+	switch checks, mapped := s.opts.Services[service]; {
+	case service == "":
+		known = true
+		unhealthy = s.manager.GetGroupStatus(ctx, health.ForReadiness()) == health.StatusUnhealthy
+	case mapped:
+		known = true
+		for _, name := range checks {
+			if result, ok := s.manager.Check(ctx, name); ok && !serving(result) {
+				unhealthy = true
+			}
+		}
+	default:
+		var result health.HealthResult
+		result, known = s.manager.Check(ctx, service)
+		unhealthy = !serving(result)
+	}
+
+	if !known {
+		return healthpb.HealthCheckResponse_SERVICE_UNKNOWN, false
+	}
+	if unhealthy || !s.manager.IsReady() {
+		return healthpb.HealthCheckResponse_NOT_SERVING, true
+	}
+	return healthpb.HealthCheckResponse_SERVING, true
+}
+
+// serving reports whether a check result allows serving, which is not the
+// case if the check is unhealthy or skipped because a dependency is.
+func serving(result health.HealthResult) bool {
+	return !result.IsUnhealthy() && result.Status != health.StatusSkipped
+}
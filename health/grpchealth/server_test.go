@@ -0,0 +1,136 @@
+package grpchealth
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/katalabut/fast-app/health"
+	"github.com/katalabut/fast-app/health/healthtest"
+)
+
+func startServer(t *testing.T, hs *Server) healthpb.HealthClient {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+
+	srv := grpc.NewServer()
+	hs.Register(srv)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return healthpb.NewHealthClient(conn)
+}
+
+func TestServer(t *testing.T) {
+	t.Run("Check", func(t *testing.T) {
+		manager := health.NewManager(health.ManagerConfig{})
+		manager.RegisterChecker(healthtest.AlwaysHealthy("db"))
+		manager.RegisterChecker(healthtest.AlwaysUnhealthy("cache", "down"), health.ForLiveness())
+
+		client := startServer(t, NewServer(manager, Options{
+			Services: map[string][]string{
+				"orders.v1.Orders":  {"db"},
+				"catalog.v1.Search": {"db", "cache"},
+			},
+		}))
+
+		for service, want := range map[string]healthpb.HealthCheckResponse_ServingStatus{
+			"":                  healthpb.HealthCheckResponse_SERVING,
+			"orders.v1.Orders":  healthpb.HealthCheckResponse_SERVING,
+			"catalog.v1.Search": healthpb.HealthCheckResponse_NOT_SERVING,
+			"db":                healthpb.HealthCheckResponse_SERVING,
+			"cache":             healthpb.HealthCheckResponse_NOT_SERVING,
+		} {
+			resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+			if err != nil {
+				t.Fatalf("Check(%q) failed: %v", service, err)
+			}
+			if resp.Status != want {
+				t.Errorf("Expected %s for %q, got %s", want, service, resp.Status)
+			}
+		}
+
+		_, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "unknown"})
+		if status.Code(err) != codes.NotFound {
+			t.Errorf("Expected NotFound for unknown service, got %v", err)
+		}
+	})
+
+	t.Run("NotReady", func(t *testing.T) {
+		manager := health.NewManager(health.ManagerConfig{})
+		manager.RegisterChecker(healthtest.AlwaysHealthy("db"))
+		manager.SetReady(false)
+
+		client := startServer(t, NewServer(manager, Options{}))
+
+		resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "db"})
+		if err != nil {
+			t.Fatalf("Check failed: %v", err)
+		}
+		if resp.Status != healthpb.HealthCheckResponse_NOT_SERVING {
+			t.Errorf("Expected %s while not ready, got %s", healthpb.HealthCheckResponse_NOT_SERVING, resp.Status)
+		}
+	})
+
+	t.Run("Watch", func(t *testing.T) {
+		var healthy atomic.Bool
+		healthy.Store(true)
+
+		manager := health.NewManager(health.ManagerConfig{CacheTTL: -1})
+		manager.RegisterChecker(health.NewCustomCheck("db", func(ctx context.Context) health.HealthResult {
+			if healthy.Load() {
+				return health.NewHealthyResult("ok")
+			}
+			return health.NewUnhealthyResult("down")
+		}))
+
+		clock := healthtest.NewFakeClock(time.Now())
+		client := startServer(t, NewServer(manager, Options{WatchInterval: time.Second, Clock: clock}))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: "db"})
+		if err != nil {
+			t.Fatalf("Watch failed: %v", err)
+		}
+
+		resp, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Recv failed: %v", err)
+		}
+		if resp.Status != healthpb.HealthCheckResponse_SERVING {
+			t.Errorf("Expected %s, got %s", healthpb.HealthCheckResponse_SERVING, resp.Status)
+		}
+
+		healthy.Store(false)
+		clock.BlockUntil(1)
+		clock.Advance(time.Second)
+
+		resp, err = stream.Recv()
+		if err != nil {
+			t.Fatalf("Recv failed: %v", err)
+		}
+		if resp.Status != healthpb.HealthCheckResponse_NOT_SERVING {
+			t.Errorf("Expected %s, got %s", healthpb.HealthCheckResponse_NOT_SERVING, resp.Status)
+		}
+	})
+}
@@ -2,6 +2,8 @@ package health
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -44,19 +46,59 @@ type AggregationStrategy interface {
 	Aggregate(results map[string]HealthResult) HealthStatus
 }
 
-// ComponentImportance defines the importance level of a component
+// ComponentImportance defines the importance level of a component. The zero
+// value is unset: checks registered with options that do not set an
+// importance are critical.
 type ComponentImportance int
 
 const (
-	Optional ComponentImportance = iota
+	Optional ComponentImportance = iota + 1
 	Important
 	Critical
 )
 
+// String returns the lower-case name of the importance
+func (i ComponentImportance) String() string {
+	switch i {
+	case Optional:
+		return "optional"
+	case Important:
+		return "important"
+	case Critical:
+		return "critical"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseImportance parses the name of an importance as returned by String
+func ParseImportance(s string) (ComponentImportance, error) {
+	switch strings.ToLower(s) {
+	case "optional":
+		return Optional, nil
+	case "important":
+		return Important, nil
+	case "critical":
+		return Critical, nil
+	default:
+		return 0, fmt.Errorf("unknown importance %q", s)
+	}
+}
+
+// MarshalText encodes the importance by its name, e.g. in JSON responses
+func (i ComponentImportance) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
 // HealthCheckOptions contains configuration for health checks
 type HealthCheckOptions struct {
-	Timeout    time.Duration
-	Interval   time.Duration
+	// Timeout bounds each run of the check, zero for no additional limit
+	Timeout time.Duration
+	// Interval is how long a result is cached, zero for the manager cache TTL.
+	// A CacheTTL set with WithCheckOptions takes precedence over it
+	Interval time.Duration
+	// Importance is used by WeightedStrategy to aggregate the overall status,
+	// critical when unset
 	Importance ComponentImportance
 }
 
@@ -153,6 +195,56 @@ func (s *AllHealthyStrategy) Aggregate(results map[string]HealthResult) HealthSt
 	return StatusHealthy
 }
 
+// WeightedStrategy uses component importance to determine overall health.
+// An unhealthy critical component makes the application unhealthy, while an
+// unhealthy important component only degrades it. Optional components are ignored
+// unless degraded. Components without a weight are considered important.
+type WeightedStrategy struct {
+	Weights map[string]ComponentImportance
+}
+
+// Aggregate returns health status based on component importance
+func (s *WeightedStrategy) Aggregate(results map[string]HealthResult) HealthStatus {
+	if len(results) == 0 {
+		return StatusHealthy
+	}
+
+	hasCriticalUnhealthy := false
+	hasImportantUnhealthy := false
+	hasDegraded := false
+
+	for name, result := range results {
+		importance, exists := s.Weights[name]
+		if !exists || importance == 0 {
+			importance = Important // default importance
+		}
+
+		switch result.Status {
+		case StatusUnhealthy:
+			switch importance {
+			case Critical:
+				hasCriticalUnhealthy = true
+			case Important:
+				hasImportantUnhealthy = true
+			}
+		case StatusDegraded:
+			hasDegraded = true
+		}
+	}
+
+	// If any critical component is unhealthy, overall is unhealthy
+	if hasCriticalUnhealthy {
+		return StatusUnhealthy
+	}
+
+	// If any important component is unhealthy, overall is degraded
+	if hasImportantUnhealthy || hasDegraded {
+		return StatusDegraded
+	}
+
+	return StatusHealthy
+}
+
 // Convenience functions for creating health checks
 
 // NewCustomCheck creates a new custom health check
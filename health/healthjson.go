@@ -0,0 +1,121 @@
+package health
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Response formats of the detailed health checks endpoint.
+const (
+	// FormatDefault is the FastApp response format.
+	FormatDefault = "default"
+	// FormatHealthJSON is the IETF "Health Check Response Format for HTTP APIs"
+	// (draft-inadarei-api-health-check), served as application/health+json.
+	FormatHealthJSON = "health+json"
+)
+
+// HealthJSONContentType is the media type of the health+json response format.
+const HealthJSONContentType = "application/health+json"
+
+// HealthJSON is a health check response in the health+json format.
+type HealthJSON struct {
+	Status string                       `json:"status"`
+	Output string                       `json:"output,omitempty"`
+	Checks map[string][]HealthJSONCheck `json:"checks,omitempty"`
+}
+
+// HealthJSONCheck is a single measurement of a component in the health+json format.
+type HealthJSONCheck struct {
+	ComponentID   string      `json:"componentId"`
+	ObservedValue interface{} `json:"observedValue,omitempty"`
+	ObservedUnit  string      `json:"observedUnit,omitempty"`
+	Status        string      `json:"status"`
+	Time          string      `json:"time"`
+	Output        string      `json:"output,omitempty"`
+}
+
+// NewHealthJSON converts health check results to the health+json format.
+// Every check reports its response time as "<name>:responseTime" in
+// milliseconds; checks with a "value" detail, such as threshold checks, also
+// report it as "<name>:value" with the "unit" detail as its unit.
+func NewHealthJSON(status HealthStatus, results map[string]HealthResult, now time.Time) HealthJSON {
+	doc := HealthJSON{
+		Status: healthJSONStatus(status),
+		Checks: make(map[string][]HealthJSONCheck, len(results)),
+	}
+
+	timestamp := now.UTC().Format(time.RFC3339)
+	var failing []string
+
+	for name, result := range results {
+		check := HealthJSONCheck{
+			ComponentID:   name,
+			ObservedValue: float64(result.Duration) / float64(time.Millisecond),
+			ObservedUnit:  "ms",
+			Status:        healthJSONStatus(result.Status),
+			Time:          timestamp,
+		}
+		// Output should be omitted for passing checks.
+		if result.Status != StatusHealthy {
+			check.Output = result.Message
+			failing = append(failing, name)
+		}
+		doc.Checks[name+":responseTime"] = []HealthJSONCheck{check}
+
+		if value, ok := result.Details["value"]; ok {
+			check.ObservedValue = value
+			check.ObservedUnit, _ = result.Details["unit"].(string)
+			doc.Checks[name+":value"] = []HealthJSONCheck{check}
+		}
+	}
+
+	if len(failing) > 0 {
+		sort.Strings(failing)
+		doc.Output = "checks not passing: " + strings.Join(failing, ", ")
+	}
+
+	return doc
+}
+
+// Terse returns the document with only the status of each component, keyed by
+// the component name, without outputs and observed values.
+func (h HealthJSON) Terse() HealthJSON {
+	terse := HealthJSON{
+		Status: h.Status,
+		Checks: make(map[string][]HealthJSONCheck, len(h.Checks)),
+	}
+	for key, checks := range h.Checks {
+		name, ok := strings.CutSuffix(key, ":responseTime")
+		if !ok {
+			continue
+		}
+		for _, check := range checks {
+			terse.Checks[name] = append(terse.Checks[name], HealthJSONCheck{
+				ComponentID: check.ComponentID,
+				Status:      check.Status,
+				Time:        check.Time,
+			})
+		}
+	}
+	return terse
+}
+
+// healthJSONStatus maps a health status to pass, warn or fail.
+func healthJSONStatus(status HealthStatus) string {
+	switch status {
+	case StatusHealthy:
+		return "pass"
+	case StatusDegraded, StatusSkipped:
+		return "warn"
+	default:
+		return "fail"
+	}
+}
+
+// WantsHealthJSON reports whether a response should use the health+json
+// format, either because it is the configured format or because the request
+// accepts application/health+json.
+func WantsHealthJSON(format, accept string) bool {
+	return format == FormatHealthJSON || strings.Contains(accept, HealthJSONContentType)
+}
@@ -0,0 +1,59 @@
+package health
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewHealthJSON(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	results := map[string]HealthResult{
+		"db":  NewHealthyResult("ok").WithDuration(15 * time.Millisecond),
+		"lag": NewDegradedResult("consumer lag is high").WithDetails("value", 1500.0).WithDetails("unit", "messages"),
+		"api": NewUnhealthyResult("connection refused"),
+	}
+
+	doc := NewHealthJSON(StatusUnhealthy, results, now)
+
+	if doc.Status != "fail" {
+		t.Errorf("Expected status 'fail', got '%s'", doc.Status)
+	}
+	if doc.Output != "checks not passing: api, lag" {
+		t.Errorf("Expected output listing failing checks, got '%s'", doc.Output)
+	}
+
+	db := doc.Checks["db:responseTime"]
+	if len(db) != 1 {
+		t.Fatalf("Expected db:responseTime measurement, got %v", doc.Checks)
+	}
+	if db[0].Status != "pass" || db[0].ObservedValue != 15.0 || db[0].ObservedUnit != "ms" {
+		t.Errorf("Unexpected db measurement %+v", db[0])
+	}
+	if db[0].Output != "" {
+		t.Errorf("Expected no output for passing check, got '%s'", db[0].Output)
+	}
+	if db[0].Time != "2024-01-15T10:30:00Z" {
+		t.Errorf("Expected time '2024-01-15T10:30:00Z', got '%s'", db[0].Time)
+	}
+
+	lag := doc.Checks["lag:value"]
+	if len(lag) != 1 || lag[0].Status != "warn" || lag[0].ObservedValue != 1500.0 || lag[0].ObservedUnit != "messages" {
+		t.Errorf("Unexpected lag measurement %+v", lag)
+	}
+
+	if api := doc.Checks["api:responseTime"]; len(api) != 1 || api[0].Output != "connection refused" {
+		t.Errorf("Expected output for failing check, got %+v", api)
+	}
+}
+
+func TestWantsHealthJSON(t *testing.T) {
+	if WantsHealthJSON(FormatDefault, "application/json") {
+		t.Error("Expected default format for application/json")
+	}
+	if !WantsHealthJSON(FormatHealthJSON, "") {
+		t.Error("Expected health+json when configured")
+	}
+	if !WantsHealthJSON(FormatDefault, "application/health+json, application/json;q=0.9") {
+		t.Error("Expected health+json when accepted")
+	}
+}
@@ -0,0 +1,60 @@
+package healthtest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/katalabut/fast-app/health"
+)
+
+// AssertStatus runs all checks registered in the manager and fails the test
+// if the aggregated status differs from want.
+func AssertStatus(tb testing.TB, m *health.Manager, want health.HealthStatus) {
+	tb.Helper()
+
+	if got := m.GetOverallStatus(context.Background()); got != want {
+		tb.Errorf("Expected overall status %s, got %s", want, got)
+	}
+}
+
+// AssertCheckStatus runs all checks registered in the manager and fails the test
+// if the named check is missing or its status differs from want.
+func AssertCheckStatus(tb testing.TB, m *health.Manager, name string, want health.HealthStatus) {
+	tb.Helper()
+
+	results := m.CheckAll(context.Background())
+	result, ok := results[name]
+	if !ok {
+		tb.Errorf("Expected check %q to be registered", name)
+		return
+	}
+	if result.Status != want {
+		tb.Errorf("Expected check %q status %s, got %s (%s)", name, want, result.Status, result.Message)
+	}
+}
+
+// AssertAggregate fails the test if the strategy aggregates results to a status other than want.
+func AssertAggregate(tb testing.TB, strategy health.AggregationStrategy, results map[string]health.HealthResult, want health.HealthStatus) {
+	tb.Helper()
+
+	if got := strategy.Aggregate(results); got != want {
+		tb.Errorf("Expected aggregated status %s, got %s", want, got)
+	}
+}
+
+// Results builds a results map from alternating check names and statuses,
+// which keeps strategy tests short:
+//
+//	healthtest.Results("db", health.StatusHealthy, "cache", health.StatusDegraded)
+func Results(pairs ...interface{}) map[string]health.HealthResult {
+	results := make(map[string]health.HealthResult, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		name, _ := pairs[i].(string)
+		status, _ := pairs[i+1].(health.HealthStatus)
+		results[name] = health.HealthResult{
+			Status:  status,
+			Message: string(status),
+		}
+	}
+	return results
+}
@@ -0,0 +1,127 @@
+package healthtest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/katalabut/fast-app/health"
+)
+
+// Checker is a scriptable health.HealthChecker that records how often it was called.
+type Checker struct {
+	name string
+	fn   func(call int) health.HealthResult
+
+	mu    sync.Mutex
+	calls int
+	delay time.Duration
+}
+
+// NewChecker creates a checker that returns fn(call) on every check,
+// where call is the 1-based number of the current invocation.
+func NewChecker(name string, fn func(call int) health.HealthResult) *Checker {
+	return &Checker{
+		name: name,
+		fn:   fn,
+	}
+}
+
+// AlwaysHealthy creates a checker that always reports healthy.
+func AlwaysHealthy(name string) *Checker {
+	return NewChecker(name, func(int) health.HealthResult {
+		return health.NewHealthyResult("ok")
+	})
+}
+
+// AlwaysUnhealthy creates a checker that always reports unhealthy with the given message.
+func AlwaysUnhealthy(name, message string) *Checker {
+	return NewChecker(name, func(int) health.HealthResult {
+		return health.NewUnhealthyResult(message)
+	})
+}
+
+// AlwaysDegraded creates a checker that always reports degraded with the given message.
+func AlwaysDegraded(name, message string) *Checker {
+	return NewChecker(name, func(int) health.HealthResult {
+		return health.NewDegradedResult(message)
+	})
+}
+
+// FailNTimesThenRecover creates a checker that reports unhealthy for the first n
+// calls and healthy afterwards.
+func FailNTimesThenRecover(name string, n int) *Checker {
+	return NewChecker(name, func(call int) health.HealthResult {
+		if call <= n {
+			return health.NewUnhealthyResult("simulated failure").
+				WithDetails("call", call)
+		}
+		return health.NewHealthyResult("recovered").
+			WithDetails("call", call)
+	})
+}
+
+// Sequence creates a checker that returns the given results in order and
+// keeps returning the last one once the sequence is exhausted.
+func Sequence(name string, results ...health.HealthResult) *Checker {
+	return NewChecker(name, func(call int) health.HealthResult {
+		if len(results) == 0 {
+			return health.NewHealthyResult("ok")
+		}
+		if call > len(results) {
+			return results[len(results)-1]
+		}
+		return results[call-1]
+	})
+}
+
+// Slow creates a checker that waits d before reporting healthy. If the context
+// is done first, it reports unhealthy with the context error.
+func Slow(name string, d time.Duration) *Checker {
+	return AlwaysHealthy(name).WithDelay(d)
+}
+
+// WithDelay makes the checker wait d before producing each result.
+func (c *Checker) WithDelay(d time.Duration) *Checker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.delay = d
+	return c
+}
+
+// Name returns the name of the health check
+func (c *Checker) Name() string {
+	return c.name
+}
+
+// Check runs the scripted check
+func (c *Checker) Check(ctx context.Context) health.HealthResult {
+	c.mu.Lock()
+	c.calls++
+	call := c.calls
+	delay := c.delay
+	c.mu.Unlock()
+
+	if delay > 0 {
+		if err := sleep(ctx, delay); err != nil {
+			return health.NewUnhealthyResult("health check cancelled").
+				WithDetails("error", err.Error())
+		}
+	}
+
+	return c.fn(call)
+}
+
+// Calls returns how many times the checker has been invoked.
+func (c *Checker) Calls() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls
+}
+
+// Reset sets the call counter back to zero.
+func (c *Checker) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = 0
+}
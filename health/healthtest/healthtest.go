@@ -0,0 +1,48 @@
+// Package healthtest provides helpers for testing code built on the health package:
+// a controllable clock for the Manager, canned health checkers and assertion helpers
+// for individual and aggregated statuses.
+//
+// Example:
+//
+//	clock := healthtest.NewFakeClock(time.Now())
+//	manager := health.NewManager(health.ManagerConfig{
+//	    CacheTTL: 5 * time.Second,
+//	    Clock:    clock,
+//	})
+//	manager.RegisterChecker(healthtest.FailNTimesThenRecover("db", 2))
+//
+//	healthtest.AssertStatus(t, manager, health.StatusUnhealthy)
+//	clock.Advance(5 * time.Second)
+//	healthtest.AssertStatus(t, manager, health.StatusUnhealthy)
+//	clock.Advance(5 * time.Second)
+//	healthtest.AssertStatus(t, manager, health.StatusHealthy)
+package healthtest
+
+import (
+	"context"
+	"time"
+
+	"github.com/katalabut/fast-app/clock"
+)
+
+// FakeClock is a health.Clock whose time only changes when told to.
+// It is an alias of clock.Fake, so it can be shared with the App as well.
+type FakeClock = clock.Fake
+
+// NewFakeClock creates a fake clock set to the given time.
+func NewFakeClock(now time.Time) *FakeClock {
+	return clock.NewFake(now)
+}
+
+// sleep waits for d or until the context is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,379 @@
+package healthtest
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/katalabut/fast-app/health"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+
+	if !clock.Now().Equal(start) {
+		t.Errorf("Expected %v, got %v", start, clock.Now())
+	}
+
+	clock.Advance(time.Minute)
+	if got := clock.Now().Sub(start); got != time.Minute {
+		t.Errorf("Expected clock to advance by 1m, got %v", got)
+	}
+}
+
+func TestManagerCacheWithFakeClock(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	manager := health.NewManager(health.ManagerConfig{
+		CacheTTL: 5 * time.Second,
+		Clock:    clock,
+	})
+	checker := FailNTimesThenRecover("db", 1)
+	manager.RegisterChecker(checker)
+
+	AssertStatus(t, manager, health.StatusUnhealthy)
+
+	// Within the TTL the cached result is served.
+	clock.Advance(4 * time.Second)
+	AssertStatus(t, manager, health.StatusUnhealthy)
+	if checker.Calls() != 1 {
+		t.Errorf("Expected 1 call within cache TTL, got %d", checker.Calls())
+	}
+
+	// Once the TTL expires the check runs again and recovers.
+	clock.Advance(time.Second)
+	AssertStatus(t, manager, health.StatusHealthy)
+	if checker.Calls() != 2 {
+		t.Errorf("Expected 2 calls after cache expiry, got %d", checker.Calls())
+	}
+}
+
+func TestManagerPerCheckCacheTTL(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	manager := health.NewManager(health.ManagerConfig{
+		CacheTTL: 5 * time.Second,
+		Clock:    clock,
+	})
+
+	cached := AlwaysHealthy("expensive")
+	uncached := AlwaysHealthy("cheap")
+	manager.RegisterChecker(health.WithCheckOptions(cached, health.CheckOptions{CacheTTL: time.Minute}))
+	manager.RegisterChecker(health.WithCheckOptions(uncached, health.CheckOptions{CacheTTL: -1}))
+
+	manager.CheckAll(context.Background())
+	clock.Advance(10 * time.Second)
+	manager.CheckAll(context.Background())
+
+	if cached.Calls() != 1 {
+		t.Errorf("Expected 1 call within the per-check TTL, got %d", cached.Calls())
+	}
+	if uncached.Calls() != 2 {
+		t.Errorf("Expected 2 calls with caching disabled, got %d", uncached.Calls())
+	}
+
+	clock.Advance(time.Minute)
+	manager.CheckAll(context.Background())
+	if cached.Calls() != 2 {
+		t.Errorf("Expected 2 calls after the per-check TTL expired, got %d", cached.Calls())
+	}
+}
+
+func TestManagerCacheTTLOverridesInterval(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	manager := health.NewManager(health.ManagerConfig{
+		CacheTTL: 5 * time.Second,
+		Clock:    clock,
+	})
+
+	interval := AlwaysHealthy("interval")
+	both := AlwaysHealthy("both")
+	manager.RegisterCheckerWithOptions(interval, health.HealthCheckOptions{Interval: time.Minute})
+	manager.RegisterCheckerWithOptions(
+		health.WithCheckOptions(both, health.CheckOptions{CacheTTL: 10 * time.Second}),
+		health.HealthCheckOptions{Interval: time.Minute},
+	)
+
+	manager.CheckAll(context.Background())
+	clock.Advance(30 * time.Second)
+	manager.CheckAll(context.Background())
+
+	if interval.Calls() != 1 {
+		t.Errorf("Expected 1 call within the interval, got %d", interval.Calls())
+	}
+	if both.Calls() != 2 {
+		t.Errorf("Expected the cache TTL to take precedence over the interval, got %d calls", both.Calls())
+	}
+}
+
+func TestManagerStaleWhileRevalidate(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	manager := health.NewManager(health.ManagerConfig{
+		CacheTTL:             5 * time.Second,
+		Clock:                clock,
+		StaleWhileRevalidate: true,
+	})
+
+	release := make(chan struct{})
+	checker := NewChecker("slow", func(call int) health.HealthResult {
+		if call == 1 {
+			return health.NewHealthyResult("first")
+		}
+		<-release
+		return health.NewUnhealthyResult("second")
+	})
+	manager.RegisterChecker(checker)
+
+	AssertStatus(t, manager, health.StatusHealthy)
+
+	// The expired result is served while the check is refreshed in the background.
+	clock.Advance(5 * time.Second)
+	AssertStatus(t, manager, health.StatusHealthy)
+	AssertStatus(t, manager, health.StatusHealthy)
+
+	close(release)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for manager.GetOverallStatus(context.Background()) != health.StatusUnhealthy {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the refreshed result to be served")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if checker.Calls() != 2 {
+		t.Errorf("Expected a single background refresh, got %d calls", checker.Calls())
+	}
+}
+
+func TestManagerStaleWhileRevalidateTimeout(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	manager := health.NewManager(health.ManagerConfig{
+		CacheTTL:             50 * time.Millisecond,
+		Clock:                clock,
+		StaleWhileRevalidate: true,
+		CheckTimeout:         50 * time.Millisecond,
+	})
+
+	var calls atomic.Int32
+	manager.RegisterChecker(health.NewCustomCheck("hanging", func(ctx context.Context) health.HealthResult {
+		if calls.Add(1) == 2 {
+			// Hangs until the refresh is cancelled.
+			<-ctx.Done()
+			return health.NewUnhealthyResult("timed out")
+		}
+		return health.NewHealthyResult("ok")
+	}))
+
+	AssertStatus(t, manager, health.StatusHealthy)
+
+	// The hanging refresh times out, so later refreshes still run.
+	deadline := time.Now().Add(5 * time.Second)
+	for calls.Load() < 3 {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the result to be refreshed after a hanging refresh")
+		}
+		clock.Advance(50 * time.Millisecond)
+		manager.GetOverallStatus(context.Background())
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestManagerSharesConcurrentChecks(t *testing.T) {
+	manager := health.NewManager(health.ManagerConfig{CacheTTL: -1})
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	checker := NewChecker("db", func(call int) health.HealthResult {
+		if call == 1 {
+			close(started)
+		}
+		<-release
+		return health.NewHealthyResult("ok")
+	})
+	manager.RegisterChecker(checker)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			manager.CheckAll(context.Background())
+		}()
+		if i == 0 {
+			<-started
+		}
+	}
+
+	// Give the other callers time to join the running check.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if checker.Calls() != 1 {
+		t.Errorf("Expected concurrent callers to share 1 call, got %d", checker.Calls())
+	}
+}
+
+func TestManagerSharedCheckOutlivesCancelledCaller(t *testing.T) {
+	manager := health.NewManager(health.ManagerConfig{})
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	checker := NewChecker("db", func(call int) health.HealthResult {
+		if call == 1 {
+			close(started)
+		}
+		<-release
+		return health.NewHealthyResult("ok")
+	})
+	manager.RegisterChecker(checker)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled := make(chan health.HealthResult, 1)
+	go func() { cancelled <- manager.CheckAll(ctx)["db"] }()
+	<-started
+
+	live := make(chan health.HealthResult, 1)
+	go func() { live <- manager.CheckAll(context.Background())["db"] }()
+
+	// Give the live caller time to join the running check.
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	if result := <-cancelled; result.Status != health.StatusUnhealthy {
+		t.Errorf("Expected the cancelled caller to get unhealthy, got %s", result.Status)
+	}
+
+	close(release)
+	if result := <-live; result.Status != health.StatusHealthy {
+		t.Errorf("Expected the live caller to get healthy, got %s: %s", result.Status, result.Message)
+	}
+
+	AssertCheckStatus(t, manager, "db", health.StatusHealthy)
+	if checker.Calls() != 1 {
+		t.Errorf("Expected the result of the shared call to be cached, got %d calls", checker.Calls())
+	}
+}
+
+func TestManagerMaxConcurrentChecks(t *testing.T) {
+	manager := health.NewManager(health.ManagerConfig{MaxConcurrentChecks: 2})
+
+	var mu sync.Mutex
+	running, peak := 0, 0
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		manager.RegisterChecker(health.NewCustomCheck(name, func(ctx context.Context) health.HealthResult {
+			mu.Lock()
+			running++
+			peak = max(peak, running)
+			mu.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			mu.Lock()
+			running--
+			mu.Unlock()
+			return health.NewHealthyResult("ok")
+		}))
+	}
+
+	results := manager.CheckAll(context.Background())
+	if len(results) != 5 {
+		t.Errorf("Expected 5 results, got %d", len(results))
+	}
+	if peak > 2 {
+		t.Errorf("Expected at most 2 concurrent checks, got %d", peak)
+	}
+
+	t.Run("DeadlineWhileWaiting", func(t *testing.T) {
+		manager := health.NewManager(health.ManagerConfig{MaxConcurrentChecks: 1})
+		manager.RegisterChecker(Slow("slow", time.Second))
+		manager.RegisterChecker(Slow("other", time.Second))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		for name, result := range manager.CheckAll(ctx) {
+			if result.Status != health.StatusUnhealthy {
+				t.Errorf("Expected %s for %s, got %s", health.StatusUnhealthy, name, result.Status)
+			}
+		}
+	})
+}
+
+func TestManagerThresholds(t *testing.T) {
+	manager := health.NewManager(health.ManagerConfig{CacheTTL: -1})
+	checker := Sequence("db",
+		health.NewHealthyResult("ok"),
+		health.NewUnhealthyResult("timeout"),
+		health.NewUnhealthyResult("timeout"),
+		health.NewUnhealthyResult("timeout"),
+		health.NewHealthyResult("ok"),
+		health.NewHealthyResult("ok"),
+	)
+	manager.RegisterChecker(health.WithCheckOptions(checker, health.CheckOptions{
+		FailureThreshold: 3,
+		SuccessThreshold: 2,
+	}))
+
+	statuses := []health.HealthStatus{
+		health.StatusHealthy,
+		health.StatusHealthy,
+		health.StatusHealthy,
+		health.StatusUnhealthy,
+		health.StatusUnhealthy,
+		health.StatusHealthy,
+	}
+	for i, want := range statuses {
+		result := manager.CheckAll(context.Background())["db"]
+		if result.Status != want {
+			t.Errorf("Call %d: expected %s, got %s", i+1, want, result.Status)
+		}
+	}
+
+	manager.RegisterChecker(health.WithCheckOptions(Sequence("cache",
+		health.NewHealthyResult("ok"),
+		health.NewUnhealthyResult("timeout"),
+	), health.CheckOptions{FailureThreshold: 2}))
+
+	manager.CheckAll(context.Background())
+	result := manager.CheckAll(context.Background())["cache"]
+	if result.Details["suppressed_status"] != health.StatusUnhealthy {
+		t.Errorf("Expected suppressed status %s, got %v", health.StatusUnhealthy, result.Details["suppressed_status"])
+	}
+	if result.Details["consecutive_failures"] != 1 {
+		t.Errorf("Expected 1 consecutive failure, got %v", result.Details["consecutive_failures"])
+	}
+}
+
+func TestCheckers(t *testing.T) {
+	t.Run("Sequence", func(t *testing.T) {
+		checker := Sequence("seq",
+			health.NewHealthyResult("ok"),
+			health.NewDegradedResult("slow"),
+		)
+		statuses := []health.HealthStatus{health.StatusHealthy, health.StatusDegraded, health.StatusDegraded}
+		for i, want := range statuses {
+			if got := checker.Check(context.Background()).Status; got != want {
+				t.Errorf("Call %d: expected %s, got %s", i+1, want, got)
+			}
+		}
+	})
+
+	t.Run("SlowRespectsContext", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		result := Slow("slow", time.Minute).Check(ctx)
+		if result.Status != health.StatusUnhealthy {
+			t.Errorf("Expected %s on cancelled context, got %s", health.StatusUnhealthy, result.Status)
+		}
+	})
+
+	t.Run("AssertAggregate", func(t *testing.T) {
+		AssertAggregate(t, &health.AllHealthyStrategy{},
+			Results("db", health.StatusHealthy, "cache", health.StatusDegraded),
+			health.StatusDegraded,
+		)
+	})
+}
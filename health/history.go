@@ -0,0 +1,83 @@
+package health
+
+import "time"
+
+// defaultHistorySize is the number of results kept per check by default.
+const defaultHistorySize = 10
+
+// HistoryEntry is a past health check result with the time it was produced
+type HistoryEntry struct {
+	HealthResult
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// resultHistory is a ring buffer of the most recent results of a check.
+type resultHistory struct {
+	entries []HistoryEntry
+	next    int
+	full    bool
+}
+
+func newResultHistory(size int) *resultHistory {
+	return &resultHistory{entries: make([]HistoryEntry, size)}
+}
+
+// add records an entry, overwriting the oldest one when the buffer is full.
+func (h *resultHistory) add(e HistoryEntry) {
+	h.entries[h.next] = e
+	h.next = (h.next + 1) % len(h.entries)
+	if h.next == 0 {
+		h.full = true
+	}
+}
+
+// list returns a copy of the entries from oldest to newest.
+func (h *resultHistory) list() []HistoryEntry {
+	if !h.full {
+		return append([]HistoryEntry(nil), h.entries[:h.next]...)
+	}
+
+	list := make([]HistoryEntry, 0, len(h.entries))
+	list = append(list, h.entries[h.next:]...)
+	return append(list, h.entries[:h.next]...)
+}
+
+// History returns the most recent results of a check from oldest to newest.
+// Results served from the cache are not recorded again.
+func (m *Manager) History(name string) []HistoryEntry {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	h, ok := m.history[name]
+	if !ok {
+		return nil
+	}
+	return h.list()
+}
+
+// AllHistory returns the most recent results of every check, see History.
+func (m *Manager) AllHistory() map[string][]HistoryEntry {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	all := make(map[string][]HistoryEntry, len(m.history))
+	for name, h := range m.history {
+		all[name] = h.list()
+	}
+	return all
+}
+
+// recordHistory adds a result to the history of a check.
+// It must be called with m.mu held.
+func (m *Manager) recordHistory(name string, result HealthResult, checkedAt time.Time) {
+	if m.historySize <= 0 {
+		return
+	}
+
+	h, ok := m.history[name]
+	if !ok {
+		h = newResultHistory(m.historySize)
+		m.history[name] = h
+	}
+	h.add(HistoryEntry{HealthResult: result, CheckedAt: checkedAt})
+}
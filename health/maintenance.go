@@ -0,0 +1,36 @@
+package health
+
+import (
+	"context"
+
+	"github.com/katalabut/fast-app/logger"
+)
+
+// StatusMaintenance is reported by the readiness endpoints while the manager
+// is in maintenance mode.
+const StatusMaintenance HealthStatus = "maintenance"
+
+// SetMaintenance enables or disables maintenance mode. In maintenance mode the
+// manager is not ready, so readiness probes fail and traffic is drained, while
+// liveness is unaffected. The message is reported by the health endpoints.
+func (m *Manager) SetMaintenance(enabled bool, message string) {
+	m.readyMu.Lock()
+	m.maintenance = enabled
+	m.maintenanceMessage = message
+	if !enabled {
+		m.maintenanceMessage = ""
+	}
+	m.readyMu.Unlock()
+
+	logger.Warn(context.Background(), "Maintenance mode changed", "enabled", enabled, "message", message)
+
+	// Notify readiness listeners about the resulting readiness.
+	m.IsReady()
+}
+
+// Maintenance reports whether maintenance mode is enabled and its message.
+func (m *Manager) Maintenance() (bool, string) {
+	m.readyMu.RLock()
+	defer m.readyMu.RUnlock()
+	return m.maintenance, m.maintenanceMessage
+}
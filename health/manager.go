@@ -5,82 +5,269 @@ import (
 	"sync"
 	"time"
 
+	"golang.org/x/sync/singleflight"
+
+	"github.com/katalabut/fast-app/clock"
 	"github.com/katalabut/fast-app/logger"
 )
 
 // Manager coordinates all health checks and manages the overall health state
 type Manager struct {
-	checkers map[string]HealthChecker
-	strategy AggregationStrategy
-	cache    map[string]HealthResult
-	cacheTTL time.Duration
-	mu       sync.RWMutex
-	ready    bool
-	readyMu  sync.RWMutex
+	checkers  map[string]HealthChecker
+	options   map[string]HealthCheckOptions
+	groups    map[string]ProbeGroup
+	dependsOn map[string][]string
+	overrides map[string]Override
+	strategy  AggregationStrategy
+	cache     map[string]cacheEntry
+	cacheTTL  time.Duration
+	clock     Clock
+	redact    Redactor
+
+	staleWhileRevalidate bool
+	refreshing           map[string]bool
+	pushStaleAfter       time.Duration
+	inflight             singleflight.Group
+	checkTimeout         time.Duration
+	workers              chan struct{}
+	defaultStrategy      bool
+
+	history     map[string]*resultHistory
+	historySize int
+	thresholds  map[string]*thresholdState
+	mu          sync.RWMutex
+	ready       bool
+	readyMu     sync.RWMutex
+
+	maintenance        bool
+	maintenanceMessage string
+
+	readinessConditions []func() bool
+	gates               map[string]bool
+
+	listenersMu      sync.Mutex
+	readyListeners   []func(ready bool)
+	overallListeners []func(from, to HealthStatus)
+	checkListeners   []func(check string, old, new HealthResult)
+	lastReady        bool
+	lastStatus       HealthStatus
+	lastResults      map[string]HealthResult
+	lastScore        float64
+	scored           bool
+}
+
+// cacheEntry is a cached health check result with the time it was produced
+type cacheEntry struct {
+	result    HealthResult
+	checkedAt time.Time
 }
 
 // ManagerConfig contains configuration for the health manager
 type ManagerConfig struct {
 	CacheTTL time.Duration `default:"5s"`
+	// Strategy aggregates the overall status. Defaults to AllHealthyStrategy, or
+	// WeightedStrategy once checks are registered with an importance.
 	Strategy AggregationStrategy
+	// StaleWhileRevalidate returns expired cached results immediately and
+	// refreshes them in the background, so slow checks never block callers.
+	// Only the first check of each checker waits for its result.
+	StaleWhileRevalidate bool
+	// MaxConcurrentChecks limits how many checks run at the same time across all
+	// callers, so that applications with many checks do not stampede their
+	// dependencies. Zero means no limit.
+	MaxConcurrentChecks int
+	// HistorySize is the number of recent results kept per check, see Manager.History.
+	// Defaults to 10, a negative value disables the history.
+	HistorySize int
+	// Clock is used for cache expiry and check durations. Defaults to the system clock.
+	Clock Clock
+	// Redact rewrites every check result before it is cached, e.g.
+	// RedactCredentials, so that secrets never leak through the health endpoints.
+	Redact Redactor
+	// PushStaleAfter is how long a status pushed with SetStatus is reported
+	// before the check is considered unhealthy. Defaults to one minute.
+	PushStaleAfter time.Duration
+	// CheckTimeout bounds each run of a check registered without a timeout of
+	// its own. Runs are shared between concurrent callers and not cancelled
+	// when one of them gives up, so they need a bound. Defaults to 30 seconds.
+	CheckTimeout time.Duration
 }
 
+// defaultCheckTimeout is the default of ManagerConfig.CheckTimeout.
+const defaultCheckTimeout = 30 * time.Second
+
+// Clock provides the current time to the Manager. It can be replaced
+// with a fake implementation to test time-dependent behavior deterministically.
+type Clock = clock.Clock
+
 // NewManager creates a new health manager
 func NewManager(config ManagerConfig) *Manager {
-	if config.Strategy == nil {
+	defaultStrategy := config.Strategy == nil
+	if defaultStrategy {
 		config.Strategy = &AllHealthyStrategy{}
 	}
 
 	if config.CacheTTL == 0 {
 		config.CacheTTL = 5 * time.Second
 	}
+	if config.Clock == nil {
+		config.Clock = clock.Real()
+	}
+	if config.HistorySize == 0 {
+		config.HistorySize = defaultHistorySize
+	}
+	if config.PushStaleAfter == 0 {
+		config.PushStaleAfter = defaultPushStaleAfter
+	}
+	if config.CheckTimeout <= 0 {
+		config.CheckTimeout = defaultCheckTimeout
+	}
+
+	var workers chan struct{}
+	if config.MaxConcurrentChecks > 0 {
+		workers = make(chan struct{}, config.MaxConcurrentChecks)
+	}
 
 	return &Manager{
-		checkers: make(map[string]HealthChecker),
-		strategy: config.Strategy,
-		cache:    make(map[string]HealthResult),
-		cacheTTL: config.CacheTTL,
-		ready:    true, // Start as ready by default
+		checkers:  make(map[string]HealthChecker),
+		options:   make(map[string]HealthCheckOptions),
+		groups:    make(map[string]ProbeGroup),
+		dependsOn: make(map[string][]string),
+		overrides: make(map[string]Override),
+		strategy:  config.Strategy,
+		cache:     make(map[string]cacheEntry),
+		cacheTTL:  config.CacheTTL,
+		clock:     config.Clock,
+		redact:    config.Redact,
+		ready:     true, // Start as ready by default
+
+		staleWhileRevalidate: config.StaleWhileRevalidate,
+		refreshing:           make(map[string]bool),
+		pushStaleAfter:       config.PushStaleAfter,
+		checkTimeout:         config.CheckTimeout,
+		defaultStrategy:      defaultStrategy,
+		workers:              workers,
+
+		history:     make(map[string]*resultHistory),
+		historySize: config.HistorySize,
+		thresholds:  make(map[string]*thresholdState),
+
+		lastResults: make(map[string]HealthResult),
 	}
 }
 
-// RegisterChecker registers a health checker
-func (m *Manager) RegisterChecker(checker HealthChecker) {
+// RegisterChecker registers a health checker in the given probe groups, or in
+// the readiness probe if none are given
+func (m *Manager) RegisterChecker(checker HealthChecker, groups ...ProbeGroup) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	m.register(checker, groups)
+}
+
+// register registers a health checker. It must be called with m.mu held.
+func (m *Manager) register(checker HealthChecker, groups []ProbeGroup) {
 	name := checker.Name()
 	if _, exists := m.checkers[name]; exists {
 		logger.Warn(context.Background(), "Health checker with name already exists, overwriting", "name", name)
 	}
 
 	m.checkers[name] = checker
+	m.groups[name] = probeGroups(groups)
+	m.registerDependencies(name, checkOptions(checker).DependsOn)
+	delete(m.options, name)
 	logger.Debug(context.Background(), "Registered health checker", "name", name)
 }
 
-// RegisterCheckers registers multiple health checkers
-func (m *Manager) RegisterCheckers(checkers []HealthChecker) {
+// RegisterCheckerWithOptions registers a health checker with a timeout, cache
+// interval and importance. Once a checker is registered with an importance, a
+// manager without an explicit strategy aggregates the overall status with a
+// WeightedStrategy; checks registered without options are considered critical,
+// so they affect the overall status as they do with the default strategy, and
+// so are checks registered with options that do not set an importance.
+//
+// Example:
+//
+//	manager.RegisterCheckerWithOptions(dbCheck, health.HealthCheckOptions{
+//	    Timeout:    2 * time.Second,
+//	    Importance: health.Critical,
+//	})
+func (m *Manager) RegisterCheckerWithOptions(checker HealthChecker, opts HealthCheckOptions, groups ...ProbeGroup) {
+	if opts.Importance == 0 {
+		opts.Importance = Critical
+	}
+	m.RegisterChecker(checker, groups...)
+
+	m.mu.Lock()
+	m.options[checker.Name()] = opts
+	m.mu.Unlock()
+}
+
+// Importance returns the importance a checker was registered with, if any.
+func (m *Manager) Importance(name string) (ComponentImportance, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	opts, ok := m.options[name]
+	return opts.Importance, ok
+}
+
+// RegisterCheckers registers multiple health checkers, see RegisterChecker
+func (m *Manager) RegisterCheckers(checkers []HealthChecker, groups ...ProbeGroup) {
 	for _, checker := range checkers {
-		m.RegisterChecker(checker)
+		m.RegisterChecker(checker, groups...)
 	}
 }
 
 // UnregisterChecker removes a health checker
 func (m *Manager) UnregisterChecker(name string) {
 	m.mu.Lock()
-	defer m.mu.Unlock()
-
 	delete(m.checkers, name)
+	delete(m.options, name)
+	delete(m.groups, name)
+	delete(m.dependsOn, name)
 	delete(m.cache, name)
+	delete(m.history, name)
+	delete(m.thresholds, name)
+	m.mu.Unlock()
+
+	m.listenersMu.Lock()
+	delete(m.lastResults, name)
+	m.listenersMu.Unlock()
+
 	logger.Debug(context.Background(), "Unregistered health checker", "name", name)
 }
 
 // CheckAll runs all registered health checks
 func (m *Manager) CheckAll(ctx context.Context) map[string]HealthResult {
+	return m.checkAll(ctx, func(string) bool { return true })
+}
+
+// Check runs the registered health check with the given name, reporting
+// false if there is none.
+func (m *Manager) Check(ctx context.Context, name string) (HealthResult, bool) {
+	m.mu.RLock()
+	checker, ok := m.checkers[name]
+	m.mu.RUnlock()
+
+	if !ok {
+		return HealthResult{}, false
+	}
+
+	start := m.clock.Now()
+	result := m.checkWithCache(ctx, name, checker)
+	return result.WithDuration(m.clock.Since(start)), true
+}
+
+// checkAll runs the registered health checks accepted by the filter, which
+// is called with m.mu held.
+func (m *Manager) checkAll(ctx context.Context, filter func(name string) bool) map[string]HealthResult {
 	m.mu.RLock()
 	checkers := make(map[string]HealthChecker, len(m.checkers))
 	for name, checker := range m.checkers {
-		checkers[name] = checker
+		if filter(name) {
+			checkers[name] = checker
+		}
 	}
 	m.mu.RUnlock()
 
@@ -93,9 +280,9 @@ func (m *Manager) CheckAll(ctx context.Context) map[string]HealthResult {
 		go func(name string, checker HealthChecker) {
 			defer wg.Done()
 
-			start := time.Now()
+			start := m.clock.Now()
 			result := m.checkWithCache(ctx, name, checker)
-			result = result.WithDuration(time.Since(start))
+			result = result.WithDuration(m.clock.Since(start))
 
 			resultsMu.Lock()
 			results[name] = result
@@ -109,45 +296,344 @@ func (m *Manager) CheckAll(ctx context.Context) map[string]HealthResult {
 
 // checkWithCache checks a single health checker with caching
 func (m *Manager) checkWithCache(ctx context.Context, name string, checker HealthChecker) HealthResult {
+	if o, ok := m.override(name); ok {
+		result := o.result()
+		m.observeResult(name, result)
+		return result
+	}
+
+	if result, skipped := m.checkDependencies(ctx, name); skipped {
+		m.observeResult(name, result)
+		return result
+	}
+
 	m.mu.RLock()
 	cached, exists := m.cache[name]
+	interval := m.options[name].Interval
+	m.mu.RUnlock()
+
+	ttl := m.cacheTTL
+	if opts := checkOptions(checker); opts.CacheTTL != 0 {
+		ttl = opts.CacheTTL
+	} else if interval > 0 {
+		ttl = interval
+	}
+
+	if exists && ttl > 0 {
+		if m.clock.Since(cached.checkedAt) < ttl {
+			return cached.result
+		}
+		if m.staleWhileRevalidate {
+			m.revalidate(ctx, name, checker, ttl)
+			return cached.result
+		}
+	}
+
+	return m.checkShared(ctx, name, checker)
+}
+
+// checkShared runs a health checker, sharing a single execution between
+// concurrent callers, e.g. readiness and liveness probes arriving at once.
+// The execution is not cancelled with the caller that started it, so that its
+// cancellation is neither reported to the other callers nor cached; it is
+// bounded by the timeout of the check instead. Each caller stops waiting when
+// its own context is done.
+func (m *Manager) checkShared(ctx context.Context, name string, checker HealthChecker) HealthResult {
+	m.mu.RLock()
+	timeout := m.options[name].Timeout
 	m.mu.RUnlock()
+	if timeout <= 0 {
+		timeout = m.checkTimeout
+	}
 
-	if exists && time.Since(time.Now().Add(-cached.Duration)) < m.cacheTTL {
-		return cached
+	shared := context.WithoutCancel(ctx)
+	ch := m.inflight.DoChan(name, func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(shared, timeout)
+		defer cancel()
+		return m.check(ctx, name, checker), nil
+	})
+
+	select {
+	case res := <-ch:
+		return res.Val.(HealthResult)
+	case <-ctx.Done():
+		return NewUnhealthyResult("health check did not complete before the deadline").
+			WithDetails("error", ctx.Err().Error())
+	}
+}
+
+// check runs a health checker and caches its result.
+func (m *Manager) check(ctx context.Context, name string, checker HealthChecker) HealthResult {
+	if m.workers != nil {
+		select {
+		case m.workers <- struct{}{}:
+			defer func() { <-m.workers }()
+		case <-ctx.Done():
+			// Not cached, the check did not get a chance to run.
+			return NewUnhealthyResult("health check did not start before the deadline").
+				WithDetails("error", ctx.Err().Error())
+		}
 	}
 
 	result := checker.Check(ctx)
+	if m.redact != nil {
+		result = m.redact(name, result)
+	}
 
 	m.mu.Lock()
-	m.cache[name] = result
+	// The checker may have been unregistered while it was running.
+	_, registered := m.checkers[name]
+	if registered {
+		result = m.applyThresholds(name, checker, result)
+		now := m.clock.Now()
+		m.cache[name] = cacheEntry{result: result, checkedAt: now}
+		m.recordHistory(name, result, now)
+	}
 	m.mu.Unlock()
 
+	if registered {
+		m.observeResult(name, result)
+	}
+
 	return result
 }
 
+// applyThresholds applies the failure and success thresholds of a checker to
+// its result. It must be called with m.mu held.
+func (m *Manager) applyThresholds(name string, checker HealthChecker, result HealthResult) HealthResult {
+	opts := checkOptions(checker)
+	if opts.FailureThreshold <= 1 && opts.SuccessThreshold <= 1 {
+		return result
+	}
+
+	state, ok := m.thresholds[name]
+	if !ok {
+		state = &thresholdState{}
+		m.thresholds[name] = state
+	}
+	return state.apply(result, opts)
+}
+
+// revalidate refreshes the cached result of a checker in the background,
+// unless a refresh is already in progress. The refresh is bounded by the
+// timeout of the check, or the cache TTL without one, so that a check hanging
+// once does not prevent its result from ever being refreshed again.
+func (m *Manager) revalidate(ctx context.Context, name string, checker HealthChecker, ttl time.Duration) {
+	m.mu.Lock()
+	if m.refreshing[name] {
+		m.mu.Unlock()
+		return
+	}
+	m.refreshing[name] = true
+	timeout := m.options[name].Timeout
+	m.mu.Unlock()
+
+	if timeout <= 0 {
+		timeout = ttl
+	}
+
+	go func() {
+		defer func() {
+			m.mu.Lock()
+			delete(m.refreshing, name)
+			m.mu.Unlock()
+		}()
+
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+		defer cancel()
+		m.checkShared(ctx, name, checker)
+	}()
+}
+
 // GetOverallStatus returns the aggregated health status
 func (m *Manager) GetOverallStatus(ctx context.Context) HealthStatus {
+	_, status := m.CheckAllWithStatus(ctx)
+	return status
+}
+
+// CheckAllWithStatus runs all registered health checks once and returns their
+// results together with the aggregated health status, like CheckAll followed by
+// GetOverallStatus but without running every check twice.
+func (m *Manager) CheckAllWithStatus(ctx context.Context) (map[string]HealthResult, HealthStatus) {
 	results := m.CheckAll(ctx)
-	return m.strategy.Aggregate(results)
+	status := m.aggregate(results)
+	if o, ok := m.override(""); ok {
+		status = o.Status
+	}
+	m.observeStatus(status)
+	m.observeScore(results)
+	return results, status
 }
 
-// IsReady returns the readiness state
+// aggregate returns the overall status of the results using the configured
+// strategy, or a WeightedStrategy if the strategy is the default one and
+// checks have been registered with an importance. Checks registered without
+// one are critical then, as they are with the default AllHealthyStrategy.
+func (m *Manager) aggregate(results map[string]HealthResult) HealthStatus {
+	m.mu.RLock()
+	strategy := m.strategy
+	if m.defaultStrategy && len(m.options) > 0 {
+		weights := make(map[string]ComponentImportance, len(results))
+		for name := range results {
+			weights[name] = Critical
+			if opts, ok := m.options[name]; ok {
+				weights[name] = opts.Importance
+			}
+		}
+		strategy = &WeightedStrategy{Weights: weights}
+	}
+	m.mu.RUnlock()
+
+	return strategy.Aggregate(results)
+}
+
+// CheckReport is a health check result together with the importance, tags and
+// labels its checker was registered with, as served by the health endpoints.
+type CheckReport struct {
+	HealthResult
+	Importance *ComponentImportance `json:"importance,omitempty"`
+	Tags       []string             `json:"tags,omitempty"`
+	Labels     map[string]string    `json:"labels,omitempty"`
+}
+
+// Reports returns the results annotated with the importance, tags and labels
+// of their checkers.
+func (m *Manager) Reports(results map[string]HealthResult) map[string]CheckReport {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	reports := make(map[string]CheckReport, len(results))
+	for name, result := range results {
+		opts := checkOptions(m.checkers[name])
+		report := CheckReport{HealthResult: result, Tags: opts.Tags, Labels: opts.Labels}
+		if opts, ok := m.options[name]; ok {
+			importance := opts.Importance
+			report.Importance = &importance
+		}
+		reports[name] = report
+	}
+	return reports
+}
+
+// OnStatusChange registers a listener called when a check produces a result with
+// a different status than its previous one, e.g. to trigger alerts or shed load
+// when a dependency becomes unhealthy. The first result of a check sets the
+// baseline and is not reported. Listeners are called synchronously by the
+// goroutine running the check and must not block.
+func (m *Manager) OnStatusChange(fn func(check string, old, new HealthResult)) {
+	m.listenersMu.Lock()
+	defer m.listenersMu.Unlock()
+	m.checkListeners = append(m.checkListeners, fn)
+}
+
+// OnOverallStatusChange registers a listener called when the overall status observed by
+// GetOverallStatus differs from the previously observed one. The first
+// observation sets the baseline and is not reported.
+func (m *Manager) OnOverallStatusChange(fn func(from, to HealthStatus)) {
+	m.listenersMu.Lock()
+	defer m.listenersMu.Unlock()
+	m.overallListeners = append(m.overallListeners, fn)
+}
+
+// OnReadinessChange registers a listener called when the readiness observed by
+// IsReady or set by SetReady changes. The manager is considered not ready
+// until readiness is first observed.
+func (m *Manager) OnReadinessChange(fn func(ready bool)) {
+	m.listenersMu.Lock()
+	defer m.listenersMu.Unlock()
+	m.readyListeners = append(m.readyListeners, fn)
+}
+
+// observeStatus notifies the status listeners if the status has changed.
+func (m *Manager) observeStatus(status HealthStatus) {
+	m.listenersMu.Lock()
+	from := m.lastStatus
+	m.lastStatus = status
+	listeners := m.overallListeners
+	m.listenersMu.Unlock()
+
+	if from == "" || from == status {
+		return
+	}
+
+	for _, fn := range listeners {
+		fn(from, status)
+	}
+}
+
+// observeResult notifies the check listeners if the status of a check has changed.
+func (m *Manager) observeResult(name string, result HealthResult) {
+	m.listenersMu.Lock()
+	old, seen := m.lastResults[name]
+	m.lastResults[name] = result
+	listeners := m.checkListeners
+	m.listenersMu.Unlock()
+
+	if !seen || old.Status == result.Status {
+		return
+	}
+
+	for _, fn := range listeners {
+		fn(name, old, result)
+	}
+}
+
+// observeReady notifies the readiness listeners if the readiness has changed.
+func (m *Manager) observeReady(ready bool) {
+	m.listenersMu.Lock()
+	changed := m.lastReady != ready
+	m.lastReady = ready
+	listeners := m.readyListeners
+	m.listenersMu.Unlock()
+
+	if !changed {
+		return
+	}
+
+	for _, fn := range listeners {
+		fn(ready)
+	}
+}
+
+// IsReady returns the readiness state. The manager is ready when it has been
+// set ready, is not in maintenance mode, every readiness gate is open and every
+// readiness condition reports true.
 func (m *Manager) IsReady() bool {
 	m.readyMu.RLock()
-	defer m.readyMu.RUnlock()
-	return m.ready
+	ready := m.ready && !m.maintenance && len(m.pendingGates()) == 0
+	conditions := m.readinessConditions
+	m.readyMu.RUnlock()
+
+	for _, cond := range conditions {
+		if !ready {
+			break
+		}
+		ready = cond()
+	}
+
+	m.observeReady(ready)
+	return ready
 }
 
-// SetReady sets the readiness state
-func (m *Manager) SetReady(ready bool) {
+// AddReadinessCondition registers a condition that must report true for the
+// manager to be ready, e.g. the readiness of a service.
+func (m *Manager) AddReadinessCondition(cond func() bool) {
 	m.readyMu.Lock()
 	defer m.readyMu.Unlock()
+	m.readinessConditions = append(m.readinessConditions, cond)
+}
 
+// SetReady sets the readiness state
+func (m *Manager) SetReady(ready bool) {
+	m.readyMu.Lock()
 	if m.ready != ready {
 		m.ready = ready
 		logger.Info(context.Background(), "Application readiness changed", "ready", ready)
 	}
+	m.readyMu.Unlock()
+
+	// Notify readiness listeners about the resulting readiness.
+	m.IsReady()
 }
 
 // GetCheckerNames returns the names of all registered checkers
@@ -167,6 +653,6 @@ func (m *Manager) ClearCache() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	m.cache = make(map[string]HealthResult)
+	m.cache = make(map[string]cacheEntry)
 	logger.Debug(context.Background(), "Health check cache cleared")
 }
@@ -2,8 +2,15 @@ package health
 
 import (
 	"context"
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/katalabut/fast-app/clock"
 )
 
 type mockChecker struct {
@@ -155,6 +162,42 @@ func TestManager(t *testing.T) {
 		}
 	})
 
+	t.Run("ReadinessCondition", func(t *testing.T) {
+		manager := NewManager(ManagerConfig{})
+		serviceReady := false
+		manager.AddReadinessCondition(func() bool { return serviceReady })
+
+		if manager.IsReady() {
+			t.Error("Expected manager to be not ready while condition is false")
+		}
+
+		serviceReady = true
+		if !manager.IsReady() {
+			t.Error("Expected manager to be ready once condition is true")
+		}
+
+		manager.SetReady(false)
+		if manager.IsReady() {
+			t.Error("Expected SetReady(false) to override conditions")
+		}
+	})
+
+	t.Run("ReadinessListener", func(t *testing.T) {
+		manager := NewManager(ManagerConfig{})
+
+		var changes []bool
+		manager.OnReadinessChange(func(ready bool) { changes = append(changes, ready) })
+
+		manager.IsReady()
+		manager.IsReady()
+		manager.SetReady(false)
+		manager.SetReady(true)
+
+		if len(changes) != 3 || !changes[0] || changes[1] || !changes[2] {
+			t.Errorf("Expected changes [true false true], got %v", changes)
+		}
+	})
+
 	t.Run("ClearCache", func(t *testing.T) {
 		manager := NewManager(ManagerConfig{
 			CacheTTL: 1 * time.Hour, // Long cache to test clearing
@@ -177,3 +220,561 @@ func TestManager(t *testing.T) {
 		// but that would require exposing internal cache state
 	})
 }
+
+func TestManagerHistory(t *testing.T) {
+	t.Run("KeepsMostRecentResults", func(t *testing.T) {
+		manager := NewManager(ManagerConfig{CacheTTL: -1, HistorySize: 3})
+		checker := &mockChecker{name: "db"}
+		manager.RegisterChecker(checker)
+
+		for _, msg := range []string{"1", "2", "3", "4"} {
+			checker.result = NewHealthyResult(msg)
+			manager.CheckAll(context.Background())
+		}
+
+		history := manager.History("db")
+		if len(history) != 3 {
+			t.Fatalf("Expected 3 entries, got %d", len(history))
+		}
+		for i, want := range []string{"2", "3", "4"} {
+			if history[i].Message != want {
+				t.Errorf("Expected entry %d to be %q, got %q", i, want, history[i].Message)
+			}
+		}
+	})
+
+	t.Run("CachedResultsNotRecorded", func(t *testing.T) {
+		manager := NewManager(ManagerConfig{CacheTTL: time.Hour})
+		manager.RegisterChecker(&mockChecker{name: "db", result: NewHealthyResult("ok")})
+
+		manager.CheckAll(context.Background())
+		manager.CheckAll(context.Background())
+
+		if got := len(manager.History("db")); got != 1 {
+			t.Errorf("Expected 1 entry, got %d", got)
+		}
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		manager := NewManager(ManagerConfig{HistorySize: -1})
+		manager.RegisterChecker(&mockChecker{name: "db", result: NewHealthyResult("ok")})
+		manager.CheckAll(context.Background())
+
+		if history := manager.History("db"); history != nil {
+			t.Errorf("Expected no history, got %+v", history)
+		}
+	})
+
+	t.Run("UnregisterRemovesHistory", func(t *testing.T) {
+		manager := NewManager(ManagerConfig{})
+		manager.RegisterChecker(&mockChecker{name: "db", result: NewHealthyResult("ok")})
+		manager.CheckAll(context.Background())
+		manager.UnregisterChecker("db")
+
+		if all := manager.AllHistory(); len(all) != 0 {
+			t.Errorf("Expected empty history, got %+v", all)
+		}
+	})
+}
+
+func TestManagerStatusChange(t *testing.T) {
+	t.Run("CheckListener", func(t *testing.T) {
+		manager := NewManager(ManagerConfig{CacheTTL: -1})
+		checker := &mockChecker{name: "db", result: NewHealthyResult("ok")}
+		manager.RegisterChecker(checker)
+
+		type change struct {
+			check    string
+			old, new HealthStatus
+		}
+		var changes []change
+		manager.OnStatusChange(func(check string, old, new HealthResult) {
+			changes = append(changes, change{check, old.Status, new.Status})
+		})
+
+		manager.CheckAll(context.Background())
+		manager.CheckAll(context.Background())
+		checker.result = NewUnhealthyResult("connection refused")
+		manager.CheckAll(context.Background())
+		checker.result = NewDegradedResult("slow")
+		manager.CheckAll(context.Background())
+
+		want := []change{
+			{"db", StatusHealthy, StatusUnhealthy},
+			{"db", StatusUnhealthy, StatusDegraded},
+		}
+		if len(changes) != len(want) {
+			t.Fatalf("Expected %d changes, got %+v", len(want), changes)
+		}
+		for i := range want {
+			if changes[i] != want[i] {
+				t.Errorf("Expected change %d to be %+v, got %+v", i, want[i], changes[i])
+			}
+		}
+	})
+
+	t.Run("OverallListener", func(t *testing.T) {
+		manager := NewManager(ManagerConfig{CacheTTL: -1})
+		checker := &mockChecker{name: "db", result: NewHealthyResult("ok")}
+		manager.RegisterChecker(checker)
+
+		var changes []HealthStatus
+		manager.OnOverallStatusChange(func(from, to HealthStatus) {
+			changes = append(changes, from, to)
+		})
+
+		manager.GetOverallStatus(context.Background())
+		checker.result = NewUnhealthyResult("down")
+		manager.GetOverallStatus(context.Background())
+
+		if len(changes) != 2 || changes[0] != StatusHealthy || changes[1] != StatusUnhealthy {
+			t.Errorf("Expected change from healthy to unhealthy, got %v", changes)
+		}
+	})
+}
+
+func TestManagerCheckerOptions(t *testing.T) {
+	t.Run("WeightedByImportance", func(t *testing.T) {
+		manager := NewManager(ManagerConfig{})
+		manager.RegisterCheckerWithOptions(&mockChecker{name: "cache", result: NewUnhealthyResult("down")}, HealthCheckOptions{Importance: Optional})
+		manager.RegisterCheckerWithOptions(&mockChecker{name: "db", result: NewHealthyResult("ok")}, HealthCheckOptions{Importance: Critical})
+
+		if status := manager.GetOverallStatus(context.Background()); status != StatusHealthy {
+			t.Errorf("Expected %s with an unhealthy optional check, got %s", StatusHealthy, status)
+		}
+
+		manager.RegisterCheckerWithOptions(&mockChecker{name: "db", result: NewUnhealthyResult("down")}, HealthCheckOptions{Importance: Critical})
+		manager.ClearCache()
+		if status := manager.GetOverallStatus(context.Background()); status != StatusUnhealthy {
+			t.Errorf("Expected %s with an unhealthy critical check, got %s", StatusUnhealthy, status)
+		}
+	})
+
+	t.Run("PlainChecksAreCritical", func(t *testing.T) {
+		manager := NewManager(ManagerConfig{})
+		manager.RegisterChecker(&mockChecker{name: "db", result: NewUnhealthyResult("down")})
+		manager.RegisterCheckerWithOptions(&mockChecker{name: "cache", result: NewHealthyResult("ok")}, HealthCheckOptions{Importance: Optional})
+
+		if status := manager.GetOverallStatus(context.Background()); status != StatusUnhealthy {
+			t.Errorf("Expected %s with an unhealthy check registered without options, got %s", StatusUnhealthy, status)
+		}
+	})
+
+	t.Run("UnsetImportanceIsCritical", func(t *testing.T) {
+		manager := NewManager(ManagerConfig{})
+		manager.RegisterCheckerWithOptions(&mockChecker{name: "db", result: NewUnhealthyResult("down")}, HealthCheckOptions{Timeout: time.Second})
+		manager.RegisterCheckerWithOptions(&mockChecker{name: "cache", result: NewHealthyResult("ok")}, HealthCheckOptions{Importance: Optional})
+
+		if status := manager.GetOverallStatus(context.Background()); status != StatusUnhealthy {
+			t.Errorf("Expected %s with an unhealthy check registered without importance, got %s", StatusUnhealthy, status)
+		}
+		if importance, _ := manager.Importance("db"); importance != Critical {
+			t.Errorf("Expected importance %s, got %s", Critical, importance)
+		}
+	})
+
+	t.Run("ExplicitStrategyIsKept", func(t *testing.T) {
+		manager := NewManager(ManagerConfig{Strategy: &AllHealthyStrategy{}})
+		manager.RegisterCheckerWithOptions(&mockChecker{name: "cache", result: NewUnhealthyResult("down")}, HealthCheckOptions{Importance: Optional})
+
+		if status := manager.GetOverallStatus(context.Background()); status != StatusUnhealthy {
+			t.Errorf("Expected %s, got %s", StatusUnhealthy, status)
+		}
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		manager := NewManager(ManagerConfig{})
+		manager.RegisterCheckerWithOptions(NewCustomCheck("slow", func(ctx context.Context) HealthResult {
+			<-ctx.Done()
+			return NewUnhealthyResult("timeout")
+		}), HealthCheckOptions{Timeout: 10 * time.Millisecond})
+
+		result := manager.CheckAll(context.Background())["slow"]
+		if result.Status != StatusUnhealthy {
+			t.Errorf("Expected %s, got %s", StatusUnhealthy, result.Status)
+		}
+	})
+
+	t.Run("Reports", func(t *testing.T) {
+		manager := NewManager(ManagerConfig{})
+		manager.RegisterChecker(&mockChecker{name: "plain", result: NewHealthyResult("ok")})
+		manager.RegisterCheckerWithOptions(&mockChecker{name: "db", result: NewHealthyResult("ok")}, HealthCheckOptions{Importance: Critical})
+
+		reports := manager.Reports(manager.CheckAll(context.Background()))
+		if reports["plain"].Importance != nil {
+			t.Errorf("Expected no importance for plain check, got %v", *reports["plain"].Importance)
+		}
+		if reports["db"].Importance == nil || *reports["db"].Importance != Critical {
+			t.Errorf("Expected importance %s for db check, got %v", Critical, reports["db"].Importance)
+		}
+
+		data, err := json.Marshal(reports["db"])
+		if err != nil {
+			t.Fatalf("Failed to marshal report: %v", err)
+		}
+		if !strings.Contains(string(data), `"importance":"critical"`) || !strings.Contains(string(data), `"status":"healthy"`) {
+			t.Errorf("Unexpected JSON %s", data)
+		}
+
+		manager.UnregisterChecker("db")
+		if _, ok := manager.Importance("db"); ok {
+			t.Error("Expected importance to be removed with the checker")
+		}
+	})
+}
+
+func TestManagerProbeGroups(t *testing.T) {
+	manager := NewManager(ManagerConfig{})
+	manager.RegisterChecker(&mockChecker{name: "db", result: NewUnhealthyResult("down")})
+	manager.RegisterChecker(&mockChecker{name: "deadlock", result: NewHealthyResult("ok")}, ForLiveness())
+	manager.RegisterChecker(&mockChecker{name: "warmup", result: NewHealthyResult("ok")}, ForReadiness(), ForStartup())
+
+	ctx := context.Background()
+	if results := manager.CheckGroup(ctx, ForReadiness()); len(results) != 2 {
+		t.Errorf("Expected 2 readiness checks, got %d", len(results))
+	}
+	if results := manager.CheckGroup(ctx, ForStartup()); len(results) != 1 || results["warmup"].Status != StatusHealthy {
+		t.Errorf("Expected only the warmup startup check, got %v", results)
+	}
+
+	if status := manager.GetGroupStatus(ctx, ForLiveness()); status != StatusHealthy {
+		t.Errorf("Expected liveness %s, got %s", StatusHealthy, status)
+	}
+	if status := manager.GetGroupStatus(ctx, ForReadiness()); status != StatusUnhealthy {
+		t.Errorf("Expected readiness %s, got %s", StatusUnhealthy, status)
+	}
+
+	manager.UnregisterChecker("deadlock")
+	if results := manager.CheckGroup(ctx, ForLiveness()); len(results) != 0 {
+		t.Errorf("Expected no liveness checks, got %d", len(results))
+	}
+}
+
+func TestManagerCheckAllWithStatus(t *testing.T) {
+	manager := NewManager(ManagerConfig{})
+	manager.RegisterChecker(&mockChecker{name: "db", result: NewHealthyResult("ok")})
+	manager.RegisterChecker(&mockChecker{name: "cache", result: NewDegradedResult("slow")}, ForLiveness())
+
+	results, status := manager.CheckAllWithStatus(context.Background())
+	if len(results) != 2 {
+		t.Errorf("Expected 2 results, got %d", len(results))
+	}
+	if status != StatusDegraded {
+		t.Errorf("Expected %s, got %s", StatusDegraded, status)
+	}
+	if status := manager.GroupStatus(results, ForReadiness()); status != StatusHealthy {
+		t.Errorf("Expected readiness %s, got %s", StatusHealthy, status)
+	}
+}
+
+func TestManagerOverride(t *testing.T) {
+	fake := clock.NewFake(time.Now())
+	manager := NewManager(ManagerConfig{Clock: fake})
+	manager.RegisterChecker(&mockChecker{name: "db", result: NewHealthyResult("ok")})
+	manager.RegisterChecker(&mockChecker{name: "deadlock", result: NewHealthyResult("ok")}, ForLiveness())
+
+	t.Run("Check", func(t *testing.T) {
+		manager.SetOverride("db", StatusUnhealthy, "failover", time.Minute)
+
+		result := manager.CheckAll(context.Background())["db"]
+		if result.Status != StatusUnhealthy || result.Message != "failover" {
+			t.Errorf("Expected overridden result, got %+v", result)
+		}
+		if result.Details["override"] != true {
+			t.Errorf("Expected override detail, got %v", result.Details)
+		}
+
+		fake.Advance(time.Minute)
+		if result := manager.CheckAll(context.Background())["db"]; result.Status != StatusHealthy {
+			t.Errorf("Expected %s once the override expired, got %s", StatusHealthy, result.Status)
+		}
+		if len(manager.Overrides()) != 0 {
+			t.Errorf("Expected no active overrides, got %v", manager.Overrides())
+		}
+	})
+
+	t.Run("Overall", func(t *testing.T) {
+		manager.SetOverride("", StatusUnhealthy, "maintenance", time.Minute)
+
+		if status := manager.GetOverallStatus(context.Background()); status != StatusUnhealthy {
+			t.Errorf("Expected overall %s, got %s", StatusUnhealthy, status)
+		}
+		if status := manager.GetGroupStatus(context.Background(), ForReadiness()); status != StatusUnhealthy {
+			t.Errorf("Expected readiness %s, got %s", StatusUnhealthy, status)
+		}
+		if status := manager.GetGroupStatus(context.Background(), ForLiveness()); status != StatusHealthy {
+			t.Errorf("Expected liveness %s, got %s", StatusHealthy, status)
+		}
+
+		manager.ClearOverride("")
+		if status := manager.GetOverallStatus(context.Background()); status != StatusHealthy {
+			t.Errorf("Expected overall %s after clearing, got %s", StatusHealthy, status)
+		}
+	})
+}
+
+func TestManagerCheckMatching(t *testing.T) {
+	manager := NewManager(ManagerConfig{})
+	manager.RegisterChecker(WithCheckOptions(
+		&mockChecker{name: "db", result: NewHealthyResult("ok")},
+		CheckOptions{Tags: []string{"critical"}, Labels: map[string]string{"team": "orders"}},
+	))
+	manager.RegisterChecker(WithCheckOptions(
+		&mockChecker{name: "redis", result: NewDegradedResult("slow")},
+		CheckOptions{Tags: []string{"cache"}, Labels: map[string]string{"team": "platform"}},
+	))
+	manager.RegisterChecker(&mockChecker{name: "external-api", result: NewUnhealthyResult("down")})
+
+	tests := []struct {
+		query    string
+		expected []string
+		status   HealthStatus
+	}{
+		{"", []string{"db", "external-api", "redis"}, StatusUnhealthy},
+		{"check=db,redis", []string{"db", "redis"}, StatusDegraded},
+		{"check=db&check=external-api", []string{"db", "external-api"}, StatusUnhealthy},
+		{"exclude=external-api", []string{"db", "redis"}, StatusDegraded},
+		{"tag=critical", []string{"db"}, StatusHealthy},
+		{"tag=critical,cache&exclude=redis", []string{"db"}, StatusHealthy},
+		{"label=team=platform", []string{"redis"}, StatusDegraded},
+		{"label=team=orders,tier=1", nil, StatusHealthy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			query, _ := url.ParseQuery(tt.query)
+			results, status := manager.CheckMatching(context.Background(), ParseCheckFilter(query))
+
+			names := make([]string, 0, len(results))
+			for name := range results {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			if strings.Join(names, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("Expected checks %v, got %v", tt.expected, names)
+			}
+			if status != tt.status {
+				t.Errorf("Expected status %s, got %s", tt.status, status)
+			}
+		})
+	}
+}
+
+func TestManagerDependencies(t *testing.T) {
+	t.Run("SkippedWhileParentUnhealthy", func(t *testing.T) {
+		manager := NewManager(ManagerConfig{CacheTTL: -1})
+		db := &mockChecker{name: "database", result: NewUnhealthyResult("connection refused")}
+		var calls atomic.Int32
+		manager.RegisterChecker(db)
+		manager.RegisterChecker(WithDependsOn(NewCustomCheck("orders-api", func(ctx context.Context) HealthResult {
+			calls.Add(1)
+			return NewHealthyResult("ok")
+		}), "database"))
+		manager.RegisterChecker(WithDependsOn(&mockChecker{name: "reports", result: NewHealthyResult("ok")}, "orders-api"))
+
+		results, status := manager.CheckAllWithStatus(context.Background())
+		if results["orders-api"].Status != StatusSkipped || results["reports"].Status != StatusSkipped {
+			t.Errorf("Expected dependents to be skipped, got %v", results)
+		}
+		if results["orders-api"].Details["dependency"] != "database" {
+			t.Errorf("Expected dependency 'database', got %v", results["orders-api"].Details)
+		}
+		if n := calls.Load(); n != 0 {
+			t.Errorf("Expected skipped check not to run, got %d calls", n)
+		}
+		if status != StatusUnhealthy {
+			t.Errorf("Expected overall %s, got %s", StatusUnhealthy, status)
+		}
+
+		db.result = NewHealthyResult("ok")
+		results = manager.CheckAll(context.Background())
+		if results["orders-api"].Status != StatusHealthy || results["reports"].Status != StatusHealthy {
+			t.Errorf("Expected dependents to run once the parent recovered, got %v", results)
+		}
+	})
+
+	t.Run("CycleIsIgnored", func(t *testing.T) {
+		manager := NewManager(ManagerConfig{})
+		manager.RegisterChecker(WithDependsOn(&mockChecker{name: "a", result: NewUnhealthyResult("down")}, "b"))
+		manager.RegisterChecker(WithDependsOn(&mockChecker{name: "b", result: NewHealthyResult("ok")}, "a"))
+
+		results := manager.CheckAll(context.Background())
+		if results["a"].Status != StatusUnhealthy || results["b"].Status != StatusHealthy {
+			t.Errorf("Expected the cyclic dependency of b to be ignored, got %v", results)
+		}
+	})
+
+	t.Run("KeepsOptions", func(t *testing.T) {
+		checker := WithDependsOn(WithCheckOptions(&mockChecker{name: "cache"}, CheckOptions{Tags: []string{"critical"}}), "database")
+		opts := checkOptions(checker)
+		if len(opts.Tags) != 1 || len(opts.DependsOn) != 1 || opts.DependsOn[0] != "database" {
+			t.Errorf("Expected tags and dependencies, got %+v", opts)
+		}
+	})
+}
+
+func TestManagerSetStatus(t *testing.T) {
+	fake := clock.NewFake(time.Now())
+	manager := NewManager(ManagerConfig{Clock: fake, PushStaleAfter: time.Minute})
+
+	var changes []HealthStatus
+	manager.OnStatusChange(func(check string, old, new HealthResult) {
+		changes = append(changes, new.Status)
+	})
+
+	t.Run("Push", func(t *testing.T) {
+		manager.SetStatus("consumer-lag", NewHealthyResult("lag 10"))
+		manager.SetStatus("consumer-lag", NewDegradedResult("lag 5000"))
+
+		result, ok := manager.Check(context.Background(), "consumer-lag")
+		if !ok || result.Status != StatusDegraded || result.Message != "lag 5000" {
+			t.Errorf("Expected the pushed result, got %+v", result)
+		}
+		if len(changes) != 1 || changes[0] != StatusDegraded {
+			t.Errorf("Expected listeners to be notified of the push, got %v", changes)
+		}
+	})
+
+	t.Run("Stale", func(t *testing.T) {
+		fake.Advance(time.Minute)
+
+		result, _ := manager.Check(context.Background(), "consumer-lag")
+		if result.Status != StatusUnhealthy {
+			t.Errorf("Expected stale status %s, got %s", StatusUnhealthy, result.Status)
+		}
+		if result.Details["last_status"] != StatusDegraded {
+			t.Errorf("Expected last status detail, got %v", result.Details)
+		}
+
+		manager.SetStatus("consumer-lag", NewHealthyResult("lag 10"))
+		if result, _ := manager.Check(context.Background(), "consumer-lag"); result.Status != StatusHealthy {
+			t.Errorf("Expected %s once refreshed, got %s", StatusHealthy, result.Status)
+		}
+	})
+
+	t.Run("RegisteredBeforePush", func(t *testing.T) {
+		manager.RegisterPushed("pool", -1, ForLiveness())
+
+		if result, _ := manager.Check(context.Background(), "pool"); result.Status != StatusUnhealthy {
+			t.Errorf("Expected %s before the first push, got %s", StatusUnhealthy, result.Status)
+		}
+
+		manager.SetStatus("pool", NewHealthyResult("10 idle connections"))
+		fake.Advance(time.Hour)
+		if status := manager.GetGroupStatus(context.Background(), ForLiveness()); status != StatusHealthy {
+			t.Errorf("Expected liveness %s without staleness, got %s", StatusHealthy, status)
+		}
+	})
+
+	t.Run("PolledChecker", func(t *testing.T) {
+		manager.RegisterChecker(&mockChecker{name: "db", result: NewHealthyResult("ok")})
+		manager.SetStatus("db", NewUnhealthyResult("down"))
+
+		if result, _ := manager.Check(context.Background(), "db"); result.Status != StatusHealthy {
+			t.Errorf("Expected pushes to polled checkers to be ignored, got %s", result.Status)
+		}
+	})
+}
+
+func TestManagerTagSummaries(t *testing.T) {
+	manager := NewManager(ManagerConfig{})
+	manager.RegisterChecker(WithCheckOptions(
+		&mockChecker{name: "db", result: NewHealthyResult("ok")},
+		CheckOptions{Tags: []string{"critical"}, Labels: map[string]string{"team": "orders"}},
+	))
+	manager.RegisterChecker(WithCheckOptions(
+		&mockChecker{name: "payments", result: NewUnhealthyResult("down")},
+		CheckOptions{Tags: []string{"critical"}},
+	))
+	manager.RegisterChecker(&mockChecker{name: "redis", result: NewDegradedResult("slow")})
+
+	summaries := manager.TagSummaries(manager.CheckAll(context.Background()))
+	if len(summaries) != 2 {
+		t.Fatalf("Expected 2 summaries, got %v", summaries)
+	}
+
+	critical := summaries["critical"]
+	if critical.Status != StatusUnhealthy || critical.Healthy != 1 || critical.Unhealthy != 1 {
+		t.Errorf("Expected unhealthy critical summary with 1 healthy and 1 unhealthy check, got %+v", critical)
+	}
+	if strings.Join(critical.Checks, ",") != "db,payments" {
+		t.Errorf("Expected checks db,payments, got %v", critical.Checks)
+	}
+	if team := summaries["team=orders"]; team.Status != StatusHealthy || len(team.Checks) != 1 {
+		t.Errorf("Expected healthy team=orders summary, got %+v", team)
+	}
+}
+
+func TestManagerSnapshot(t *testing.T) {
+	manager := NewManager(ManagerConfig{})
+	calls := 0
+	manager.RegisterChecker(NewCustomCheck("db", func(ctx context.Context) HealthResult {
+		calls++
+		return NewDegradedResult("slow")
+	}))
+
+	if snapshot := manager.Snapshot(); len(snapshot.Results) != 0 || !snapshot.IsHealthy() {
+		t.Errorf("Expected empty healthy snapshot before checks ran, got %+v", snapshot)
+	}
+
+	manager.CheckAll(context.Background())
+	snapshot := manager.Snapshot()
+	if !snapshot.IsDegraded() || snapshot.Results["db"].Message != "slow" {
+		t.Errorf("Expected degraded snapshot with the db result, got %+v", snapshot)
+	}
+	if !snapshot.Ready {
+		t.Error("Expected snapshot to be ready")
+	}
+	if calls != 1 {
+		t.Errorf("Expected snapshots not to run checks, got %d calls", calls)
+	}
+
+	manager.SetMaintenance(true, "migration")
+	if snapshot := manager.Snapshot(); snapshot.Ready || !snapshot.Maintenance {
+		t.Errorf("Expected snapshot in maintenance, got %+v", snapshot)
+	}
+}
+
+func TestManagerReadinessGates(t *testing.T) {
+	manager := NewManager(ManagerConfig{})
+	manager.SetReady(true)
+
+	var changes []bool
+	manager.OnReadinessChange(func(ready bool) {
+		changes = append(changes, ready)
+	})
+
+	cache := manager.ReadinessGate("cache")
+	migrations := manager.ReadinessGate("migrations")
+	if manager.IsReady() {
+		t.Error("Expected manager not to be ready with closed gates")
+	}
+	if pending := manager.PendingGates(); len(pending) != 2 || pending[0] != "cache" || pending[1] != "migrations" {
+		t.Errorf("Expected pending gates [cache migrations], got %v", pending)
+	}
+
+	cache.Open()
+	if !manager.ReadinessGate("cache").IsOpen() {
+		t.Error("Expected the same gate to be returned for the same name")
+	}
+	if manager.IsReady() {
+		t.Error("Expected manager not to be ready with a closed gate")
+	}
+
+	migrations.Open()
+	if !manager.IsReady() {
+		t.Error("Expected manager to be ready with all gates open")
+	}
+	if pending := manager.PendingGates(); len(pending) != 0 {
+		t.Errorf("Expected no pending gates, got %v", pending)
+	}
+
+	cache.Close()
+	if manager.IsReady() {
+		t.Error("Expected manager not to be ready after closing a gate")
+	}
+	if len(changes) == 0 || changes[len(changes)-1] {
+		t.Errorf("Expected readiness listeners to be notified, got %v", changes)
+	}
+}
@@ -0,0 +1,289 @@
+// Package notify posts health status transitions to webhooks such as Slack or
+// PagerDuty. Transitions are debounced so that a flapping check does not flood
+// the receivers, and filtered by severity.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/katalabut/fast-app/clock"
+	"github.com/katalabut/fast-app/config"
+	"github.com/katalabut/fast-app/health"
+	"github.com/katalabut/fast-app/logger"
+)
+
+// Webhook types supported by the notifier.
+const (
+	TypeGeneric   = "generic"
+	TypeSlack     = "slack"
+	TypePagerDuty = "pagerduty"
+)
+
+// overallSubject is the subject of notifications about the overall status.
+const overallSubject = ""
+
+// Notification describes a health status transition.
+type Notification struct {
+	// App is the name of the application.
+	App string `json:"app,omitempty"`
+	// Check is the name of the check, or empty for the overall status.
+	Check          string              `json:"check,omitempty"`
+	Status         health.HealthStatus `json:"status"`
+	PreviousStatus health.HealthStatus `json:"previous_status"`
+	Message        string              `json:"message,omitempty"`
+	Time           time.Time           `json:"time"`
+}
+
+// Options contains optional dependencies of a Notifier.
+type Options struct {
+	// App is the application name included in notifications.
+	App string
+	// Client sends the webhook requests, http.DefaultClient if nil.
+	Client *http.Client
+	// Clock is used for debouncing, the system clock if nil.
+	Clock clock.Clock
+}
+
+// Notifier sends notifications about health status transitions to webhooks.
+type Notifier struct {
+	cfg    config.Notifications
+	opts   Options
+	minSev int
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu       sync.Mutex
+	notified map[string]health.HealthStatus
+	pending  map[string]chan struct{}
+}
+
+// New creates a Notifier for the given configuration. Call Attach to start
+// observing a health manager and Close to stop sending notifications.
+func New(cfg config.Notifications, opts Options) (*Notifier, error) {
+	minSev := severity(health.HealthStatus(cfg.MinSeverity))
+	if cfg.MinSeverity == "" {
+		minSev = severity(health.StatusDegraded)
+	} else if minSev < 0 {
+		return nil, errors.Errorf("unknown minimum severity %q", cfg.MinSeverity)
+	}
+
+	for _, w := range cfg.Webhooks {
+		switch w.Type {
+		case "", TypeGeneric, TypeSlack, TypePagerDuty:
+		default:
+			return nil, errors.Errorf("unknown webhook type %q", w.Type)
+		}
+		if w.URL == "" && w.Type != TypePagerDuty {
+			return nil, errors.Errorf("webhook of type %q has no URL", w.Type)
+		}
+	}
+
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	if opts.Client == nil {
+		opts.Client = http.DefaultClient
+	}
+	if opts.Clock == nil {
+		opts.Clock = clock.Real()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Notifier{
+		cfg:      cfg,
+		opts:     opts,
+		minSev:   minSev,
+		ctx:      ctx,
+		cancel:   cancel,
+		notified: make(map[string]health.HealthStatus),
+		pending:  make(map[string]chan struct{}),
+	}, nil
+}
+
+// Attach registers the notifier as a listener of per-check and overall status
+// changes of the manager.
+func (n *Notifier) Attach(m *health.Manager) {
+	m.OnStatusChange(func(check string, old, new health.HealthResult) {
+		n.Observe(check, old.Status, new.Status, new.Message)
+	})
+	m.OnOverallStatusChange(func(from, to health.HealthStatus) {
+		n.Observe(overallSubject, from, to, "")
+	})
+}
+
+// Observe records a status transition of a check, or of the overall status if
+// check is empty. The notification is sent once the new status has persisted
+// for the configured debounce period; a transition back to the last notified
+// status within that period cancels it.
+func (n *Notifier) Observe(check string, from, to health.HealthStatus, message string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.ctx.Err() != nil {
+		return
+	}
+
+	if cancel, ok := n.pending[check]; ok {
+		close(cancel)
+		delete(n.pending, check)
+	}
+
+	if last, ok := n.notified[check]; ok {
+		from = last
+	} else {
+		n.notified[check] = from
+	}
+	if from == to {
+		return
+	}
+
+	notification := Notification{
+		App:            n.opts.App,
+		Check:          check,
+		Status:         to,
+		PreviousStatus: from,
+		Message:        message,
+	}
+
+	cancel := make(chan struct{})
+	n.pending[check] = cancel
+
+	var after <-chan time.Time
+	if n.cfg.Debounce > 0 {
+		after = n.opts.Clock.After(n.cfg.Debounce)
+	}
+
+	n.wg.Add(1)
+	go func() {
+		defer n.wg.Done()
+
+		if after != nil {
+			select {
+			case <-after:
+			case <-cancel:
+				return
+			case <-n.ctx.Done():
+				// Send notifications whose debounce period has already elapsed.
+				select {
+				case <-after:
+				default:
+					return
+				}
+			}
+		}
+
+		n.mu.Lock()
+		select {
+		case <-cancel:
+			n.mu.Unlock()
+			return
+		default:
+		}
+		delete(n.pending, check)
+		n.notified[check] = to
+		n.mu.Unlock()
+
+		if !n.shouldNotify(from, to) {
+			return
+		}
+
+		notification.Time = n.opts.Clock.Now()
+		n.send(notification)
+	}()
+}
+
+// Close cancels pending notifications and waits for in-flight requests.
+func (n *Notifier) Close() error {
+	n.mu.Lock()
+	n.cancel()
+	n.mu.Unlock()
+
+	n.wg.Wait()
+	return nil
+}
+
+// shouldNotify reports whether a transition enters or leaves a status at
+// least as severe as the minimum severity.
+func (n *Notifier) shouldNotify(from, to health.HealthStatus) bool {
+	return severity(from) >= n.minSev || severity(to) >= n.minSev
+}
+
+// send posts the notification to every webhook, logging failures.
+func (n *Notifier) send(notification Notification) {
+	for _, w := range n.cfg.Webhooks {
+		if err := n.post(w, notification); err != nil {
+			logger.Warn(n.ctx, "Failed to send health notification",
+				"type", w.Type, "check", notification.Check, "error", err)
+		}
+	}
+}
+
+func (n *Notifier) post(w config.Webhook, notification Notification) error {
+	url := w.URL
+	var payload interface{}
+	switch w.Type {
+	case TypeSlack:
+		payload = slackPayload(notification)
+	case TypePagerDuty:
+		if url == "" {
+			url = pagerDutyURL
+		}
+		payload = pagerDutyPayload(w.RoutingKey.Reveal(), notification)
+	default:
+		payload = notification
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "encode payload")
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(n.ctx), n.cfg.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range w.Headers {
+		req.Header.Set(key, value.Reveal())
+	}
+
+	resp, err := n.opts.Client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "post")
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// severity orders statuses from healthy to unhealthy, -1 if unknown.
+func severity(s health.HealthStatus) int {
+	switch s {
+	case health.StatusHealthy:
+		return 0
+	case health.StatusDegraded:
+		return 1
+	case health.StatusUnhealthy:
+		return 2
+	default:
+		return -1
+	}
+}
@@ -0,0 +1,177 @@
+package notify
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/katalabut/fast-app/clock"
+	"github.com/katalabut/fast-app/config"
+	"github.com/katalabut/fast-app/health"
+)
+
+// recorder is a webhook receiver that records the decoded request bodies.
+type recorder struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	payloads []map[string]interface{}
+	headers  []http.Header
+}
+
+func newRecorder(t *testing.T) *recorder {
+	r := &recorder{}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var payload map[string]interface{}
+		if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+			t.Errorf("Failed to decode payload: %v", err)
+		}
+
+		r.mu.Lock()
+		r.payloads = append(r.payloads, payload)
+		r.headers = append(r.headers, req.Header.Clone())
+		r.mu.Unlock()
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+func (r *recorder) received() []map[string]interface{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]map[string]interface{}(nil), r.payloads...)
+}
+
+func TestNotifier(t *testing.T) {
+	t.Run("Debounce", func(t *testing.T) {
+		rec := newRecorder(t)
+		clk := clock.NewFake(time.Now())
+		n, err := New(config.Notifications{
+			Debounce: 30 * time.Second,
+			Webhooks: []config.Webhook{{URL: rec.URL, Headers: map[string]config.Secret{"X-Token": "secret"}}},
+		}, Options{App: "orders", Clock: clk})
+		if err != nil {
+			t.Fatalf("Failed to create notifier: %v", err)
+		}
+
+		n.Observe("db", health.StatusHealthy, health.StatusUnhealthy, "connection refused")
+		clk.BlockUntil(1)
+		clk.Advance(30 * time.Second)
+		n.Close()
+
+		payloads := rec.received()
+		if len(payloads) != 1 {
+			t.Fatalf("Expected 1 notification, got %d", len(payloads))
+		}
+		if payloads[0]["check"] != "db" || payloads[0]["status"] != "unhealthy" || payloads[0]["previous_status"] != "healthy" {
+			t.Errorf("Unexpected payload %v", payloads[0])
+		}
+		if payloads[0]["app"] != "orders" {
+			t.Errorf("Expected app 'orders', got %v", payloads[0]["app"])
+		}
+		if rec.headers[0].Get("X-Token") != "secret" {
+			t.Errorf("Expected X-Token header 'secret', got '%s'", rec.headers[0].Get("X-Token"))
+		}
+	})
+
+	t.Run("FlapIsSuppressed", func(t *testing.T) {
+		rec := newRecorder(t)
+		clk := clock.NewFake(time.Now())
+		n, err := New(config.Notifications{
+			Debounce: 30 * time.Second,
+			Webhooks: []config.Webhook{{URL: rec.URL}},
+		}, Options{Clock: clk})
+		if err != nil {
+			t.Fatalf("Failed to create notifier: %v", err)
+		}
+
+		n.Observe("db", health.StatusHealthy, health.StatusUnhealthy, "")
+		n.Observe("db", health.StatusUnhealthy, health.StatusHealthy, "")
+		clk.Advance(time.Minute)
+		n.Close()
+
+		if len(rec.received()) != 0 {
+			t.Errorf("Expected no notifications, got %v", rec.received())
+		}
+	})
+
+	t.Run("MinSeverity", func(t *testing.T) {
+		rec := newRecorder(t)
+		n, err := New(config.Notifications{
+			MinSeverity: "unhealthy",
+			Webhooks:    []config.Webhook{{URL: rec.URL}},
+		}, Options{})
+		if err != nil {
+			t.Fatalf("Failed to create notifier: %v", err)
+		}
+
+		n.Observe("cache", health.StatusHealthy, health.StatusDegraded, "")
+		n.Close()
+
+		if len(rec.received()) != 0 {
+			t.Errorf("Expected degraded transition to be filtered, got %v", rec.received())
+		}
+	})
+
+	t.Run("Slack", func(t *testing.T) {
+		rec := newRecorder(t)
+		n, err := New(config.Notifications{
+			Webhooks: []config.Webhook{{Type: TypeSlack, URL: rec.URL}},
+		}, Options{App: "orders"})
+		if err != nil {
+			t.Fatalf("Failed to create notifier: %v", err)
+		}
+
+		n.Observe("", health.StatusHealthy, health.StatusDegraded, "")
+		n.Close()
+
+		payloads := rec.received()
+		if len(payloads) != 1 {
+			t.Fatalf("Expected 1 notification, got %d", len(payloads))
+		}
+		expected := ":large_yellow_circle: [orders] overall status is degraded (was healthy)"
+		if payloads[0]["text"] != expected {
+			t.Errorf("Expected text '%s', got '%v'", expected, payloads[0]["text"])
+		}
+	})
+
+	t.Run("PagerDuty", func(t *testing.T) {
+		rec := newRecorder(t)
+		n, err := New(config.Notifications{
+			Webhooks: []config.Webhook{{Type: TypePagerDuty, URL: rec.URL, RoutingKey: "key"}},
+		}, Options{App: "orders"})
+		if err != nil {
+			t.Fatalf("Failed to create notifier: %v", err)
+		}
+
+		n.Observe("db", health.StatusHealthy, health.StatusUnhealthy, "")
+		n.Close()
+
+		payloads := rec.received()
+		if len(payloads) != 1 {
+			t.Fatalf("Expected 1 notification, got %d", len(payloads))
+		}
+		if payloads[0]["routing_key"] != "key" || payloads[0]["event_action"] != "trigger" {
+			t.Errorf("Unexpected payload %v", payloads[0])
+		}
+		payload, _ := payloads[0]["payload"].(map[string]interface{})
+		if payload["severity"] != "critical" {
+			t.Errorf("Expected severity 'critical', got %v", payload["severity"])
+		}
+	})
+
+	t.Run("InvalidConfig", func(t *testing.T) {
+		if _, err := New(config.Notifications{MinSeverity: "fatal"}, Options{}); err == nil {
+			t.Error("Expected error for unknown minimum severity")
+		}
+		if _, err := New(config.Notifications{Webhooks: []config.Webhook{{Type: "email", URL: "x"}}}, Options{}); err == nil {
+			t.Error("Expected error for unknown webhook type")
+		}
+		if _, err := New(config.Notifications{Webhooks: []config.Webhook{{}}}, Options{}); err == nil {
+			t.Error("Expected error for webhook without URL")
+		}
+	})
+}
@@ -0,0 +1,80 @@
+package notify
+
+import (
+	"fmt"
+
+	"github.com/katalabut/fast-app/health"
+)
+
+// pagerDutyURL is the PagerDuty Events API v2 endpoint used when a pagerduty
+// webhook has no URL.
+const pagerDutyURL = "https://events.pagerduty.com/v2/enqueue"
+
+// summary returns a one-line human readable description of the notification.
+func summary(n Notification) string {
+	subject := "overall status"
+	if n.Check != "" {
+		subject = fmt.Sprintf("check %q", n.Check)
+	}
+
+	s := fmt.Sprintf("%s is %s (was %s)", subject, n.Status, n.PreviousStatus)
+	if n.App != "" {
+		s = fmt.Sprintf("[%s] %s", n.App, s)
+	}
+	if n.Message != "" {
+		s += ": " + n.Message
+	}
+	return s
+}
+
+// slackPayload formats the notification for a Slack incoming webhook.
+func slackPayload(n Notification) map[string]interface{} {
+	emoji := ":large_green_circle:"
+	switch n.Status {
+	case health.StatusDegraded:
+		emoji = ":large_yellow_circle:"
+	case health.StatusUnhealthy:
+		emoji = ":red_circle:"
+	}
+
+	return map[string]interface{}{
+		"text": emoji + " " + summary(n),
+	}
+}
+
+// pagerDutyPayload formats the notification as a PagerDuty Events API v2
+// event. Recoveries resolve the incident opened for the same check.
+func pagerDutyPayload(routingKey string, n Notification) map[string]interface{} {
+	action := "trigger"
+	if n.Status == health.StatusHealthy {
+		action = "resolve"
+	}
+
+	severity := "warning"
+	if n.Status == health.StatusUnhealthy {
+		severity = "critical"
+	}
+
+	source := n.App
+	if source == "" {
+		source = "fastapp"
+	}
+
+	return map[string]interface{}{
+		"routing_key":  routingKey,
+		"event_action": action,
+		"dedup_key":    source + "/" + n.Check,
+		"payload": map[string]interface{}{
+			"summary":   summary(n),
+			"source":    source,
+			"severity":  severity,
+			"timestamp": n.Time,
+			"custom_details": map[string]interface{}{
+				"check":           n.Check,
+				"status":          n.Status,
+				"previous_status": n.PreviousStatus,
+				"message":         n.Message,
+			},
+		},
+	}
+}
@@ -0,0 +1,108 @@
+package health
+
+import "time"
+
+// CheckOptions contains per-check options applied by the Manager
+type CheckOptions struct {
+	// CacheTTL overrides the manager cache TTL for the check.
+	// Zero uses the manager TTL and a negative value disables caching.
+	CacheTTL time.Duration
+
+	// FailureThreshold is the number of consecutive unhealthy results required
+	// before the check is reported unhealthy, like the Kubernetes probe setting.
+	// Until then the previously reported status is kept. Defaults to 1.
+	FailureThreshold int
+	// SuccessThreshold is the number of consecutive results that are not
+	// unhealthy required before an unhealthy check is reported recovered.
+	// Defaults to 1.
+	SuccessThreshold int
+
+	// Tags label the check, e.g. "critical", so that a subset of checks can
+	// be selected with the "tag" query parameter of the health checks endpoint.
+	Tags []string
+	// Labels describe the check, e.g. team=payments or tier=1. Like tags they
+	// are included in the health checks response and select checks with the
+	// "label" query parameter.
+	Labels map[string]string
+
+	// DependsOn names the checks this check depends on, see WithDependsOn.
+	DependsOn []string
+}
+
+// WithCheckOptions returns a checker with options applied by the Manager it is
+// registered with, e.g. a longer cache TTL for an expensive check.
+//
+// Example:
+//
+//	manager.RegisterChecker(health.WithCheckOptions(
+//	    checks.NewHTTPCheck("billing", billingURL),
+//	    health.CheckOptions{CacheTTL: time.Minute, FailureThreshold: 3},
+//	))
+func WithCheckOptions(checker HealthChecker, opts CheckOptions) HealthChecker {
+	return &optionsChecker{HealthChecker: checker, opts: opts}
+}
+
+// optionsChecker is a HealthChecker with per-check options.
+type optionsChecker struct {
+	HealthChecker
+	opts CheckOptions
+}
+
+// checkOptions returns the per-check options of a checker, if any.
+func checkOptions(checker HealthChecker) CheckOptions {
+	if c, ok := checker.(*optionsChecker); ok {
+		return c.opts
+	}
+	return CheckOptions{}
+}
+
+// thresholdState tracks the consecutive results of a check with thresholds.
+type thresholdState struct {
+	status    HealthStatus
+	failures  int
+	successes int
+}
+
+// apply returns the result to report for a check given its failure
+// and success thresholds. A suppressed transition keeps the previously reported
+// status and records the actual one in the details. The first result of a
+// check is reported as is.
+func (s *thresholdState) apply(result HealthResult, opts CheckOptions) HealthResult {
+	failureThreshold := max(opts.FailureThreshold, 1)
+	successThreshold := max(opts.SuccessThreshold, 1)
+
+	if result.Status == StatusUnhealthy {
+		s.failures++
+		s.successes = 0
+	} else {
+		s.successes++
+		s.failures = 0
+	}
+
+	switch {
+	case s.status == "":
+		s.status = result.Status
+		return result
+	case result.Status == StatusUnhealthy && s.status != StatusUnhealthy && s.failures < failureThreshold:
+		return suppress(result, s.status, "consecutive_failures", s.failures)
+	case result.Status != StatusUnhealthy && s.status == StatusUnhealthy && s.successes < successThreshold:
+		return suppress(result, s.status, "consecutive_successes", s.successes)
+	}
+
+	s.status = result.Status
+	return result
+}
+
+// suppress reports a result with the given status instead of its own.
+func suppress(result HealthResult, status HealthStatus, counter string, count int) HealthResult {
+	details := make(map[string]interface{}, len(result.Details)+2)
+	for k, v := range result.Details {
+		details[k] = v
+	}
+	details["suppressed_status"] = result.Status
+	details[counter] = count
+
+	result.Status = status
+	result.Details = details
+	return result
+}
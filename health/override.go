@@ -0,0 +1,92 @@
+package health
+
+import (
+	"context"
+	"time"
+
+	"github.com/katalabut/fast-app/logger"
+)
+
+// Override forces the status of a check, or of the overall status, for a
+// bounded time, e.g. to drain traffic deliberately during a planned failover.
+type Override struct {
+	Status    HealthStatus `json:"status"`
+	Reason    string       `json:"reason"`
+	ExpiresAt time.Time    `json:"expires_at"`
+}
+
+// SetOverride forces the status of a check, or of the overall status if check
+// is empty, until the ttl has elapsed or ClearOverride is called. An
+// overridden check is not run; its result carries the reason as message. The
+// overall override also applies to the readiness probe, but not to liveness.
+func (m *Manager) SetOverride(check string, status HealthStatus, reason string, ttl time.Duration) {
+	m.mu.Lock()
+	m.overrides[check] = Override{
+		Status:    status,
+		Reason:    reason,
+		ExpiresAt: m.clock.Now().Add(ttl),
+	}
+	m.mu.Unlock()
+
+	logger.Warn(context.Background(), "Health status overridden",
+		"check", check, "status", status, "reason", reason, "ttl", ttl.String())
+}
+
+// ClearOverride removes the override of a check, or of the overall status if
+// check is empty.
+func (m *Manager) ClearOverride(check string) {
+	m.mu.Lock()
+	delete(m.overrides, check)
+	m.mu.Unlock()
+
+	logger.Info(context.Background(), "Health status override cleared", "check", check)
+}
+
+// Overrides returns the active overrides by check name. The override of the
+// overall status has an empty name.
+func (m *Manager) Overrides() map[string]Override {
+	now := m.clock.Now()
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	overrides := make(map[string]Override, len(m.overrides))
+	for check, o := range m.overrides {
+		if now.Before(o.ExpiresAt) {
+			overrides[check] = o
+		}
+	}
+	return overrides
+}
+
+// override returns the active override of a check, removing it once expired.
+func (m *Manager) override(check string) (Override, bool) {
+	m.mu.RLock()
+	o, ok := m.overrides[check]
+	m.mu.RUnlock()
+
+	if !ok {
+		return Override{}, false
+	}
+	if !m.clock.Now().Before(o.ExpiresAt) {
+		m.mu.Lock()
+		if current, ok := m.overrides[check]; ok && current == o {
+			delete(m.overrides, check)
+		}
+		m.mu.Unlock()
+		return Override{}, false
+	}
+	return o, true
+}
+
+// result returns the health result reported for an overridden check.
+func (o Override) result() HealthResult {
+	return HealthResult{
+		Status:  o.Status,
+		Message: o.Reason,
+		Details: map[string]interface{}{
+			"override":   true,
+			"expires_at": o.ExpiresAt.UTC().Format(time.RFC3339),
+		},
+	}
+}
@@ -0,0 +1,89 @@
+package health
+
+import "context"
+
+// ProbeGroup is a set of probes that evaluate a check. Checks are added to
+// groups when they are registered, e.g.
+//
+//	manager.RegisterChecker(deadlockCheck, health.ForLiveness())
+//	manager.RegisterChecker(dbCheck, health.ForReadiness(), health.ForStartup())
+//
+// Checks registered without a group only affect readiness.
+type ProbeGroup uint8
+
+const (
+	probeLiveness ProbeGroup = 1 << iota
+	probeReadiness
+	probeStartup
+)
+
+// ForLiveness adds a check to the liveness probe. A failing liveness probe
+// gets the process restarted, so it should only include checks that a restart
+// can fix, such as a deadlock detector, and not external dependencies.
+func ForLiveness() ProbeGroup {
+	return probeLiveness
+}
+
+// ForReadiness adds a check to the readiness probe, which takes the instance
+// out of load balancing while the check is unhealthy.
+func ForReadiness() ProbeGroup {
+	return probeReadiness
+}
+
+// ForStartup adds a check to the startup probe, which holds off the other
+// probes until the application has finished starting.
+func ForStartup() ProbeGroup {
+	return probeStartup
+}
+
+// probeGroups merges groups into one, defaulting to the readiness probe.
+func probeGroups(groups []ProbeGroup) ProbeGroup {
+	var merged ProbeGroup
+	for _, g := range groups {
+		merged |= g
+	}
+	if merged == 0 {
+		merged = probeReadiness
+	}
+	return merged
+}
+
+// CheckGroup runs the registered health checks belonging to the probe group.
+func (m *Manager) CheckGroup(ctx context.Context, group ProbeGroup) map[string]HealthResult {
+	return m.checkAll(ctx, func(name string) bool {
+		return m.groups[name]&group != 0
+	})
+}
+
+// GroupStatus aggregates the results of the checks belonging to the probe
+// group, e.g. from CheckAllWithStatus, without running any check.
+func (m *Manager) GroupStatus(results map[string]HealthResult, group ProbeGroup) HealthStatus {
+	m.mu.RLock()
+	filtered := make(map[string]HealthResult, len(results))
+	for name, result := range results {
+		if m.groups[name]&group != 0 {
+			filtered[name] = result
+		}
+	}
+	m.mu.RUnlock()
+
+	return m.groupStatus(filtered, group)
+}
+
+// GetGroupStatus returns the aggregated health status of the checks belonging
+// to the probe group. Unlike GetOverallStatus it does not notify status listeners.
+func (m *Manager) GetGroupStatus(ctx context.Context, group ProbeGroup) HealthStatus {
+	return m.groupStatus(m.CheckGroup(ctx, group), group)
+}
+
+// groupStatus aggregates the results of a probe group. An override of the
+// overall status applies to readiness, but never to liveness so that a
+// deliberate drain does not get the process restarted.
+func (m *Manager) groupStatus(results map[string]HealthResult, group ProbeGroup) HealthStatus {
+	if group&probeReadiness != 0 {
+		if o, ok := m.override(""); ok {
+			return o.Status
+		}
+	}
+	return m.aggregate(results)
+}
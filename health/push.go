@@ -0,0 +1,106 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/katalabut/fast-app/logger"
+)
+
+// defaultPushStaleAfter is how long a pushed status is reported by default
+// before the check is considered stale.
+const defaultPushStaleAfter = time.Minute
+
+// pushedCheck is a HealthChecker reporting the last status pushed with
+// Manager.SetStatus instead of probing a dependency.
+type pushedCheck struct {
+	name       string
+	staleAfter time.Duration
+	clock      Clock
+
+	mu        sync.Mutex
+	result    HealthResult
+	updatedAt time.Time
+}
+
+func (c *pushedCheck) Name() string {
+	return c.name
+}
+
+// Check returns the last pushed result, or an unhealthy result if no status
+// has been pushed yet or the last one is older than the stale interval.
+func (c *pushedCheck) Check(ctx context.Context) HealthResult {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.updatedAt.IsZero() {
+		return NewUnhealthyResult("no status reported yet")
+	}
+	if age := c.clock.Since(c.updatedAt); c.staleAfter > 0 && age >= c.staleAfter {
+		return NewUnhealthyResult("status not reported for "+age.String()).
+			WithDetails("last_status", c.result.Status).
+			WithDetails("updated_at", c.updatedAt.UTC().Format(time.RFC3339))
+	}
+	return c.result
+}
+
+func (c *pushedCheck) set(result HealthResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.result = result
+	c.updatedAt = c.clock.Now()
+}
+
+// RegisterPushed registers a check whose status is pushed with SetStatus
+// instead of being polled, e.g. by a consumer lag monitor or a connection pool
+// reporting its events. The check is unhealthy until the first status is
+// pushed and once the last one is older than staleAfter; a zero staleAfter
+// uses the manager default and a negative one never considers it stale.
+func (m *Manager) RegisterPushed(name string, staleAfter time.Duration, groups ...ProbeGroup) {
+	m.RegisterChecker(m.newPushedCheck(name, staleAfter), groups...)
+}
+
+func (m *Manager) newPushedCheck(name string, staleAfter time.Duration) HealthChecker {
+	if staleAfter == 0 {
+		staleAfter = m.pushStaleAfter
+	}
+
+	check := &pushedCheck{name: name, staleAfter: staleAfter, clock: m.clock}
+	// Pushed statuses are served as soon as they are set.
+	return WithCheckOptions(check, CheckOptions{CacheTTL: -1})
+}
+
+// SetStatus pushes the status of a check registered with RegisterPushed. A
+// check that is not registered yet is registered in the readiness probe with
+// the default stale interval. Status change listeners are notified at once.
+func (m *Manager) SetStatus(name string, result HealthResult) {
+	m.mu.Lock()
+	checker, registered := m.checkers[name]
+	if !registered {
+		checker = m.newPushedCheck(name, 0)
+		m.register(checker, nil)
+	}
+	m.mu.Unlock()
+
+	check, ok := unwrapChecker(checker).(*pushedCheck)
+	if !ok {
+		logger.Warn(context.Background(), "Ignoring status pushed for a polled health checker", "name", name)
+		return
+	}
+
+	if m.redact != nil {
+		result = m.redact(name, result)
+	}
+	check.set(result)
+	m.observeResult(name, result)
+}
+
+// unwrapChecker returns the checker wrapped with per-check options, if any.
+func unwrapChecker(checker HealthChecker) HealthChecker {
+	if c, ok := checker.(*optionsChecker); ok {
+		return c.HealthChecker
+	}
+	return checker
+}
@@ -0,0 +1,84 @@
+package health
+
+import (
+	"context"
+	"net/url"
+	"testing"
+)
+
+func TestRedactCredentials(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"dial postgres://app:s3cret@db:5432/orders failed", "dial postgres://app:[REDACTED]@db:5432/orders failed"},
+		{"host=db user=app password=s3cret dbname=orders", "host=db user=app password=[REDACTED] dbname=orders"},
+		{"Server=db;Pwd='s3 cret';Database=orders", "Server=db;Pwd=[REDACTED];Database=orders"},
+		{"redis://:s3cret@cache:6379", "redis://:[REDACTED]@cache:6379"},
+		{"GET https://api.example.com/health: 503", "GET https://api.example.com/health: 503"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result := RedactCredentials("db", NewUnhealthyResult(tt.input))
+			if result.Message != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result.Message)
+			}
+		})
+	}
+
+	t.Run("Details", func(t *testing.T) {
+		details := map[string]interface{}{"dsn": "mysql://root:s3cret@db/orders", "port": 3306}
+		result := RedactCredentials("db", HealthResult{Status: StatusHealthy, Details: details})
+
+		if result.Details["dsn"] != "mysql://root:[REDACTED]@db/orders" {
+			t.Errorf("Expected redacted dsn, got %v", result.Details["dsn"])
+		}
+		if result.Details["port"] != 3306 {
+			t.Errorf("Expected port 3306, got %v", result.Details["port"])
+		}
+		if details["dsn"] != "mysql://root:s3cret@db/orders" {
+			t.Error("Expected the original details not to be modified")
+		}
+	})
+}
+
+func TestRedactDetails(t *testing.T) {
+	manager := NewManager(ManagerConfig{Redact: RedactDetails("dsn")})
+	manager.RegisterChecker(&mockChecker{
+		name:   "db",
+		result: NewHealthyResult("ok").WithDetails("dsn", "host=db").WithDetails("latency", "5ms"),
+	})
+
+	result := manager.CheckAll(context.Background())["db"]
+	if result.Details["dsn"] != Redacted {
+		t.Errorf("Expected dsn %s, got %v", Redacted, result.Details["dsn"])
+	}
+	if result.Details["latency"] != "5ms" {
+		t.Errorf("Expected latency '5ms', got %v", result.Details["latency"])
+	}
+	if history := manager.History("db"); len(history) != 1 || history[0].Details["dsn"] != Redacted {
+		t.Errorf("Expected redacted history, got %+v", history)
+	}
+}
+
+func TestWantsVerbose(t *testing.T) {
+	tests := []struct {
+		terse    bool
+		query    string
+		expected bool
+	}{
+		{false, "", true},
+		{true, "", false},
+		{true, "verbose=true", true},
+		{false, "verbose=false", false},
+		{true, "verbose=yes", false},
+	}
+
+	for _, tt := range tests {
+		query, _ := url.ParseQuery(tt.query)
+		if got := WantsVerbose(tt.terse, query); got != tt.expected {
+			t.Errorf("Expected %v for terse=%v and query %q, got %v", tt.expected, tt.terse, tt.query, got)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package health
+
+// Scorer is implemented by aggregation strategies that compute a health score
+// from 0 (every check failing) to 100 (every check healthy), such as
+// strategies.ScoreStrategy.
+type Scorer interface {
+	Score(results map[string]HealthResult) float64
+}
+
+// Score returns the health score of the results if the configured strategy is
+// a Scorer.
+func (m *Manager) Score(results map[string]HealthResult) (float64, bool) {
+	m.mu.RLock()
+	scorer, ok := m.strategy.(Scorer)
+	m.mu.RUnlock()
+
+	if !ok {
+		return 0, false
+	}
+	return scorer.Score(results), true
+}
+
+// LastScore returns the health score computed by the last CheckAllWithStatus,
+// e.g. for metrics, reporting false if the strategy is not a Scorer or no
+// checks have run yet.
+func (m *Manager) LastScore() (float64, bool) {
+	m.listenersMu.Lock()
+	defer m.listenersMu.Unlock()
+	return m.lastScore, m.scored
+}
+
+// observeScore records the health score of the results.
+func (m *Manager) observeScore(results map[string]HealthResult) {
+	score, ok := m.Score(results)
+	if !ok {
+		return
+	}
+
+	m.listenersMu.Lock()
+	m.lastScore = score
+	m.scored = true
+	m.listenersMu.Unlock()
+}
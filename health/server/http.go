@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/katalabut/fast-app/health"
@@ -19,6 +20,16 @@ type Config struct {
 	ReadyPath string        `default:"/health/ready"`
 	CheckPath string        `default:"/health/checks"`
 	Timeout   time.Duration `default:"30s"`
+
+	HistoryPath string `default:"/health/history"`
+	StartupPath string `default:"/health/startup"`
+	Format      string `default:"default"`
+	Terse       bool   `default:"false"`
+
+	// CheckStatusCodes and ReadyStatusCodes override the HTTP status codes of
+	// the checks and readiness endpoints by status, e.g. {"degraded": 218}.
+	CheckStatusCodes map[string]int
+	ReadyStatusCodes map[string]int
 }
 
 // Server provides HTTP endpoints for health checks
@@ -49,10 +60,16 @@ func (s *Server) Start(ctx context.Context) error {
 	mux.HandleFunc(s.config.LivePath, s.handleLiveness)
 	mux.HandleFunc(s.config.ReadyPath, s.handleReadiness)
 	mux.HandleFunc(s.config.CheckPath, s.handleChecks)
+	if s.config.HistoryPath != "" {
+		mux.HandleFunc(s.config.HistoryPath, s.handleHistory)
+	}
+	if s.config.StartupPath != "" {
+		mux.HandleFunc(s.config.StartupPath, s.handleStartup)
+	}
 
 	s.server = &http.Server{
 		Addr:         fmt.Sprintf(":%d", s.config.Port),
-		Handler:      mux,
+		Handler:      logger.RequestIDMiddleware(mux),
 		ReadTimeout:  s.config.Timeout,
 		WriteTimeout: s.config.Timeout,
 		IdleTimeout:  60 * time.Second,
@@ -83,14 +100,51 @@ func (s *Server) Shutdown(ctx context.Context) error {
 
 // handleLiveness handles liveness probe requests
 func (s *Server) handleLiveness(w http.ResponseWriter, r *http.Request) {
-	// Liveness probe should always return 200 if the process is running
+	ctx, cancel := context.WithTimeout(r.Context(), s.config.Timeout)
+	defer cancel()
+
+	// Liveness probe returns 200 if the process is running, unless a
+	// liveness check is unhealthy
+	status := s.manager.GetGroupStatus(ctx, health.ForLiveness())
+
 	response := map[string]interface{}{
 		"status":    "alive",
 		"timestamp": time.Now().UTC().Format(time.RFC3339),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
+
+	if status == health.StatusUnhealthy {
+		response["status"] = status
+		w.WriteHeader(http.StatusServiceUnavailable)
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	json.NewEncoder(w).Encode(response)
+}
+
+// handleStartup handles startup probe requests
+func (s *Server) handleStartup(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), s.config.Timeout)
+	defer cancel()
+
+	status := s.manager.GetGroupStatus(ctx, health.ForStartup())
+
+	response := map[string]interface{}{
+		"status":    status,
+		"started":   status == health.StatusHealthy,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if status == health.StatusHealthy {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+
 	json.NewEncoder(w).Encode(response)
 }
 
@@ -100,61 +154,130 @@ func (s *Server) handleReadiness(w http.ResponseWriter, r *http.Request) {
 	defer cancel()
 
 	isReady := s.manager.IsReady()
-	overallStatus := s.manager.GetOverallStatus(ctx)
+	results, overallStatus := s.manager.CheckAllWithStatus(ctx)
+	status := s.manager.GroupStatus(results, health.ForReadiness())
+
+	maintenance, message := s.manager.Maintenance()
+	readyStatus := status
+	if maintenance {
+		readyStatus = health.StatusMaintenance
+	}
 
-	// Ready if manager says ready AND overall status is not unhealthy
-	ready := isReady && overallStatus != health.StatusUnhealthy
+	// Ready if manager says ready AND the readiness status maps to a success code
+	statusCode := health.ReadyStatusCode(readyStatus, s.config.ReadyStatusCodes)
+	if !isReady && !maintenance {
+		statusCode = http.StatusServiceUnavailable
+	}
+	ready := isReady && statusCode < http.StatusMultipleChoices
 
 	response := map[string]interface{}{
-		"status":         overallStatus,
+		"status":         readyStatus,
 		"ready":          ready,
 		"timestamp":      time.Now().UTC().Format(time.RFC3339),
 		"manager_ready":  isReady,
 		"overall_status": overallStatus,
 	}
+	if maintenance {
+		response["message"] = message
+	}
+	if pending := s.manager.PendingGates(); len(pending) > 0 {
+		response["pending_gates"] = pending
+	}
 
 	w.Header().Set("Content-Type", "application/json")
-	
-	if ready {
-		w.WriteHeader(http.StatusOK)
-	} else {
-		w.WriteHeader(http.StatusServiceUnavailable)
-	}
-	
+	w.WriteHeader(statusCode)
 	json.NewEncoder(w).Encode(response)
 }
 
-// handleChecks handles detailed health checks requests
+// handleChecks handles detailed health checks requests.
+// The "check", "exclude", "tag" and "label" query parameters select a subset of checks,
+// and the "verbose" query parameter overrides the configured terse mode.
 func (s *Server) handleChecks(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), s.config.Timeout)
 	defer cancel()
 
+	filter := health.ParseCheckFilter(r.URL.Query())
+	verbose := health.WantsVerbose(s.config.Terse, r.URL.Query())
+	healthJSON := health.WantsHealthJSON(s.config.Format, r.Header.Get("Accept"))
+	ready := s.manager.IsReady()
+	maintenance, message := s.manager.Maintenance()
+
+	etag := func(status health.HealthStatus, results map[string]health.HealthResult) string {
+		return health.ETag(status, results, strconv.FormatBool(healthJSON), strconv.FormatBool(verbose),
+			strconv.FormatBool(ready), strconv.FormatBool(maintenance), message)
+	}
+
+	// Revalidations are answered from the latest results, without running the checks
+	if r.Header.Get("If-None-Match") != "" {
+		if results, status, ok := s.manager.LatestMatching(filter); ok && health.NotModified(w, r, etag(status, results)) {
+			return
+		}
+	}
+
 	start := time.Now()
-	results := s.manager.CheckAll(ctx)
-	overallStatus := s.manager.GetOverallStatus(ctx)
+	results, overallStatus := s.manager.CheckMatching(ctx, filter)
 	duration := time.Since(start)
+	if health.NotModified(w, r, etag(overallStatus, results)) {
+		return
+	}
+
+	if healthJSON {
+		doc := health.NewHealthJSON(overallStatus, results, time.Now())
+		if !verbose {
+			doc = doc.Terse()
+		}
+
+		w.Header().Set("Content-Type", health.HealthJSONContentType)
+		w.WriteHeader(health.CheckStatusCode(overallStatus, s.config.CheckStatusCodes))
+		json.NewEncoder(w).Encode(doc)
+		return
+	}
 
 	response := map[string]interface{}{
 		"status":    overallStatus,
 		"timestamp": time.Now().UTC().Format(time.RFC3339),
 		"duration":  duration.String(),
-		"checks":    results,
-		"ready":     s.manager.IsReady(),
+		"checks":    s.manager.Reports(results),
+		"ready":     ready,
+	}
+	if !verbose {
+		response["checks"] = health.Statuses(results)
+	}
+	if tags := s.manager.TagSummaries(results); len(tags) > 0 {
+		response["tags"] = tags
+	}
+	if score, ok := s.manager.Score(results); ok {
+		response["score"] = score
+	}
+	if maintenance {
+		response["maintenance"] = map[string]interface{}{"enabled": true, "message": message}
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	
-	// Return appropriate status code based on overall health
-	switch overallStatus {
-	case health.StatusHealthy:
-		w.WriteHeader(http.StatusOK)
-	case health.StatusDegraded:
-		w.WriteHeader(http.StatusOK) // Still OK, but degraded
-	case health.StatusUnhealthy:
-		w.WriteHeader(http.StatusServiceUnavailable)
-	default:
-		w.WriteHeader(http.StatusInternalServerError)
-	}
+	// Return the status code configured for the overall health
+	w.WriteHeader(health.CheckStatusCode(overallStatus, s.config.CheckStatusCodes))
 	
 	json.NewEncoder(w).Encode(response)
 }
+
+// handleHistory handles health check history requests.
+// The "check" query parameter limits the response to a single check.
+func (s *Server) handleHistory(w http.ResponseWriter, r *http.Request) {
+	history := s.manager.AllHistory()
+	if name := r.URL.Query().Get("check"); name != "" {
+		history = map[string][]health.HistoryEntry{name: s.manager.History(name)}
+	}
+	if !health.WantsVerbose(s.config.Terse, r.URL.Query()) {
+		history = health.TerseHistory(history)
+	}
+
+	response := map[string]interface{}{
+		"history":   history,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -129,6 +130,35 @@ func TestServer(t *testing.T) {
 		}
 	})
 
+	t.Run("ReadinessEndpointPendingGates", func(t *testing.T) {
+		config := Config{
+			ReadyPath: "/health/ready",
+			Timeout:   30 * time.Second,
+		}
+		manager := health.NewManager(health.ManagerConfig{})
+		manager.ReadinessGate("cache")
+		server := NewServer(config, manager)
+
+		req := httptest.NewRequest("GET", "/health/ready", nil)
+		w := httptest.NewRecorder()
+
+		server.handleReadiness(w, req)
+
+		if w.Code != http.StatusServiceUnavailable {
+			t.Errorf("Expected status %d, got %d", http.StatusServiceUnavailable, w.Code)
+		}
+
+		var response map[string]interface{}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+
+		pending, _ := response["pending_gates"].([]interface{})
+		if len(pending) != 1 || pending[0] != "cache" {
+			t.Errorf("Expected pending gates [cache], got %v", response["pending_gates"])
+		}
+	})
+
 	t.Run("ChecksEndpointHealthy", func(t *testing.T) {
 		config := Config{
 			CheckPath: "/health/checks",
@@ -266,4 +296,277 @@ func TestServer(t *testing.T) {
 			t.Errorf("Expected Content-Type 'application/json', got '%s'", contentType)
 		}
 	})
+
+	t.Run("HistoryEndpoint", func(t *testing.T) {
+		config := Config{
+			HistoryPath: "/health/history",
+			Timeout:     30 * time.Second,
+		}
+		manager := health.NewManager(health.ManagerConfig{CacheTTL: -1})
+		manager.RegisterChecker(&mockHealthChecker{name: "db", result: health.NewHealthyResult("ok")})
+		manager.RegisterChecker(&mockHealthChecker{name: "cache", result: health.NewHealthyResult("ok")})
+		manager.CheckAll(context.Background())
+		manager.CheckAll(context.Background())
+		server := NewServer(config, manager)
+
+		req := httptest.NewRequest("GET", "/health/history?check=db", nil)
+		w := httptest.NewRecorder()
+
+		server.handleHistory(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+		}
+
+		var response struct {
+			History map[string][]health.HistoryEntry `json:"history"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+
+		if len(response.History) != 1 {
+			t.Errorf("Expected history of 1 check, got %d", len(response.History))
+		}
+		if entries := response.History["db"]; len(entries) != 2 || entries[0].Status != health.StatusHealthy {
+			t.Errorf("Expected 2 healthy entries for db, got %+v", entries)
+		}
+	})
+
+	t.Run("ProbeGroups", func(t *testing.T) {
+		config := Config{Timeout: 30 * time.Second}
+		manager := health.NewManager(health.ManagerConfig{})
+		manager.RegisterChecker(&mockHealthChecker{name: "db", result: health.NewUnhealthyResult("down")})
+		manager.RegisterChecker(&mockHealthChecker{name: "migrations", result: health.NewUnhealthyResult("running")}, health.ForStartup())
+		manager.RegisterChecker(&mockHealthChecker{name: "deadlock", result: health.NewHealthyResult("ok")}, health.ForLiveness())
+		server := NewServer(config, manager)
+
+		// An unhealthy dependency affects readiness and startup, but not liveness.
+		handlers := map[string]struct {
+			handler  http.HandlerFunc
+			expected int
+		}{
+			"live":    {server.handleLiveness, http.StatusOK},
+			"ready":   {server.handleReadiness, http.StatusServiceUnavailable},
+			"startup": {server.handleStartup, http.StatusServiceUnavailable},
+		}
+		for name, h := range handlers {
+			w := httptest.NewRecorder()
+			h.handler(w, httptest.NewRequest("GET", "/health/"+name, nil))
+			if w.Code != h.expected {
+				t.Errorf("Expected %s status %d, got %d", name, h.expected, w.Code)
+			}
+		}
+
+		manager.RegisterChecker(&mockHealthChecker{name: "deadlock", result: health.NewUnhealthyResult("stuck")}, health.ForLiveness())
+		manager.ClearCache()
+
+		w := httptest.NewRecorder()
+		server.handleLiveness(w, httptest.NewRequest("GET", "/health/live", nil))
+		if w.Code != http.StatusServiceUnavailable {
+			t.Errorf("Expected live status %d with an unhealthy liveness check, got %d", http.StatusServiceUnavailable, w.Code)
+		}
+	})
+
+	t.Run("ChecksEndpointHealthJSON", func(t *testing.T) {
+		config := Config{Timeout: 30 * time.Second}
+		manager := health.NewManager(health.ManagerConfig{})
+		manager.RegisterChecker(&mockHealthChecker{name: "db", result: health.NewDegradedResult("slow")})
+		server := NewServer(config, manager)
+
+		req := httptest.NewRequest("GET", "/health/checks", nil)
+		req.Header.Set("Accept", health.HealthJSONContentType)
+		w := httptest.NewRecorder()
+
+		server.handleChecks(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+		}
+		if contentType := w.Header().Get("Content-Type"); contentType != health.HealthJSONContentType {
+			t.Errorf("Expected Content-Type '%s', got '%s'", health.HealthJSONContentType, contentType)
+		}
+
+		var response health.HealthJSON
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		if response.Status != "warn" {
+			t.Errorf("Expected status 'warn', got '%s'", response.Status)
+		}
+		if checks := response.Checks["db:responseTime"]; len(checks) != 1 || checks[0].Output != "slow" {
+			t.Errorf("Expected db:responseTime measurement, got %+v", response.Checks)
+		}
+	})
+
+	t.Run("ChecksRunOncePerRequest", func(t *testing.T) {
+		config := Config{Timeout: 30 * time.Second}
+		manager := health.NewManager(health.ManagerConfig{CacheTTL: -1})
+		var calls int32
+		manager.RegisterChecker(health.NewCustomCheck("db", func(ctx context.Context) health.HealthResult {
+			atomic.AddInt32(&calls, 1)
+			return health.NewHealthyResult("ok")
+		}))
+		server := NewServer(config, manager)
+
+		server.handleChecks(httptest.NewRecorder(), httptest.NewRequest("GET", "/health/checks", nil))
+		server.handleReadiness(httptest.NewRecorder(), httptest.NewRequest("GET", "/health/ready", nil))
+
+		if n := atomic.LoadInt32(&calls); n != 2 {
+			t.Errorf("Expected 1 check per request, got %d checks for 2 requests", n)
+		}
+	})
+
+	t.Run("ChecksEndpointFilter", func(t *testing.T) {
+		config := Config{Timeout: 30 * time.Second}
+		manager := health.NewManager(health.ManagerConfig{})
+		manager.RegisterChecker(health.WithCheckOptions(
+			health.NewCustomCheck("db", func(ctx context.Context) health.HealthResult {
+				return health.NewHealthyResult("ok")
+			}),
+			health.CheckOptions{Tags: []string{"critical"}},
+		))
+		manager.RegisterChecker(health.NewCustomCheck("external-api", func(ctx context.Context) health.HealthResult {
+			return health.NewUnhealthyResult("down")
+		}))
+		server := NewServer(config, manager)
+
+		req := httptest.NewRequest("GET", "/health/checks?tag=critical", nil)
+		w := httptest.NewRecorder()
+
+		server.handleChecks(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+		}
+
+		var response map[string]interface{}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		checks, _ := response["checks"].(map[string]interface{})
+		if len(checks) != 1 || checks["db"] == nil {
+			t.Errorf("Expected only the db check, got %v", checks)
+		}
+	})
+
+	t.Run("ChecksEndpointTerse", func(t *testing.T) {
+		config := Config{Timeout: 30 * time.Second, Terse: true}
+		manager := health.NewManager(health.ManagerConfig{})
+		manager.RegisterChecker(health.NewCustomCheck("db", func(ctx context.Context) health.HealthResult {
+			return health.NewDegradedResult("slow").WithDetails("host", "db.internal")
+		}))
+		server := NewServer(config, manager)
+
+		for query, verbose := range map[string]bool{"": false, "?verbose=true": true} {
+			w := httptest.NewRecorder()
+			server.handleChecks(w, httptest.NewRequest("GET", "/health/checks"+query, nil))
+
+			var response map[string]interface{}
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			checks, _ := response["checks"].(map[string]interface{})
+			db, _ := checks["db"].(map[string]interface{})
+			if db["status"] != "degraded" {
+				t.Errorf("Expected db status 'degraded' for %q, got %v", query, db["status"])
+			}
+			if _, ok := db["details"]; ok != verbose {
+				t.Errorf("Expected details present %v for %q, got %v", verbose, query, db)
+			}
+		}
+	})
+
+	t.Run("StatusCodeMapping", func(t *testing.T) {
+		manager := health.NewManager(health.ManagerConfig{})
+		manager.RegisterChecker(&mockHealthChecker{name: "search", result: health.NewDegradedResult("slow")})
+
+		tests := []struct {
+			name     string
+			config   Config
+			handler  func(*Server) http.HandlerFunc
+			expected int
+		}{
+			{"ChecksDefault", Config{}, func(s *Server) http.HandlerFunc { return s.handleChecks }, http.StatusOK},
+			{"ChecksDegraded", Config{CheckStatusCodes: map[string]int{"degraded": 218}}, func(s *Server) http.HandlerFunc { return s.handleChecks }, 218},
+			{"ReadyDefault", Config{}, func(s *Server) http.HandlerFunc { return s.handleReadiness }, http.StatusServiceUnavailable},
+			{"ReadyDegraded", Config{ReadyStatusCodes: map[string]int{"degraded": http.StatusOK}}, func(s *Server) http.HandlerFunc { return s.handleReadiness }, http.StatusOK},
+			{"InvalidCode", Config{CheckStatusCodes: map[string]int{"degraded": 42}}, func(s *Server) http.HandlerFunc { return s.handleChecks }, http.StatusOK},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				tt.config.Timeout = 30 * time.Second
+				w := httptest.NewRecorder()
+				tt.handler(NewServer(tt.config, manager))(w, httptest.NewRequest("GET", "/", nil))
+
+				if w.Code != tt.expected {
+					t.Errorf("Expected status %d, got %d", tt.expected, w.Code)
+				}
+			})
+		}
+	})
+
+	t.Run("ChecksEndpointETag", func(t *testing.T) {
+		manager := health.NewManager(health.ManagerConfig{})
+		manager.RegisterChecker(&mockHealthChecker{name: "db", result: health.NewHealthyResult("ok")})
+		server := NewServer(Config{Timeout: 30 * time.Second}, manager)
+
+		w := httptest.NewRecorder()
+		server.handleChecks(w, httptest.NewRequest("GET", "/health/checks", nil))
+		etag := w.Header().Get("ETag")
+		if etag == "" {
+			t.Fatal("Expected ETag header to be set")
+		}
+
+		req := httptest.NewRequest("GET", "/health/checks", nil)
+		req.Header.Set("If-None-Match", etag)
+		w = httptest.NewRecorder()
+		server.handleChecks(w, req)
+
+		if w.Code != http.StatusNotModified {
+			t.Errorf("Expected status %d, got %d", http.StatusNotModified, w.Code)
+		}
+		if w.Body.Len() != 0 {
+			t.Errorf("Expected empty body, got %s", w.Body.String())
+		}
+
+		manager.SetMaintenance(true, "migration")
+		w = httptest.NewRecorder()
+		server.handleChecks(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected status %d after a change, got %d", http.StatusOK, w.Code)
+		}
+	})
+	t.Run("ChecksEndpointETagWithoutRunningChecks", func(t *testing.T) {
+		manager := health.NewManager(health.ManagerConfig{CacheTTL: -1})
+		var calls int32
+		manager.RegisterChecker(health.NewCustomCheck("db", func(ctx context.Context) health.HealthResult {
+			atomic.AddInt32(&calls, 1)
+			return health.NewHealthyResult("ok")
+		}))
+		server := NewServer(Config{Timeout: 30 * time.Second}, manager)
+
+		w := httptest.NewRecorder()
+		server.handleChecks(w, httptest.NewRequest("GET", "/health/checks", nil))
+
+		req := httptest.NewRequest("GET", "/health/checks", nil)
+		req.Header.Set("If-None-Match", w.Header().Get("ETag"))
+		w = httptest.NewRecorder()
+		server.handleChecks(w, req)
+
+		if w.Code != http.StatusNotModified {
+			t.Errorf("Expected status %d, got %d", http.StatusNotModified, w.Code)
+		}
+		if n := atomic.LoadInt32(&calls); n != 1 {
+			t.Errorf("Expected checks not to run for a matching revalidation, got %d runs", n)
+		}
+
+		req.Header.Set("If-None-Match", `W/"stale"`)
+		server.handleChecks(httptest.NewRecorder(), req)
+		if n := atomic.LoadInt32(&calls); n != 2 {
+			t.Errorf("Expected checks to run for a stale revalidation, got %d runs", n)
+		}
+	})
 }
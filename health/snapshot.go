@@ -0,0 +1,55 @@
+package health
+
+// Snapshot is the latest known health state of a manager.
+type Snapshot struct {
+	// Status is the overall status aggregated from Results.
+	Status HealthStatus
+	// Results are the latest results of the registered checks. Checks that
+	// have not run yet are missing.
+	Results map[string]HealthResult
+	// Ready is the readiness state, see Manager.IsReady.
+	Ready bool
+	// Maintenance reports whether maintenance mode is enabled.
+	Maintenance bool
+}
+
+// IsHealthy returns true if the overall status is healthy
+func (s Snapshot) IsHealthy() bool {
+	return s.Status == StatusHealthy
+}
+
+// IsDegraded returns true if the overall status is degraded
+func (s Snapshot) IsDegraded() bool {
+	return s.Status == StatusDegraded
+}
+
+// IsUnhealthy returns true if the overall status is unhealthy
+func (s Snapshot) IsUnhealthy() bool {
+	return s.Status == StatusUnhealthy
+}
+
+// Snapshot returns the latest results of the checks and their aggregated
+// status without running any check, so it is cheap enough to be called on
+// every request, e.g. by a middleware shedding load while degraded. Results
+// are refreshed whenever checks run, e.g. by the health endpoints.
+func (m *Manager) Snapshot() Snapshot {
+	m.listenersMu.Lock()
+	results := make(map[string]HealthResult, len(m.lastResults))
+	for name, result := range m.lastResults {
+		results[name] = result
+	}
+	m.listenersMu.Unlock()
+
+	status := m.aggregate(results)
+	if o, ok := m.override(""); ok {
+		status = o.Status
+	}
+	maintenance, _ := m.Maintenance()
+
+	return Snapshot{
+		Status:      status,
+		Results:     results,
+		Ready:       m.IsReady(),
+		Maintenance: maintenance,
+	}
+}
@@ -0,0 +1,46 @@
+package health
+
+import "net/http"
+
+// Default HTTP status codes by health status of the detailed health checks
+// endpoint, where a degraded application still serves, and of the readiness
+// endpoint, where it stops receiving traffic.
+var (
+	defaultCheckStatusCodes = map[HealthStatus]int{
+		StatusHealthy:   http.StatusOK,
+		StatusDegraded:  http.StatusOK,
+		StatusUnhealthy: http.StatusServiceUnavailable,
+	}
+	defaultReadyStatusCodes = map[HealthStatus]int{
+		StatusHealthy:     http.StatusOK,
+		StatusDegraded:    http.StatusServiceUnavailable,
+		StatusUnhealthy:   http.StatusServiceUnavailable,
+		StatusMaintenance: http.StatusServiceUnavailable,
+	}
+)
+
+// CheckStatusCode returns the HTTP status code of the detailed health checks
+// endpoint for a status. Codes maps statuses to configured codes, e.g.
+// {"degraded": 218}; statuses without a valid code use the defaults:
+// 200 for healthy and degraded, 503 otherwise.
+func CheckStatusCode(status HealthStatus, codes map[string]int) int {
+	return statusCode(status, codes, defaultCheckStatusCodes)
+}
+
+// ReadyStatusCode returns the HTTP status code of the readiness endpoint for
+// a status, including StatusMaintenance. Codes maps statuses to configured
+// codes; statuses without a valid code use the defaults: 200 for healthy,
+// 503 otherwise.
+func ReadyStatusCode(status HealthStatus, codes map[string]int) int {
+	return statusCode(status, codes, defaultReadyStatusCodes)
+}
+
+func statusCode(status HealthStatus, codes map[string]int, defaults map[HealthStatus]int) int {
+	if code, ok := codes[string(status)]; ok && code >= 100 && code <= 599 {
+		return code
+	}
+	if code, ok := defaults[status]; ok {
+		return code
+	}
+	return http.StatusServiceUnavailable
+}
@@ -0,0 +1,78 @@
+package strategies
+
+import "github.com/katalabut/fast-app/health"
+
+// Default score thresholds of ScoreStrategy.
+const (
+	DefaultHealthyScore  = 90
+	DefaultDegradedScore = 50
+)
+
+// ScoreStrategy computes a weighted health score from 0 to 100 and maps it to
+// a status, so that a few failing low-weight checks in a large deployment do
+// not dominate the overall status. A healthy check scores 100, a degraded one
+// 50 and an unhealthy one 0; skipped checks are left out.
+type ScoreStrategy struct {
+	// Weights of the checks, 1 for checks without a weight.
+	Weights map[string]float64
+	// HealthyScore is the minimum score reported healthy, DefaultHealthyScore if zero.
+	HealthyScore float64
+	// DegradedScore is the minimum score reported degraded, DefaultDegradedScore if zero.
+	DegradedScore float64
+}
+
+// NewScoreStrategy creates a score strategy with the default thresholds.
+func NewScoreStrategy(weights map[string]float64) *ScoreStrategy {
+	return &ScoreStrategy{
+		Weights: weights,
+	}
+}
+
+// Score returns the weighted health score of the results, 100 if there are none.
+func (s *ScoreStrategy) Score(results map[string]health.HealthResult) float64 {
+	var total, score float64
+
+	for name, result := range results {
+		weight, exists := s.Weights[name]
+		if !exists {
+			weight = 1
+		}
+
+		switch result.Status {
+		case health.StatusHealthy:
+			score += weight * 100
+		case health.StatusDegraded:
+			score += weight * 50
+		case health.StatusUnhealthy:
+		default:
+			continue
+		}
+		total += weight
+	}
+
+	if total == 0 {
+		return 100
+	}
+	return score / total
+}
+
+// Aggregate returns healthy if the score reaches the healthy threshold,
+// degraded if it reaches the degraded threshold and unhealthy otherwise.
+func (s *ScoreStrategy) Aggregate(results map[string]health.HealthResult) health.HealthStatus {
+	healthy, degraded := s.HealthyScore, s.DegradedScore
+	if healthy == 0 {
+		healthy = DefaultHealthyScore
+	}
+	if degraded == 0 {
+		degraded = DefaultDegradedScore
+	}
+
+	switch score := s.Score(results); {
+	case score >= healthy:
+		return health.StatusHealthy
+	case score >= degraded:
+		return health.StatusDegraded
+	default:
+		return health.StatusUnhealthy
+	}
+}
@@ -0,0 +1,51 @@
+package strategies
+
+import (
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/katalabut/fast-app/health"
+)
+
+// Strategy names accepted by New.
+const (
+	AllHealthy = "all_healthy"
+	Majority   = "majority"
+	Weighted   = "weighted"
+	Score      = "score"
+)
+
+// New returns the strategy with the given name. Weights map check names to
+// their importance (critical, important or optional) for the weighted
+// strategy, or to a numeric weight for the score strategy.
+func New(name string, weights map[string]string) (health.AggregationStrategy, error) {
+	switch name {
+	case "", AllHealthy:
+		return &AllHealthyStrategy{}, nil
+	case Majority:
+		return &MajorityHealthyStrategy{}, nil
+	case Weighted:
+		parsed := make(map[string]health.ComponentImportance, len(weights))
+		for check, importance := range weights {
+			i, err := health.ParseImportance(importance)
+			if err != nil {
+				return nil, errors.Wrapf(err, "weight of check %q", check)
+			}
+			parsed[check] = i
+		}
+		return NewWeightedStrategy(parsed), nil
+	case Score:
+		parsed := make(map[string]float64, len(weights))
+		for check, weight := range weights {
+			w, err := strconv.ParseFloat(weight, 64)
+			if err != nil || w < 0 {
+				return nil, errors.Errorf("weight of check %q: invalid score weight %q", check, weight)
+			}
+			parsed[check] = w
+		}
+		return NewScoreStrategy(parsed), nil
+	default:
+		return nil, errors.Errorf("unknown health strategy %q", name)
+	}
+}
@@ -1,6 +1,7 @@
 package strategies
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/katalabut/fast-app/health"
@@ -275,3 +276,108 @@ func TestWeightedStrategy(t *testing.T) {
 		}
 	})
 }
+
+func TestNew(t *testing.T) {
+	t.Run("Names", func(t *testing.T) {
+		for name, want := range map[string]health.AggregationStrategy{
+			"":         &AllHealthyStrategy{},
+			AllHealthy: &AllHealthyStrategy{},
+			Majority:   &MajorityHealthyStrategy{},
+			Weighted:   &WeightedStrategy{},
+			Score:      &ScoreStrategy{},
+		} {
+			strategy, err := New(name, nil)
+			if err != nil {
+				t.Fatalf("Unexpected error for %q: %v", name, err)
+			}
+			if fmt.Sprintf("%T", strategy) != fmt.Sprintf("%T", want) {
+				t.Errorf("Expected %T for %q, got %T", want, name, strategy)
+			}
+		}
+	})
+
+	t.Run("Weights", func(t *testing.T) {
+		strategy, err := New(Weighted, map[string]string{"db": "critical", "cache": "Optional"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		weights := strategy.(*WeightedStrategy).Weights
+		if weights["db"] != health.Critical || weights["cache"] != health.Optional {
+			t.Errorf("Unexpected weights %v", weights)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		if _, err := New("quorum", nil); err == nil {
+			t.Error("Expected error for unknown strategy")
+		}
+		if _, err := New(Weighted, map[string]string{"db": "vital"}); err == nil {
+			t.Error("Expected error for unknown importance")
+		}
+		if _, err := New(Score, map[string]string{"db": "critical"}); err == nil {
+			t.Error("Expected error for non-numeric score weight")
+		}
+	})
+}
+
+func TestScoreStrategy(t *testing.T) {
+	t.Run("EmptyResults", func(t *testing.T) {
+		strategy := NewScoreStrategy(nil)
+		if score := strategy.Score(nil); score != 100 {
+			t.Errorf("Expected score 100 for empty results, got %v", score)
+		}
+	})
+
+	t.Run("Weighted", func(t *testing.T) {
+		strategy := NewScoreStrategy(map[string]float64{"db": 8})
+		results := map[string]health.HealthResult{
+			"db":      health.NewHealthyResult("ok"),
+			"search":  health.NewDegradedResult("slow"),
+			"reports": health.NewUnhealthyResult("failed"),
+		}
+
+		if score := strategy.Score(results); score != 85 {
+			t.Errorf("Expected score 85, got %v", score)
+		}
+		if status := strategy.Aggregate(results); status != health.StatusDegraded {
+			t.Errorf("Expected %s, got %s", health.StatusDegraded, status)
+		}
+	})
+
+	t.Run("Thresholds", func(t *testing.T) {
+		strategy := &ScoreStrategy{HealthyScore: 80, DegradedScore: 60}
+		results := map[string]health.HealthResult{
+			"check1": health.NewHealthyResult("ok"),
+			"check2": health.NewHealthyResult("ok"),
+			"check3": health.NewHealthyResult("ok"),
+			"check4": health.NewHealthyResult("ok"),
+			"check5": health.NewUnhealthyResult("failed"),
+		}
+
+		if status := strategy.Aggregate(results); status != health.StatusHealthy {
+			t.Errorf("Expected %s for score 80, got %s", health.StatusHealthy, status)
+		}
+
+		results["check4"] = health.NewUnhealthyResult("failed")
+		if status := strategy.Aggregate(results); status != health.StatusDegraded {
+			t.Errorf("Expected %s for score 60, got %s", health.StatusDegraded, status)
+		}
+
+		results["check3"] = health.NewUnhealthyResult("failed")
+		if status := strategy.Aggregate(results); status != health.StatusUnhealthy {
+			t.Errorf("Expected %s for score 40, got %s", health.StatusUnhealthy, status)
+		}
+	})
+
+	t.Run("SkippedChecksAreIgnored", func(t *testing.T) {
+		strategy := NewScoreStrategy(nil)
+		results := map[string]health.HealthResult{
+			"db":  health.NewHealthyResult("ok"),
+			"api": {Status: health.StatusSkipped},
+		}
+		if score := strategy.Score(results); score != 100 {
+			t.Errorf("Expected score 100, got %v", score)
+		}
+	})
+}
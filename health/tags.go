@@ -0,0 +1,54 @@
+package health
+
+import "sort"
+
+// TagSummary aggregates the results of the checks sharing a tag or label.
+type TagSummary struct {
+	Status    HealthStatus `json:"status"`
+	Checks    []string     `json:"checks"`
+	Healthy   int          `json:"healthy"`
+	Degraded  int          `json:"degraded"`
+	Unhealthy int          `json:"unhealthy"`
+}
+
+// TagSummaries aggregates the results per tag of their checkers, and per
+// label as "key=value", with the configured strategy. Results of checkers
+// without tags or labels are not summarized.
+func (m *Manager) TagSummaries(results map[string]HealthResult) map[string]TagSummary {
+	m.mu.RLock()
+	groups := make(map[string]map[string]HealthResult)
+	for name, result := range results {
+		opts := checkOptions(m.checkers[name])
+
+		keys := append([]string(nil), opts.Tags...)
+		for key, value := range opts.Labels {
+			keys = append(keys, key+"="+value)
+		}
+		for _, key := range keys {
+			if groups[key] == nil {
+				groups[key] = make(map[string]HealthResult)
+			}
+			groups[key][name] = result
+		}
+	}
+	m.mu.RUnlock()
+
+	summaries := make(map[string]TagSummary, len(groups))
+	for key, group := range groups {
+		summary := TagSummary{Status: m.aggregate(group)}
+		for name, result := range group {
+			summary.Checks = append(summary.Checks, name)
+			switch result.Status {
+			case StatusHealthy:
+				summary.Healthy++
+			case StatusDegraded:
+				summary.Degraded++
+			case StatusUnhealthy:
+				summary.Unhealthy++
+			}
+		}
+		sort.Strings(summary.Checks)
+		summaries[key] = summary
+	}
+	return summaries
+}
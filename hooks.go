@@ -0,0 +1,81 @@
+package fastapp
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// Hook is a callback executed at a well-defined phase of the application lifecycle.
+type Hook func(ctx context.Context) error
+
+// Lifecycle stages at which hooks are executed, used in error messages.
+const (
+	stageBeforeStart    = "before start"
+	stageAfterStart     = "after start"
+	stageBeforeShutdown = "before shutdown"
+	stageAfterShutdown  = "after shutdown"
+)
+
+type hooks struct {
+	beforeStart    []Hook
+	afterStart     []Hook
+	beforeShutdown []Hook
+	afterShutdown  []Hook
+}
+
+// OnBeforeStart registers a hook that runs before the observability server and
+// any service is started, e.g. to warm caches. If a hook returns an error,
+// the application does not start.
+func (a *App) OnBeforeStart(h Hook) *App {
+	a.hooks.beforeStart = append(a.hooks.beforeStart, h)
+	return a
+}
+
+// OnAfterStart registers a hook that runs once every registered service has been started.
+// If a hook returns an error, the application is shut down.
+func (a *App) OnAfterStart(h Hook) *App {
+	a.hooks.afterStart = append(a.hooks.afterStart, h)
+	return a
+}
+
+// OnBeforeShutdown registers a hook that runs when shutdown is initiated,
+// before any service is shut down. Hooks share the shutdown timeout with services;
+// errors are logged and do not prevent the services from being shut down.
+func (a *App) OnBeforeShutdown(h Hook) *App {
+	a.hooks.beforeShutdown = append(a.hooks.beforeShutdown, h)
+	return a
+}
+
+// OnAfterShutdown registers a hook that runs after every service has stopped,
+// e.g. to flush buffers. It receives a context bounded by the shutdown timeout.
+// If a hook returns an error, the application exits with a non-zero code.
+func (a *App) OnAfterShutdown(h Hook) *App {
+	a.hooks.afterShutdown = append(a.hooks.afterShutdown, h)
+	return a
+}
+
+// runHooks executes hooks sequentially and stops at the first error.
+func (a *App) runHooks(ctx context.Context, stage string, hooks []Hook) error {
+	for i, h := range hooks {
+		if err := h(ctx); err != nil {
+			return errors.Wrapf(err, "%s hook #%d failed", stage, i+1)
+		}
+	}
+
+	return nil
+}
+
+// waitAllStarted runs the after start hooks once every service has been started.
+func (a *App) waitAllStarted(ctx context.Context) error {
+	for _, r := range a.runners {
+		select {
+		case <-r.started:
+		case <-r.done():
+		case <-ctx.Done():
+			return nil
+		}
+	}
+
+	return a.runHooks(ctx, stageAfterStart, a.hooks.afterStart)
+}
@@ -0,0 +1,33 @@
+package fastapp
+
+import (
+	"encoding/json"
+	"net/http"
+	"runtime"
+	"time"
+)
+
+// infoPath is the path of the application information endpoint.
+const infoPath = "/info"
+
+// Profile returns the active configuration profile, e.g. "prod", set by
+// configloader.WithProfile, or an empty string without one.
+func (a *App) Profile() string {
+	return a.config.Profile
+}
+
+// handleInfo handles application information requests.
+func (a *App) handleInfo(w http.ResponseWriter, r *http.Request) {
+	response := map[string]interface{}{
+		"app":         a.config.Logger.AppName,
+		"version":     a.opts.version,
+		"profile":     a.config.Profile,
+		"config_hash": a.configHash(),
+		"go_version":  runtime.Version(),
+		"timestamp":   time.Now().UTC().Format(time.RFC3339),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
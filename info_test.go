@@ -0,0 +1,74 @@
+package fastapp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/katalabut/fast-app/configloader"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestInfoEndpoint(t *testing.T) {
+	cfg := Config{}
+	cfg.Logger.AppName = "orders"
+	cfg.Profile = "prod"
+	app := New(cfg, WithVersion("1.2.3"), WithExitFunc(func(int) {}))
+
+	if app.Profile() != "prod" {
+		t.Errorf("Expected profile prod, got %q", app.Profile())
+	}
+
+	rec := httptest.NewRecorder()
+	app.handleInfo(rec, httptest.NewRequest(http.MethodGet, "/info", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+
+	var response map[string]interface{}
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	for key, expected := range map[string]string{"app": "orders", "version": "1.2.3", "profile": "prod"} {
+		if response[key] != expected {
+			t.Errorf("Expected %s %q, got %v", key, expected, response[key])
+		}
+	}
+}
+
+func TestConfigHash(t *testing.T) {
+	t.Run("ApplicationConfig", func(t *testing.T) {
+		app, _, _ := newTestApp()
+		if hash := app.configHash(); hash != configloader.Hash(Config{}) {
+			t.Errorf("Expected the hash of the application configuration, got %s", hash)
+		}
+	})
+
+	t.Run("FullConfig", func(t *testing.T) {
+		cfg := &reloadConfig{Timeout: 5, Token: "secret"}
+		app, _, _ := newTestApp(WithConfig(cfg))
+
+		hash := configloader.Hash(cfg)
+		if app.configHash() != hash {
+			t.Errorf("Expected hash %s, got %s", hash, app.configHash())
+		}
+
+		reg := prometheus.NewRegistry()
+		reg.MustRegister(newServiceCollector(app))
+		if v, ok := gatherMetric(t, reg, "fastapp_config_hash_info", map[string]string{"hash": hash}); !ok || v != 1 {
+			t.Errorf("Expected fastapp_config_hash_info with hash %s, got %v", hash, v)
+		}
+
+		rec := httptest.NewRecorder()
+		app.handleInfo(rec, httptest.NewRequest(http.MethodGet, "/info", nil))
+		var response map[string]interface{}
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if response["config_hash"] != hash {
+			t.Errorf("Expected config_hash %s, got %v", hash, response["config_hash"])
+		}
+	})
+}
@@ -0,0 +1,43 @@
+package fastapp
+
+import (
+	"context"
+)
+
+// Job is a unit of work that runs to completion, e.g. a database migration or seed.
+// Unlike a long-running service, a job returning nil from Run is treated as
+// successfully completed and the application keeps running.
+type Job interface {
+	Run(ctx context.Context) error
+}
+
+// jobService adapts a Job that does not implement Service.
+type jobService struct {
+	Job
+}
+
+// Shutdown does nothing, the job is stopped by cancelling its context.
+func (jobService) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+// AddJob registers a one-shot job with the application. The job is started like
+// any other service and accepts the same options. A job is ready once it has
+// completed successfully, so services depending on it only start after it has
+// finished. If the job fails, the application is shut down.
+//
+// Example:
+//
+//	app.AddJob(migrations, fastapp.WithName("migrations"))
+//	app.Add(api, fastapp.DependsOn("migrations"))
+func (a *App) AddJob(job Job, opts ...ServiceOption) *App {
+	svc, ok := job.(Service)
+	if !ok {
+		svc = jobService{job}
+	}
+
+	r := a.add(svc, job, opts)
+	r.job = true
+
+	return a
+}
@@ -0,0 +1,86 @@
+package fastapp
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type testJob struct {
+	release chan struct{}
+	err     error
+}
+
+func (j *testJob) Run(ctx context.Context) error {
+	select {
+	case <-j.release:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return j.err
+}
+
+func TestJobs(t *testing.T) {
+	t.Run("DependentsWaitForCompletion", func(t *testing.T) {
+		app, cancel, exitCode := newTestApp()
+		job := &testJob{release: make(chan struct{})}
+		api := newTestService()
+
+		app.AddJob(job, WithName("migrations"))
+		app.Add(api, DependsOn("migrations"))
+		stop := startTestApp(t, app, cancel, exitCode)
+
+		select {
+		case <-api.started:
+			t.Fatal("Expected api not to start before migrations completed")
+		case <-time.After(3 * readinessPollInterval):
+		}
+
+		close(job.release)
+		select {
+		case <-api.started:
+		case <-time.After(time.Second):
+			t.Fatal("Expected api to start once migrations completed")
+		}
+
+		info := app.Services()[0]
+		if !info.Job || info.State != StateCompleted {
+			t.Errorf("Expected completed job, got %+v", info)
+		}
+		if state := app.Services()[1].State; state != StateRunning {
+			t.Errorf("Expected api to keep running, got %s", state)
+		}
+
+		if code := stop(); code != exitCodeOk {
+			t.Errorf("Expected exit code %d, got %d", exitCodeOk, code)
+		}
+	})
+
+	t.Run("DefaultName", func(t *testing.T) {
+		app, _, _ := newTestApp()
+		app.AddJob(&testJob{})
+
+		if name := app.runners[0].Name(); name != "testJob" {
+			t.Errorf("Expected name testJob, got %s", name)
+		}
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		app, cancel, exitCode := newTestApp()
+		defer cancel()
+
+		job := &testJob{release: make(chan struct{}), err: errors.New("migration failed")}
+		close(job.release)
+		app.AddJob(job)
+		app.Add(newTestService())
+		app.Start()
+
+		if code := <-exitCode; code != exitCodeApplicationErr {
+			t.Errorf("Expected exit code %d, got %d", exitCodeApplicationErr, code)
+		}
+		if state := app.Services()[0].State; state != StateFailed {
+			t.Errorf("Expected %s, got %s", StateFailed, state)
+		}
+	})
+}
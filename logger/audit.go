@@ -0,0 +1,153 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// AuditConfig contains configuration for the audit log.
+type AuditConfig struct {
+	// Output is where audit events are written: "stdout", "stderr" or a
+	// file path, appended to
+	Output string `default:"stdout"`
+}
+
+// AuditEvent is a compliance-relevant event; all its fields are mandatory.
+type AuditEvent struct {
+	// Actor is who performed the action, e.g. a user or service ID
+	Actor string
+	// Action is what was done, e.g. "user.delete"
+	Action string
+	// Resource is what the action was performed on, e.g. "user/42"
+	Resource string
+	// Outcome is the result of the action, e.g. OutcomeSuccess
+	Outcome string
+}
+
+// Audit event outcomes.
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+	OutcomeDenied  = "denied"
+)
+
+var (
+	// audit writes audit events, separately from the application logs.
+	audit   zapcore.Core
+	auditMu sync.RWMutex
+	// auditClose closes the file opened by InitAudit.
+	auditClose func()
+)
+
+func init() {
+	SetAuditSink(zapcore.AddSync(os.Stdout))
+}
+
+// InitAudit directs audit events to the output of cfg.
+func InitAudit(cfg AuditConfig) error {
+	output := cfg.Output
+	if output == "" {
+		output = "stdout"
+	}
+
+	sink, closeSink, err := zap.Open(output)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log %s: %w", output, err)
+	}
+	setAuditSink(sink, closeSink)
+	return nil
+}
+
+// SetAuditSink directs audit events to sink, e.g. a writer producing them
+// to a message queue topic. Events are JSON objects, one per line.
+func SetAuditSink(sink zapcore.WriteSyncer) {
+	setAuditSink(sink, nil)
+}
+
+// setAuditSink replaces the audit sink, closing the previous one if it was
+// opened by InitAudit.
+func setAuditSink(sink zapcore.WriteSyncer, closeSink func()) {
+	encoder := zapcore.NewJSONEncoder(zapcore.EncoderConfig{
+		TimeKey:        "timestamp",
+		LineEnding:     zapcore.DefaultLineEnding,
+		EncodeTime:     zapcore.ISO8601TimeEncoder,
+		EncodeDuration: zapcore.SecondsDurationEncoder,
+	})
+
+	auditMu.Lock()
+	previous := auditClose
+	audit, auditClose = zapcore.NewCore(encoder, sink, zapcore.DebugLevel), closeSink
+	auditMu.Unlock()
+
+	if previous != nil {
+		previous()
+	}
+}
+
+// Audit writes a compliance-relevant event to the audit log, whatever the
+// log level, with the request and trace IDs of the context and additional
+// key-value pairs. It returns an error if a mandatory field of the event is
+// missing or the event can't be written.
+//
+// Usage:
+//
+//	logger.Audit(ctx, logger.AuditEvent{
+//		Actor:    userID,
+//		Action:   "user.delete",
+//		Resource: "user/42",
+//		Outcome:  logger.OutcomeSuccess,
+//	}, "reason", "GDPR request")
+func Audit(ctx context.Context, event AuditEvent, kvs ...interface{}) error {
+	var missing []string
+	for _, f := range []struct{ name, value string }{
+		{"actor", event.Actor},
+		{"action", event.Action},
+		{"resource", event.Resource},
+		{"outcome", event.Outcome},
+	} {
+		if f.value == "" {
+			missing = append(missing, f.name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("audit event is missing %s", strings.Join(missing, ", "))
+	}
+
+	fields := append(getZapFields(Config{AppName: globalAppName}),
+		zap.String("type", "audit"),
+		zap.String("actor", event.Actor),
+		zap.String("action", event.Action),
+		zap.String("resource", event.Resource),
+		zap.String("outcome", event.Outcome),
+	)
+	if id := RequestID(ctx); id != "" {
+		fields = append(fields, zap.String("request_id", id))
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		fields = append(fields, zap.String("trace_id", sc.TraceID().String()), zap.String("span_id", sc.SpanID().String()))
+	}
+	for i := 0; i+1 < len(kvs); i += 2 {
+		key, ok := kvs[i].(string)
+		if !ok {
+			return fmt.Errorf("invalid audit key %v", kvs[i])
+		}
+		fields = append(fields, zap.Any(key, kvs[i+1]))
+	}
+
+	auditMu.RLock()
+	core := audit
+	auditMu.RUnlock()
+
+	if err := core.Write(zapcore.Entry{Level: zapcore.InfoLevel, Time: time.Now()}, fields); err != nil {
+		return fmt.Errorf("failed to write audit event: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestAudit(t *testing.T) {
+	defer SetAuditSink(zapcore.AddSync(os.Stdout))
+
+	var buf bytes.Buffer
+	SetAuditSink(zapcore.AddSync(&buf))
+
+	event := AuditEvent{Actor: "user-1", Action: "user.delete", Resource: "user/42", Outcome: OutcomeSuccess}
+
+	t.Run("WritesEvent", func(t *testing.T) {
+		buf.Reset()
+		ctx := ContextWithRequestID(context.Background(), "req-1")
+		if err := Audit(ctx, event, "reason", "GDPR request"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		var record map[string]interface{}
+		if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+			t.Fatalf("Expected a JSON record, got %q: %v", buf.String(), err)
+		}
+		expected := map[string]interface{}{
+			"type":       "audit",
+			"actor":      "user-1",
+			"action":     "user.delete",
+			"resource":   "user/42",
+			"outcome":    "success",
+			"request_id": "req-1",
+			"reason":     "GDPR request",
+		}
+		for k, v := range expected {
+			if record[k] != v {
+				t.Errorf("Expected %s=%v, got %v", k, v, record[k])
+			}
+		}
+		if _, ok := record["timestamp"]; !ok {
+			t.Error("Expected a timestamp")
+		}
+	})
+
+	t.Run("MissingFields", func(t *testing.T) {
+		buf.Reset()
+		err := Audit(context.Background(), AuditEvent{Actor: "user-1", Action: "user.delete"})
+		if err == nil || err.Error() != "audit event is missing resource, outcome" {
+			t.Errorf("Expected missing fields error, got %v", err)
+		}
+		if buf.Len() != 0 {
+			t.Errorf("Expected nothing to be written, got %q", buf.String())
+		}
+	})
+
+	t.Run("File", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "audit.log")
+		if err := InitAudit(AuditConfig{Output: path}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if err := Audit(context.Background(), event); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !bytes.Contains(content, []byte(`"action":"user.delete"`)) {
+			t.Errorf("Expected the event in the file, got %q", content)
+		}
+	})
+}
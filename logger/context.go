@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
@@ -13,14 +14,22 @@ type contextTags struct{}
 var loggerContextKey = contextKey{}
 var loggerContextTags = contextTags{}
 
+// contextLogger is the logger stored in a context, with its desugared form
+// for the typed fast path, see InfoF.
+type contextLogger struct {
+	sugared *zap.SugaredLogger
+	base    *zap.Logger
+}
+
 // ToContext returns new context with specified sugared logger inside.
 func ToContext(ctx context.Context, l *zap.SugaredLogger) context.Context {
-	return context.WithValue(ctx, loggerContextKey, l)
+	return context.WithValue(ctx, loggerContextKey, &contextLogger{l, l.Desugar()})
 }
 
 // ContextWithKV returns new context with specified logger with field
 func ContextWithKV(ctx context.Context, kvs ...interface{}) context.Context {
-	l := FromContext(ctx).Desugar()
+	// The trace fields are not stored, FromContext adds those of the current span.
+	l := loggerFrom(ctx).Desugar()
 	result := make([]zap.Field, 0, len(kvs)/2)
 	for i := 0; i < len(kvs); i += 2 {
 		if i == len(kvs)-1 {
@@ -40,6 +49,21 @@ func ContextWithKV(ctx context.Context, kvs ...interface{}) context.Context {
 	return ToContext(ctx, l.With(result...).Sugar())
 }
 
+// WithFields returns a context carrying a child of its logger with the given
+// key-value pairs, included in every entry logged with the context, e.g. the
+// IDs of the user and order being processed. It is an alias of ContextWithKV.
+func WithFields(ctx context.Context, kvs ...interface{}) context.Context {
+	return ContextWithKV(ctx, kvs...)
+}
+
+// IntoContext returns a context carrying a child of its logger with the given
+// typed fields, like WithFields without reflection:
+//
+//	ctx = logger.IntoContext(ctx, logger.String("order_id", id))
+func IntoContext(ctx context.Context, fields ...Field) context.Context {
+	return ToContext(ctx, loggerFrom(ctx).Desugar().With(fields...).Sugar())
+}
+
 func ContextWithTags(ctx context.Context, tags ...string) context.Context {
 	if v, ok := ctx.Value(loggerContextTags).([]string); ok {
 		tags = append(v, tags...)
@@ -49,30 +73,44 @@ func ContextWithTags(ctx context.Context, tags ...string) context.Context {
 }
 
 // FromContext returns logger from context if set. Otherwise returns global `global` logger.
-// In both cases returned logger is populated with `trace_id` & `span_id`.
+// In both cases returned logger is populated with `trace_id` & `span_id` when the
+// context holds a valid OpenTelemetry span context.
 func FromContext(ctx context.Context) *zap.SugaredLogger {
-	l := global
+	l := loggerFrom(ctx)
 
-	if logger, ok := ctx.Value(loggerContextKey).(*zap.SugaredLogger); ok {
-		l = logger
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		l = withTraceID(l, sc.TraceID())
+		l = withSpanID(l, sc.SpanID())
 	}
 
-	//if span, ok := tracer.SpanFromContext(ctx); ok {
-	//	if span.Context() != nil {
-	//		l = withTraceID(l, span.Context().TraceID())
-	//		l = withSpanID(l, span.Context().SpanID())
-	//	}
-	//}
-
 	return l
 }
 
-func withTraceID(l *zap.SugaredLogger, traceId uint64) *zap.SugaredLogger {
-	log := l.With(zap.Uint64("trace_id", traceId))
+// loggerFrom returns the logger set in the context or the global one.
+func loggerFrom(ctx context.Context) *zap.SugaredLogger {
+	if logger, ok := ctx.Value(loggerContextKey).(*contextLogger); ok {
+		return logger.sugared
+	}
+	return Logger()
+}
+
+// baseFrom returns the desugared logger set in the context or the global one.
+func baseFrom(ctx context.Context) *zap.Logger {
+	if logger, ok := ctx.Value(loggerContextKey).(*contextLogger); ok {
+		return logger.base
+	}
+
+	globalGuard.RLock()
+	defer globalGuard.RUnlock()
+	return globalBase
+}
+
+func withTraceID(l *zap.SugaredLogger, traceId trace.TraceID) *zap.SugaredLogger {
+	log := l.With(zap.String("trace_id", traceId.String()))
 	return log
 }
 
-func withSpanID(l *zap.SugaredLogger, spanId uint64) *zap.SugaredLogger {
-	log := l.With(zap.Uint64("span_id", spanId))
+func withSpanID(l *zap.SugaredLogger, spanId trace.SpanID) *zap.SugaredLogger {
+	log := l.With(zap.String("span_id", spanId.String()))
 	return log
 }
@@ -0,0 +1,90 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestFromContextTraceFields(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	ctx := ToContext(context.Background(), zap.New(core).Sugar())
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	traced := trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  spanID,
+	}))
+
+	FromContext(ctx).Info("untraced")
+	FromContext(ContextWithKV(traced, "user", "42")).Info("traced")
+
+	entries := logs.All()
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+	if _, ok := entries[0].ContextMap()["trace_id"]; ok {
+		t.Error("Expected no trace_id without a span")
+	}
+
+	fields := entries[1].ContextMap()
+	if fields["trace_id"] != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("Expected trace_id, got %v", fields["trace_id"])
+	}
+	if fields["span_id"] != "00f067aa0ba902b7" {
+		t.Errorf("Expected span_id, got %v", fields["span_id"])
+	}
+	if fields["user"] != "42" {
+		t.Errorf("Expected user field, got %v", fields["user"])
+	}
+	if n := len(entries[1].Context); n != 3 {
+		t.Errorf("Expected 3 fields without duplicated trace fields, got %d", n)
+	}
+}
+
+func TestIntoContext(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	ctx := ToContext(context.Background(), zap.New(core).Sugar())
+
+	user := WithFields(ctx, "user_id", 42)
+	order := IntoContext(user, String("order_id", "ord-1"))
+
+	Info(order, "paid")
+	Info(user, "logged in")
+	InfoF(order, "shipped")
+
+	entries := logs.All()
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(entries))
+	}
+	for _, i := range []int{0, 2} {
+		fields := entries[i].ContextMap()
+		if fields["user_id"] != int64(42) || fields["order_id"] != "ord-1" {
+			t.Errorf("Expected user_id and order_id on %q, got %v", entries[i].Message, fields)
+		}
+	}
+	if _, ok := entries[1].ContextMap()["order_id"]; ok {
+		t.Error("Expected the parent context to be unchanged")
+	}
+}
+
+func TestDesugar(t *testing.T) {
+	if Desugar() == nil {
+		t.Fatal("Expected the global logger")
+	}
+
+	previous := Logger()
+	defer SetLogger(previous)
+
+	core, logs := observer.New(zap.InfoLevel)
+	SetLogger(zap.New(core).Sugar())
+	Desugar().Info("direct")
+
+	if logs.Len() != 1 {
+		t.Errorf("Expected the entry on the global logger, got %d", logs.Len())
+	}
+}
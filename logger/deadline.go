@@ -0,0 +1,47 @@
+package logger
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type timeoutKey struct{}
+
+// WithTimeout returns context.WithTimeout(parent, timeout), recording the
+// timeout for the errors logged with the context once it is done, see Error.
+func WithTimeout(parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(parent, timeout)
+	return context.WithValue(ctx, timeoutKey{}, timeout), cancel
+}
+
+// contextErrFields returns the fields describing why a context is done,
+// added to the errors logged with it so failures caused by a timeout or
+// cancellation are told apart from others: ctx_err, the cause of the
+// cancellation, deadline_remaining, negative once the deadline has passed,
+// and timeout, the one of WithTimeout. It returns nil while ctx is not done.
+func contextErrFields(ctx context.Context) []zap.Field {
+	if ctx.Err() == nil {
+		return nil
+	}
+
+	fields := []zap.Field{zap.String("ctx_err", context.Cause(ctx).Error())}
+	if deadline, ok := ctx.Deadline(); ok {
+		fields = append(fields, zap.Duration("deadline_remaining", time.Until(deadline)))
+	}
+	if timeout, ok := ctx.Value(timeoutKey{}).(time.Duration); ok {
+		fields = append(fields, zap.Duration("timeout", timeout))
+	}
+	return fields
+}
+
+// errorLogger returns the logger from context with the fields of
+// contextErrFields, for logging errors.
+func errorLogger(ctx context.Context) *zap.SugaredLogger {
+	l := FromContext(ctx)
+	if fields := contextErrFields(ctx); fields != nil {
+		l = l.Desugar().With(fields...).Sugar()
+	}
+	return l
+}
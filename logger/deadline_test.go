@@ -0,0 +1,68 @@
+package logger
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestContextErrFields(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	base := ToContext(context.Background(), zap.New(core).Sugar())
+
+	t.Run("DeadlineExceeded", func(t *testing.T) {
+		logs.TakeAll()
+		ctx, cancel := WithTimeout(base, time.Millisecond)
+		defer cancel()
+		<-ctx.Done()
+
+		ErrorKV(ctx, "Query failed", "table", "users")
+		InfoKV(ctx, "Not an error")
+
+		entries := logs.TakeAll()
+		fields := entries[0].ContextMap()
+		if fields["ctx_err"] != "context deadline exceeded" {
+			t.Errorf("Expected ctx_err, got %v", fields["ctx_err"])
+		}
+		if remaining, ok := fields["deadline_remaining"].(time.Duration); !ok || remaining >= 0 {
+			t.Errorf("Expected a negative deadline_remaining, got %v", fields["deadline_remaining"])
+		}
+		if fields["timeout"] != time.Millisecond {
+			t.Errorf("Expected timeout 1ms, got %v", fields["timeout"])
+		}
+		if _, ok := entries[1].ContextMap()["ctx_err"]; ok {
+			t.Error("Expected no annotations below the error level")
+		}
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		logs.TakeAll()
+		ctx, cancel := context.WithCancelCause(base)
+		cancel(context.Canceled)
+
+		ErrorF(ctx, "Request aborted")
+
+		fields := logs.TakeAll()[0].ContextMap()
+		if fields["ctx_err"] != "context canceled" {
+			t.Errorf("Expected ctx_err, got %v", fields["ctx_err"])
+		}
+		if _, ok := fields["deadline_remaining"]; ok {
+			t.Error("Expected no deadline_remaining without a deadline")
+		}
+	})
+
+	t.Run("NotDone", func(t *testing.T) {
+		logs.TakeAll()
+		ctx, cancel := WithTimeout(base, time.Hour)
+		defer cancel()
+
+		Error(ctx, "Real error")
+
+		if _, ok := logs.TakeAll()[0].ContextMap()["ctx_err"]; ok {
+			t.Error("Expected no annotations while the context is not done")
+		}
+	})
+}
@@ -0,0 +1,123 @@
+package logger
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// WithDedup returns `zap.Option` collapsing identical messages logged again
+// within window of the first one, e.g. by a reconnect loop, into a single
+// "<message> (repeated N times)" entry at the end of the window. Messages are
+// identical with the same level, logger name and message, whatever their
+// fields. Entries more severe than error are never suppressed.
+//
+// Usage:
+//
+//	logger.Logger().Desugar().WithOptions(logger.WithDedup(time.Second)).Sugar()
+func WithDedup(window time.Duration) zap.Option {
+	return zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return NewDedupCore(core, window)
+	})
+}
+
+// NewDedupCore returns a core suppressing the identical messages written to
+// core within window, see WithDedup.
+func NewDedupCore(core zapcore.Core, window time.Duration) zapcore.Core {
+	return &dedupCore{core, &dedupState{window: window, seen: map[dedupKey]*dedupEntry{}}}
+}
+
+type dedupCore struct {
+	zapcore.Core
+	state *dedupState
+}
+
+// dedupState holds the messages written within their window, shared by the
+// cores derived with With.
+type dedupState struct {
+	window time.Duration
+	mu     sync.Mutex
+	seen   map[dedupKey]*dedupEntry
+}
+
+type dedupKey struct {
+	level   zapcore.Level
+	logger  string
+	message string
+}
+
+type dedupEntry struct {
+	// core writes the summary entry.
+	core     zapcore.Core
+	ent      zapcore.Entry
+	repeated int
+	timer    *time.Timer
+}
+
+func (c *dedupCore) With(fields []zapcore.Field) zapcore.Core {
+	return &dedupCore{c.Core.With(fields), c.state}
+}
+
+func (c *dedupCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *dedupCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if ent.Level > zapcore.ErrorLevel || c.state.window <= 0 {
+		return c.Core.Write(ent, fields)
+	}
+
+	key := dedupKey{ent.Level, ent.LoggerName, ent.Message}
+
+	c.state.mu.Lock()
+	if e, ok := c.state.seen[key]; ok {
+		e.repeated++
+		c.state.mu.Unlock()
+		return nil
+	}
+	e := &dedupEntry{core: c.Core, ent: ent}
+	e.timer = time.AfterFunc(c.state.window, func() { c.state.flush(key) })
+	c.state.seen[key] = e
+	c.state.mu.Unlock()
+
+	return c.Core.Write(ent, fields)
+}
+
+func (c *dedupCore) Sync() error {
+	c.state.mu.Lock()
+	keys := make([]dedupKey, 0, len(c.state.seen))
+	for key, e := range c.state.seen {
+		e.timer.Stop()
+		keys = append(keys, key)
+	}
+	c.state.mu.Unlock()
+
+	for _, key := range keys {
+		c.state.flush(key)
+	}
+	return c.Core.Sync()
+}
+
+// flush ends the window of a message, writing how many times it was
+// repeated within it, if it was.
+func (s *dedupState) flush(key dedupKey) {
+	s.mu.Lock()
+	e, ok := s.seen[key]
+	delete(s.seen, key)
+	s.mu.Unlock()
+
+	if !ok || e.repeated == 0 {
+		return
+	}
+
+	ent := e.ent
+	ent.Time = time.Now()
+	ent.Message = fmt.Sprintf("%s (repeated %d times)", e.ent.Message, e.repeated)
+	_ = e.core.Write(ent, []zapcore.Field{zap.Int("repeated", e.repeated)})
+}
@@ -0,0 +1,57 @@
+package logger
+
+import (
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestDedup(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	l := zap.New(core, WithDedup(50*time.Millisecond)).Sugar()
+
+	t.Run("CollapsesRepeated", func(t *testing.T) {
+		for i := 0; i < 5; i++ {
+			l.Warnw("Reconnecting", "attempt", i)
+		}
+		l.Warn("Other")
+
+		entries := logs.TakeAll()
+		if len(entries) != 2 {
+			t.Fatalf("Expected 2 entries within the window, got %d", len(entries))
+		}
+
+		time.Sleep(100 * time.Millisecond)
+		entries = logs.TakeAll()
+		if len(entries) != 1 {
+			t.Fatalf("Expected 1 summary entry, got %d", len(entries))
+		}
+		if entries[0].Message != "Reconnecting (repeated 4 times)" {
+			t.Errorf("Expected summary message, got %q", entries[0].Message)
+		}
+		if entries[0].ContextMap()["repeated"] != int64(4) {
+			t.Errorf("Expected repeated=4, got %v", entries[0].ContextMap()["repeated"])
+		}
+	})
+
+	t.Run("NewWindowAfterFlush", func(t *testing.T) {
+		l.Warn("Reconnecting")
+		if n := len(logs.TakeAll()); n != 1 {
+			t.Errorf("Expected the message to be logged in a new window, got %d entries", n)
+		}
+	})
+
+	t.Run("SyncFlushes", func(t *testing.T) {
+		logs.TakeAll()
+		l.Info("Tick")
+		l.Info("Tick")
+		_ = l.Sync()
+
+		entries := logs.TakeAll()
+		if len(entries) != 2 || entries[1].Message != "Tick (repeated 1 times)" {
+			t.Errorf("Expected entry and summary, got %v", entries)
+		}
+	})
+}
@@ -0,0 +1,102 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+
+	pkgerrors "github.com/pkg/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// ErrorE logs an error message with err recorded as structured fields,
+// instead of a flattened string, so error logs can be queried by type or
+// cause:
+//   - error: the message of err
+//   - error_type: the type of err, e.g. "*fs.PathError"
+//   - error_chain: the type and message of err and each error it wraps
+//   - error_stack: the stack trace of the innermost error created or
+//     wrapped with github.com/pkg/errors, if any
+func ErrorE(ctx context.Context, message string, err error, kvs ...interface{}) {
+	errorLogger(ctx).Errorw(message, append(ErrorFields(err), kvs...)...)
+}
+
+// ErrorFields returns the structured fields of an error logged by ErrorE,
+// e.g. to log it at another level.
+func ErrorFields(err error) []interface{} {
+	if err == nil {
+		return nil
+	}
+
+	var (
+		chain errorChain
+		stack pkgerrors.StackTrace
+	)
+	walkErrors(err, func(e error) {
+		chain = append(chain, e)
+		if st, ok := e.(interface{ StackTrace() pkgerrors.StackTrace }); ok {
+			stack = st.StackTrace()
+		}
+	})
+
+	fields := []interface{}{
+		zap.NamedError("error", structuredError{err}),
+		zap.String("error_type", fmt.Sprintf("%T", err)),
+		zap.Array("error_chain", chain),
+	}
+	if stack != nil {
+		frames := make([]string, 0, len(stack))
+		for _, f := range stack {
+			pc := uintptr(f) - 1
+			if fn := runtime.FuncForPC(pc); fn != nil {
+				file, line := fn.FileLine(pc)
+				frames = append(frames, fmt.Sprintf("%s (%s:%d)", fn.Name(), file, line))
+			}
+		}
+		fields = append(fields, zap.Strings("error_stack", frames))
+	}
+	return fields
+}
+
+// walkErrors calls fn with err and each error it wraps, depth first.
+func walkErrors(err error, fn func(error)) {
+	for err != nil {
+		fn(err)
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, e := range joined.Unwrap() {
+				walkErrors(e, fn)
+			}
+			return
+		}
+		err = errors.Unwrap(err)
+	}
+}
+
+// structuredError hides the fmt.Formatter of an error from zap, which would
+// otherwise log its verbose form, stack trace included, in a flat string.
+type structuredError struct {
+	error
+}
+
+func (e structuredError) Unwrap() error {
+	return e.error
+}
+
+// errorChain encodes the errors of a chain as objects with their type and message.
+type errorChain []error
+
+func (c errorChain) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for _, e := range c {
+		e := e
+		if err := enc.AppendObject(zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+			enc.AddString("type", fmt.Sprintf("%T", e))
+			enc.AddString("message", e.Error())
+			return nil
+		})); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestErrorE(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	ctx := ToContext(context.Background(), zap.New(core).Sugar())
+
+	t.Run("StructuredFields", func(t *testing.T) {
+		logs.TakeAll()
+		err := fmt.Errorf("load config: %w", errors.Wrap(fs.ErrNotExist, "open config.yaml"))
+		ErrorE(ctx, "Failed to start", err, "attempt", 2)
+
+		entries := logs.TakeAll()
+		if len(entries) != 1 {
+			t.Fatalf("Expected 1 entry, got %d", len(entries))
+		}
+		fields := entries[0].ContextMap()
+
+		if fields["error"] != "load config: open config.yaml: file does not exist" {
+			t.Errorf("Expected error message, got %v", fields["error"])
+		}
+		if _, ok := fields["errorVerbose"]; ok {
+			t.Error("Expected no flattened errorVerbose field")
+		}
+		if fields["error_type"] != "*fmt.wrapError" {
+			t.Errorf("Expected error_type *fmt.wrapError, got %v", fields["error_type"])
+		}
+		if fields["attempt"] != int64(2) {
+			t.Errorf("Expected attempt field, got %v", fields["attempt"])
+		}
+
+		chain, ok := fields["error_chain"].([]interface{})
+		if !ok || len(chain) != 4 {
+			t.Fatalf("Expected a chain of 4 errors, got %v", fields["error_chain"])
+		}
+		last := chain[3].(map[string]interface{})
+		if last["type"] != "*errors.errorString" || last["message"] != "file does not exist" {
+			t.Errorf("Expected the root cause last, got %v", last)
+		}
+
+		stack, ok := fields["error_stack"].([]interface{})
+		if !ok || len(stack) == 0 {
+			t.Fatalf("Expected a stack trace, got %v", fields["error_stack"])
+		}
+		if !strings.Contains(stack[0].(string), "TestErrorE") {
+			t.Errorf("Expected the stack to start in the test, got %v", stack[0])
+		}
+	})
+
+	t.Run("NoStack", func(t *testing.T) {
+		logs.TakeAll()
+		ErrorE(ctx, "Failed", fs.ErrPermission)
+
+		fields := logs.TakeAll()[0].ContextMap()
+		if _, ok := fields["error_stack"]; ok {
+			t.Error("Expected no stack trace for an error without one")
+		}
+	})
+}
@@ -0,0 +1,79 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+var (
+	fatalHooks   []func()
+	fatalHandler func(message string)
+	fatalMu      sync.RWMutex
+)
+
+// OnFatal registers a function run when a fatal entry is logged, before the
+// process exits, e.g. to flush buffers or end spans. Functions run in reverse
+// order of registration, like deferred calls; a panic in one is recovered.
+//
+// Hooks run for loggers created by New, which includes the global logger,
+// and for those created with the WithFatalHooks option.
+func OnFatal(f func()) {
+	fatalMu.Lock()
+	defer fatalMu.Unlock()
+	fatalHooks = append(fatalHooks, f)
+}
+
+// SetFatalHandler routes fatal entries to handler instead of exiting the
+// process, e.g. to shut down gracefully. Once the hooks and handler have run,
+// the goroutine that logged the entry is terminated with runtime.Goexit, so
+// the code following the call is never executed, like after os.Exit. A nil
+// handler restores the exit.
+func SetFatalHandler(handler func(message string)) {
+	fatalMu.Lock()
+	defer fatalMu.Unlock()
+	fatalHandler = handler
+}
+
+// WithFatalHooks returns `zap.Option` running the functions registered with
+// OnFatal and the handler set with SetFatalHandler when a fatal entry is
+// logged, for loggers not created by New.
+//
+// Usage:
+//
+//	l := zap.New(core, logger.WithFatalHooks()).Sugar()
+func WithFatalHooks() zap.Option {
+	return zap.WithFatalHook(fatalHook{})
+}
+
+type fatalHook struct{}
+
+func (fatalHook) OnWrite(ce *zapcore.CheckedEntry, _ []zapcore.Field) {
+	fatalMu.RLock()
+	hooks := append([]func(){}, fatalHooks...)
+	handler := fatalHandler
+	fatalMu.RUnlock()
+
+	for i := len(hooks) - 1; i >= 0; i-- {
+		runFatalHook(hooks[i])
+	}
+
+	if handler == nil {
+		os.Exit(1)
+	}
+	handler(ce.Message)
+	runtime.Goexit()
+}
+
+func runFatalHook(f func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Fprintf(os.Stderr, "fatal hook panicked: %v\n", r)
+		}
+	}()
+	f()
+}
@@ -0,0 +1,45 @@
+package logger
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestFatalHandler(t *testing.T) {
+	defer SetFatalHandler(nil)
+
+	var order []string
+	OnFatal(func() { order = append(order, "first") })
+	OnFatal(func() { panic("broken hook") })
+	OnFatal(func() { order = append(order, "last") })
+
+	messages := make(chan string, 1)
+	SetFatalHandler(func(message string) { messages <- message })
+
+	core, logs := observer.New(zap.InfoLevel)
+	l := zap.New(core, WithFatalHooks()).Sugar()
+
+	returned := false
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		l.Fatal("cannot continue")
+		returned = true
+	}()
+	<-done
+
+	if returned {
+		t.Error("Expected Fatal not to return")
+	}
+	if msg := <-messages; msg != "cannot continue" {
+		t.Errorf("Expected handler message 'cannot continue', got %q", msg)
+	}
+	if len(order) != 2 || order[0] != "last" || order[1] != "first" {
+		t.Errorf("Expected hooks in reverse order, got %v", order)
+	}
+	if logs.Len() != 1 {
+		t.Errorf("Expected the fatal entry to be written, got %d entries", logs.Len())
+	}
+}
@@ -0,0 +1,97 @@
+package logger
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Field is a typed log field, logged without the reflection and allocations
+// of the key-value pairs of the sugared API, see InfoF.
+type Field = zap.Field
+
+// String constructs a field with a string value.
+func String(key, val string) Field { return zap.String(key, val) }
+
+// Strings constructs a field with a slice of strings.
+func Strings(key string, val []string) Field { return zap.Strings(key, val) }
+
+// Int constructs a field with an int value.
+func Int(key string, val int) Field { return zap.Int(key, val) }
+
+// Int64 constructs a field with an int64 value.
+func Int64(key string, val int64) Field { return zap.Int64(key, val) }
+
+// Uint64 constructs a field with a uint64 value.
+func Uint64(key string, val uint64) Field { return zap.Uint64(key, val) }
+
+// Float64 constructs a field with a float64 value.
+func Float64(key string, val float64) Field { return zap.Float64(key, val) }
+
+// Bool constructs a field with a bool value.
+func Bool(key string, val bool) Field { return zap.Bool(key, val) }
+
+// Duration constructs a field with a time.Duration value.
+func Duration(key string, val time.Duration) Field { return zap.Duration(key, val) }
+
+// Time constructs a field with a time.Time value.
+func Time(key string, val time.Time) Field { return zap.Time(key, val) }
+
+// Err constructs a field with an error under the "error" key.
+func Err(err error) Field { return zap.Error(err) }
+
+// Any constructs a field with any value, falling back to reflection for
+// types without a dedicated constructor.
+func Any(key string, val interface{}) Field { return zap.Any(key, val) }
+
+// DebugF logs a debug message with typed fields using the logger from context.
+func DebugF(ctx context.Context, message string, fields ...Field) {
+	logF(ctx, zapcore.DebugLevel, message, fields)
+}
+
+// InfoF logs an info message with typed fields using the logger from context,
+// for hot paths where the sugared API shows up in allocation profiles:
+//
+//	logger.InfoF(ctx, "Request handled", logger.String("path", path), logger.Int("status", 200))
+func InfoF(ctx context.Context, message string, fields ...Field) {
+	logF(ctx, zapcore.InfoLevel, message, fields)
+}
+
+// WarnF logs a warning message with typed fields using the logger from context.
+func WarnF(ctx context.Context, message string, fields ...Field) {
+	logF(ctx, zapcore.WarnLevel, message, fields)
+}
+
+// ErrorF logs an error message with typed fields using the logger from context.
+func ErrorF(ctx context.Context, message string, fields ...Field) {
+	logF(ctx, zapcore.ErrorLevel, message, fields)
+}
+
+// FatalF logs a fatal message with typed fields using the logger from context,
+// then exits, see OnFatal.
+func FatalF(ctx context.Context, message string, fields ...Field) {
+	logF(ctx, zapcore.FatalLevel, message, fields)
+}
+
+// logF writes an entry with the desugared logger from context, adding the
+// trace fields FromContext would to the entry rather than to a new logger.
+func logF(ctx context.Context, lvl zapcore.Level, message string, fields []Field) {
+	ce := baseFrom(ctx).Check(lvl, message)
+	if ce == nil {
+		return
+	}
+
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		fields = append(fields[:len(fields):len(fields)],
+			zap.String("trace_id", sc.TraceID().String()),
+			zap.String("span_id", sc.SpanID().String()),
+		)
+	}
+	if lvl >= zapcore.ErrorLevel {
+		fields = append(fields[:len(fields):len(fields)], contextErrFields(ctx)...)
+	}
+	ce.Write(fields...)
+}
@@ -0,0 +1,57 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestInfoF(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	ctx := ToContext(context.Background(), zap.New(core).Sugar().With("service", "api"))
+
+	t.Run("Fields", func(t *testing.T) {
+		logs.TakeAll()
+		traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+		spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+		traced := trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: traceID,
+			SpanID:  spanID,
+		}))
+
+		InfoF(traced, "handled", String("path", "/users"), Int("status", 200))
+
+		entries := logs.TakeAll()
+		if len(entries) != 1 {
+			t.Fatalf("Expected 1 entry, got %d", len(entries))
+		}
+		fields := entries[0].ContextMap()
+		expected := map[string]interface{}{
+			"service":  "api",
+			"path":     "/users",
+			"status":   int64(200),
+			"trace_id": "4bf92f3577b34da6a3ce929d0e0e4736",
+			"span_id":  "00f067aa0ba902b7",
+		}
+		for k, v := range expected {
+			if fields[k] != v {
+				t.Errorf("Expected %s=%v, got %v", k, v, fields[k])
+			}
+		}
+	})
+
+	t.Run("Level", func(t *testing.T) {
+		logs.TakeAll()
+		DebugF(ctx, "hidden", String("k", "v"))
+		WarnF(ctx, "shown")
+
+		entries := logs.TakeAll()
+		if len(entries) != 1 || entries[0].Level != zapcore.WarnLevel {
+			t.Errorf("Expected one warn entry, got %v", entries)
+		}
+	})
+}
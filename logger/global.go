@@ -0,0 +1,83 @@
+package logger
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+var (
+	// globalFields are the fields added with AddGlobalFields, replaced as a
+	// whole so writes read them without locking.
+	globalFields   atomic.Pointer[[]zapcore.Field]
+	globalFieldsMu sync.Mutex
+)
+
+// AddGlobalFields adds key-value pairs to every entry logged from now on by
+// the loggers created by New, the global logger and the loggers derived from
+// it included, without rebuilding them. It is meant for fields known only
+// after startup, e.g. the node name or pod IP from the Downward API. A key
+// added again replaces its value.
+func AddGlobalFields(kvs ...interface{}) {
+	globalFieldsMu.Lock()
+	defer globalFieldsMu.Unlock()
+
+	var fields []zapcore.Field
+	if current := globalFields.Load(); current != nil {
+		fields = append(fields, *current...)
+	}
+
+	for i := 0; i+1 < len(kvs); i += 2 {
+		key, ok := kvs[i].(string)
+		if !ok {
+			Logger().Warnf("invalid global field key %v", kvs[i])
+			continue
+		}
+
+		field := zap.Any(key, kvs[i+1])
+		replaced := false
+		for j := range fields {
+			if fields[j].Key == key {
+				fields[j], replaced = field, true
+				break
+			}
+		}
+		if !replaced {
+			fields = append(fields, field)
+		}
+	}
+
+	globalFields.Store(&fields)
+}
+
+// withGlobalFields returns `zap.Option` adding the global fields to the
+// entries written by a logger.
+func withGlobalFields() zap.Option {
+	return zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &globalFieldsCore{core}
+	})
+}
+
+type globalFieldsCore struct {
+	zapcore.Core
+}
+
+func (c *globalFieldsCore) With(fields []zapcore.Field) zapcore.Core {
+	return &globalFieldsCore{c.Core.With(fields)}
+}
+
+func (c *globalFieldsCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *globalFieldsCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if global := globalFields.Load(); global != nil && len(*global) > 0 {
+		fields = append(fields[:len(fields):len(fields)], *global...)
+	}
+	return c.Core.Write(ent, fields)
+}
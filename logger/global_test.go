@@ -0,0 +1,51 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestAddGlobalFields(t *testing.T) {
+	defer globalFields.Store(nil)
+
+	var buf bytes.Buffer
+	encoder := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	l := zap.New(zapcore.NewCore(encoder, zapcore.AddSync(&buf), zap.InfoLevel), withGlobalFields())
+	child := l.With(zap.String("component", "kafka"))
+
+	child.Info("before")
+	AddGlobalFields("node", "node-1", "region", "eu-west-1")
+	child.Info("after")
+	AddGlobalFields("node", "node-2")
+	l.Info("replaced")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(lines))
+	}
+
+	records := make([]map[string]interface{}, len(lines))
+	for i, line := range lines {
+		if err := json.Unmarshal([]byte(line), &records[i]); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if _, ok := records[0]["node"]; ok {
+		t.Error("Expected no global fields before they are added")
+	}
+	if records[1]["node"] != "node-1" || records[1]["region"] != "eu-west-1" || records[1]["component"] != "kafka" {
+		t.Errorf("Expected global fields on the child logger, got %v", records[1])
+	}
+	if records[2]["node"] != "node-2" || records[2]["region"] != "eu-west-1" {
+		t.Errorf("Expected node to be replaced, got %v", records[2])
+	}
+	if n := strings.Count(lines[2], `"node"`); n != 1 {
+		t.Errorf("Expected node once, got %d times", n)
+	}
+}
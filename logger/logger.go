@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"os"
 	"sync"
+	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -20,8 +21,13 @@ type Config struct {
 
 	// Level sets the minimum log level (debug, info, warn, error, fatal)
 	Level string `default:"info"`
+	// Levels sets the log levels of components, see Named
+	Levels map[string]string
 	// DevMode enables development-friendly console output with colors
 	DevMode bool `default:"false"`
+	// DevPretty, with DevMode, prints fields on aligned lines, with maps and
+	// structs pretty-printed, shortened timestamps and clickable callers
+	DevPretty bool `default:"false"`
 
 	// MessageKey is the JSON key for the log message
 	MessageKey string `default:"message"`
@@ -29,11 +35,25 @@ type Config struct {
 	LevelKey string `default:"severity"`
 	// TimeKey is the JSON key for the timestamp
 	TimeKey string `default:"timestamp"`
+	// Preset maps the JSON keys and levels to those a log backend parses
+	// natively, PresetGCP, PresetAWS or PresetOTel, overriding the keys above
+	Preset string
+	// GCPProject is the project of the trace names of PresetGCP, defaults to
+	// the GOOGLE_CLOUD_PROJECT environment variable
+	GCPProject string
+
+	// DedupWindow collapses repeated messages within it, see WithDedup
+	DedupWindow time.Duration
+	// Sentry forwards error and fatal entries to Sentry when its DSN is set
+	Sentry SentryConfig
+	// Audit configures the audit log, see Audit
+	Audit AuditConfig
 }
 
 var (
 	// global logger instance.
 	global      *zap.SugaredLogger
+	globalBase  *zap.Logger // desugared, for the typed fast path
 	globalGuard sync.RWMutex
 
 	level      = zap.NewAtomicLevelAt(zap.InfoLevel)
@@ -47,6 +67,13 @@ var (
 	}
 
 	globalVersion string
+	// globalAppName is the application name of audit events.
+	globalAppName string
+
+	// revert restores the level changed by SetLevelFor.
+	revert   *time.Timer
+	revertTo zapcore.Level
+	revertMu sync.Mutex
 )
 
 func init() {
@@ -55,20 +82,94 @@ func init() {
 
 // InitLogger initializes the global logger with the given configuration and version.
 // This should be called early in the application startup process.
-// The version will be included in all log entries.
+// The version will be included in all log entries and reported to Sentry as
+// the release, unless cfg.Sentry sets one.
 func InitLogger(cfg Config, version string) (*zap.SugaredLogger, error) {
 	globalVersion = version
+	globalAppName = cfg.AppName
 
-	lvl, err := zapLevelFromString(cfg.Level)
-	if err != nil {
-		return nil, fmt.Errorf("failed to unmurshal log level: %s; err: %v", cfg.Level, err)
+	if err := SetLevel(cfg.Level); err != nil {
+		return nil, err
+	}
+	if err := SetComponentLevels(cfg.Levels); err != nil {
+		return nil, err
+	}
+	if _, err := newPreset(cfg); err != nil {
+		return nil, err
+	}
+	if err := InitAudit(cfg.Audit); err != nil {
+		return nil, err
 	}
 
-	logger := New(lvl, cfg)
+	var options []zap.Option
+	if cfg.Sentry.DSN != "" {
+		if cfg.Sentry.Release == "" {
+			cfg.Sentry.Release = version
+		}
+		core, err := NewSentryCore(cfg.Sentry)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, WithSentry(core))
+	}
+	if cfg.DedupWindow > 0 {
+		// Wraps the Sentry core too, so storms are not reported repeatedly.
+		options = append(options, WithDedup(cfg.DedupWindow))
+	}
+
+	logger := New(level, cfg, options...)
 	SetLogger(logger)
 	return logger, nil
 }
 
+// SetLevel changes the minimum log level of loggers created by InitLogger at runtime.
+// It cancels the revert of a level set with SetLevelFor.
+func SetLevel(newLogLevel string) error {
+	return SetLevelFor(newLogLevel, 0)
+}
+
+// SetLevelFor changes the minimum log level like SetLevel and restores the
+// current level once ttl has elapsed, e.g. to enable debug logging on a live
+// instance temporarily. A zero ttl keeps the new level.
+func SetLevelFor(newLogLevel string, ttl time.Duration) error {
+	lvl, err := zapLevelFromString(newLogLevel)
+	if err != nil {
+		return fmt.Errorf("failed to unmurshal log level: %s; err: %v", newLogLevel, err)
+	}
+
+	revertMu.Lock()
+	defer revertMu.Unlock()
+
+	// A level set temporarily over another temporary one still reverts to
+	// the level before both.
+	previous := level.Level()
+	if revert != nil {
+		revert.Stop()
+		revert = nil
+		previous = revertTo
+	}
+	if ttl > 0 {
+		var timer *time.Timer
+		timer = time.AfterFunc(ttl, func() {
+			revertMu.Lock()
+			defer revertMu.Unlock()
+			if revert == timer {
+				level.SetLevel(revertTo)
+				revert = nil
+			}
+		})
+		revert, revertTo = timer, previous
+	}
+
+	level.SetLevel(lvl.Level())
+	return nil
+}
+
+// Level returns the current minimum log level of loggers created by InitLogger.
+func Level() string {
+	return level.Level().String()
+}
+
 func zapLevelFromString(newLogLevel string) (zap.AtomicLevel, error) {
 	lvl := zap.NewAtomicLevel()
 	err := lvl.UnmarshalText([]byte(newLogLevel))
@@ -81,7 +182,8 @@ func New(lvl zapcore.LevelEnabler, cfg Config, options ...zap.Option) *zap.Sugar
 		lvl = level
 	}
 	sink := zapcore.AddSync(os.Stdout)
-	options = append(options, zap.ErrorOutput(sink))
+	options = append([]zap.Option{WithFatalHooks()}, options...)
+	options = append(options, zap.ErrorOutput(sink), withGlobalFields())
 
 	config := zapcore.EncoderConfig{
 		TimeKey:        cfg.TimeKey,
@@ -96,11 +198,19 @@ func New(lvl zapcore.LevelEnabler, cfg Config, options ...zap.Option) *zap.Sugar
 		EncodeCaller:   zapcore.ShortCallerEncoder,
 	}
 	var encoder zapcore.Encoder
-	if cfg.DevMode {
+	if cfg.DevMode && cfg.DevPretty {
+		encoder = newPrettyEncoder()
+	} else if cfg.DevMode {
 		config.EncodeLevel = zapcore.LowercaseColorLevelEncoder
 		encoder = zapcore.NewConsoleEncoder(config)
 	} else {
 		config.EncodeLevel = zapcore.LowercaseLevelEncoder
+		// Unknown presets are reported by InitLogger.
+		if p, _ := newPreset(cfg); p != nil {
+			p.apply(&config)
+			// Wraps the stdout core only, so the Sentry core gets the usual fields.
+			options = append([]zap.Option{zap.WrapCore(p.wrap)}, options...)
+		}
 		encoder = zapcore.NewJSONEncoder(config)
 	}
 
@@ -128,11 +238,20 @@ func Logger() *zap.SugaredLogger {
 	return global
 }
 
+// Desugar returns the global logger as *zap.Logger, e.g. for libraries that
+// take one. FromContext(ctx).Desugar() returns the logger of a context.
+func Desugar() *zap.Logger {
+	globalGuard.RLock()
+	defer globalGuard.RUnlock()
+	return globalBase
+}
+
 // SetLogger sets global used logger. This function is not thread-safe.
 func SetLogger(l *zap.SugaredLogger) {
 	globalGuard.Lock()
 	defer globalGuard.Unlock()
 	global = l
+	globalBase = l.Desugar()
 }
 
 // Debug logs a debug message using the logger from context.
@@ -173,27 +292,29 @@ func WarnKV(ctx context.Context, message string, kvs ...interface{}) {
 	FromContext(ctx).Warnw(message, kvs...)
 }
 
-// Error logs an error message using the logger from context.
+// Error logs an error message using the logger from context. When the context
+// is done, the entry has the ctx_err, deadline_remaining and timeout fields,
+// see WithTimeout; so do those of the other Error and Fatal functions.
 func Error(ctx context.Context, args ...interface{}) {
-	FromContext(ctx).Error(args...)
+	errorLogger(ctx).Error(args...)
 }
 
 func Errorf(ctx context.Context, format string, args ...interface{}) {
-	FromContext(ctx).Errorf(format, args...)
+	errorLogger(ctx).Errorf(format, args...)
 }
 
 func ErrorKV(ctx context.Context, message string, kvs ...interface{}) {
-	FromContext(ctx).Errorw(message, kvs...)
+	errorLogger(ctx).Errorw(message, kvs...)
 }
 
 func Fatal(ctx context.Context, args ...interface{}) {
-	FromContext(ctx).Fatal(args...)
+	errorLogger(ctx).Fatal(args...)
 }
 
 func Fatalf(ctx context.Context, format string, args ...interface{}) {
-	FromContext(ctx).Fatalf(format, args...)
+	errorLogger(ctx).Fatalf(format, args...)
 }
 
 func FatalKV(ctx context.Context, message string, kvs ...interface{}) {
-	FromContext(ctx).Fatalw(message, kvs...)
+	errorLogger(ctx).Fatalw(message, kvs...)
 }
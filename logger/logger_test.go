@@ -0,0 +1,67 @@
+package logger
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSetLevelFor(t *testing.T) {
+	if err := SetLevel("info"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer SetLevel("info")
+
+	t.Run("Reverts", func(t *testing.T) {
+		if err := SetLevelFor("debug", 50*time.Millisecond); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if Level() != "debug" {
+			t.Errorf("Expected level debug, got %s", Level())
+		}
+
+		waitForLevel(t, "info")
+	})
+
+	t.Run("NestedRevertsToOriginal", func(t *testing.T) {
+		if err := SetLevelFor("debug", time.Hour); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if err := SetLevelFor("warn", 50*time.Millisecond); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		waitForLevel(t, "info")
+	})
+
+	t.Run("SetLevelCancelsRevert", func(t *testing.T) {
+		if err := SetLevelFor("debug", 50*time.Millisecond); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if err := SetLevel("error"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		time.Sleep(100 * time.Millisecond)
+		if Level() != "error" {
+			t.Errorf("Expected level error to be kept, got %s", Level())
+		}
+	})
+
+	t.Run("InvalidLevel", func(t *testing.T) {
+		if err := SetLevelFor("verbose", time.Minute); err == nil {
+			t.Error("Expected error for an invalid level")
+		}
+	})
+}
+
+func waitForLevel(t *testing.T, expected string) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for Level() != expected {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected level %s, got %s", expected, Level())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,112 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+var (
+	// componentLevels holds the levels of components set with SetComponentLevel.
+	componentLevels   = map[string]zap.AtomicLevel{}
+	componentLevelsMu sync.RWMutex
+)
+
+// Named returns the logger from context named after a component, e.g.
+// "kafka", logging at the level set for the component with
+// SetComponentLevel, or at the level of the logger from context if none is.
+// Component levels apply to loggers already returned by Named, so a noisy
+// subsystem can be silenced, or debugged, independently at runtime.
+func Named(ctx context.Context, name string) *zap.SugaredLogger {
+	return FromContext(ctx).Desugar().WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &coreWithLevel{core, componentLevel{name, core}}
+	})).Named(name).Sugar()
+}
+
+// SetComponentLevel sets the minimum log level of the loggers returned by
+// Named for a component. An empty level restores the level of the logger
+// from context.
+func SetComponentLevel(name, newLogLevel string) error {
+	componentLevelsMu.Lock()
+	defer componentLevelsMu.Unlock()
+
+	if newLogLevel == "" {
+		delete(componentLevels, name)
+		return nil
+	}
+
+	lvl, err := zapLevelFromString(newLogLevel)
+	if err != nil {
+		return fmt.Errorf("failed to unmurshal log level: %s; err: %v", newLogLevel, err)
+	}
+	if current, ok := componentLevels[name]; ok {
+		current.SetLevel(lvl.Level())
+		return nil
+	}
+	componentLevels[name] = lvl
+	return nil
+}
+
+// SetComponentLevels replaces the levels of all components, e.g. with
+// {"kafka": "warn", "httpserver": "debug"}.
+func SetComponentLevels(levels map[string]string) error {
+	names := make([]string, 0, len(levels))
+	for name := range levels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, err := zapLevelFromString(levels[name]); err != nil {
+			return fmt.Errorf("invalid log level of component %s: %s", name, levels[name])
+		}
+	}
+
+	componentLevelsMu.Lock()
+	for name := range componentLevels {
+		if _, ok := levels[name]; !ok {
+			delete(componentLevels, name)
+		}
+	}
+	componentLevelsMu.Unlock()
+
+	for _, name := range names {
+		if err := SetComponentLevel(name, levels[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ComponentLevels returns the levels set for components.
+func ComponentLevels() map[string]string {
+	componentLevelsMu.RLock()
+	defer componentLevelsMu.RUnlock()
+
+	levels := make(map[string]string, len(componentLevels))
+	for name, lvl := range componentLevels {
+		levels[name] = lvl.Level().String()
+	}
+	return levels
+}
+
+// componentLevel enables the levels of a component, falling back to those
+// of the core of the logger it was named from.
+type componentLevel struct {
+	name string
+	core zapcore.Core
+}
+
+func (c componentLevel) Enabled(l zapcore.Level) bool {
+	componentLevelsMu.RLock()
+	lvl, ok := componentLevels[c.name]
+	componentLevelsMu.RUnlock()
+
+	if ok {
+		return lvl.Enabled(l)
+	}
+	return c.core.Enabled(l)
+}
@@ -0,0 +1,73 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestNamed(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	ctx := ToContext(context.Background(), zap.New(core).Sugar())
+	defer SetComponentLevels(nil)
+
+	kafka := Named(ctx, "kafka")
+	http := Named(ctx, "httpserver")
+
+	t.Run("DefaultsToContextLevel", func(t *testing.T) {
+		logs.TakeAll()
+		kafka.Debug("hidden")
+		kafka.Info("shown")
+
+		entries := logs.TakeAll()
+		if len(entries) != 1 || entries[0].Message != "shown" {
+			t.Fatalf("Expected only the info entry, got %v", entries)
+		}
+		if entries[0].LoggerName != "kafka" {
+			t.Errorf("Expected logger name kafka, got %q", entries[0].LoggerName)
+		}
+	})
+
+	t.Run("ComponentLevels", func(t *testing.T) {
+		if err := SetComponentLevels(map[string]string{"kafka": "warn", "httpserver": "debug"}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		logs.TakeAll()
+		kafka.Info("silenced")
+		http.Debug("debugged")
+
+		entries := logs.TakeAll()
+		if len(entries) != 1 || entries[0].Message != "debugged" {
+			t.Errorf("Expected only the httpserver debug entry, got %v", entries)
+		}
+
+		levels := ComponentLevels()
+		if levels["kafka"] != "warn" || levels["httpserver"] != "debug" {
+			t.Errorf("Expected component levels, got %v", levels)
+		}
+	})
+
+	t.Run("ResetComponentLevel", func(t *testing.T) {
+		if err := SetComponentLevel("kafka", ""); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		logs.TakeAll()
+		kafka.Info("shown again")
+		if n := logs.Len(); n != 1 {
+			t.Errorf("Expected 1 entry, got %d", n)
+		}
+	})
+
+	t.Run("InvalidLevel", func(t *testing.T) {
+		if err := SetComponentLevels(map[string]string{"kafka": "loud"}); err == nil {
+			t.Error("Expected error for invalid level")
+		}
+		if ComponentLevels()["httpserver"] != "debug" {
+			t.Error("Expected levels to be kept on error")
+		}
+	})
+}
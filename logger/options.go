@@ -7,7 +7,7 @@ import (
 
 type coreWithLevel struct {
 	zapcore.Core
-	level zapcore.Level
+	level zapcore.LevelEnabler
 }
 
 func (c *coreWithLevel) Enabled(l zapcore.Level) bool {
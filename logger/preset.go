@@ -0,0 +1,188 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Log format presets, see Config.Preset.
+const (
+	// PresetGCP formats entries for Google Cloud Logging.
+	PresetGCP = "gcp"
+	// PresetAWS formats entries for CloudWatch Logs, with X-Ray trace IDs.
+	PresetAWS = "aws"
+	// PresetOTel formats entries after the OpenTelemetry log data model.
+	PresetOTel = "otel"
+)
+
+// envGCPProject is the environment variable the project of trace names
+// defaults to with PresetGCP.
+const envGCPProject = "GOOGLE_CLOUD_PROJECT"
+
+// preset maps the keys and values of JSON entries to those a log backend
+// parses natively.
+type preset struct {
+	messageKey  string
+	levelKey    string
+	timeKey     string
+	encodeLevel zapcore.LevelEncoder
+	encodeTime  zapcore.TimeEncoder
+	// traceID and spanID replace the trace_id and span_id fields.
+	traceID func(id string) zap.Field
+	spanID  func(id string) zap.Field
+	// fields are added to each entry.
+	fields func(ent zapcore.Entry) []zapcore.Field
+}
+
+var gcpSeverities = map[zapcore.Level]string{
+	zapcore.DebugLevel:  "DEBUG",
+	zapcore.InfoLevel:   "INFO",
+	zapcore.WarnLevel:   "WARNING",
+	zapcore.ErrorLevel:  "ERROR",
+	zapcore.DPanicLevel: "CRITICAL",
+	zapcore.PanicLevel:  "ALERT",
+	zapcore.FatalLevel:  "EMERGENCY",
+}
+
+var otelSeverityNumbers = map[zapcore.Level]int{
+	zapcore.DebugLevel:  5,
+	zapcore.InfoLevel:   9,
+	zapcore.WarnLevel:   13,
+	zapcore.ErrorLevel:  17,
+	zapcore.DPanicLevel: 18,
+	zapcore.PanicLevel:  21,
+	zapcore.FatalLevel:  21,
+}
+
+// newPreset returns the preset of a name, nil for an empty one.
+func newPreset(cfg Config) (*preset, error) {
+	switch cfg.Preset {
+	case "":
+		return nil, nil
+	case PresetGCP:
+		project := cfg.GCPProject
+		if project == "" {
+			project = os.Getenv(envGCPProject)
+		}
+		return &preset{
+			messageKey: "message",
+			levelKey:   "severity",
+			timeKey:    "time",
+			encodeLevel: func(l zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+				enc.AppendString(gcpSeverities[l])
+			},
+			encodeTime: zapcore.RFC3339NanoTimeEncoder,
+			traceID: func(id string) zap.Field {
+				if project != "" {
+					id = "projects/" + project + "/traces/" + id
+				}
+				return zap.String("logging.googleapis.com/trace", id)
+			},
+			spanID: func(id string) zap.Field {
+				return zap.String("logging.googleapis.com/spanId", id)
+			},
+		}, nil
+	case PresetAWS:
+		return &preset{
+			messageKey:  "message",
+			levelKey:    "level",
+			timeKey:     "timestamp",
+			encodeLevel: zapcore.CapitalLevelEncoder,
+			encodeTime:  zapcore.RFC3339NanoTimeEncoder,
+			traceID: func(id string) zap.Field {
+				// X-Ray trace IDs are W3C ones split after the timestamp.
+				if len(id) == 32 {
+					id = "1-" + id[:8] + "-" + id[8:]
+				}
+				return zap.String("xray_trace_id", id)
+			},
+			spanID: func(id string) zap.Field {
+				return zap.String("span_id", id)
+			},
+		}, nil
+	case PresetOTel:
+		return &preset{
+			messageKey:  "Body",
+			levelKey:    "SeverityText",
+			timeKey:     "Timestamp",
+			encodeLevel: zapcore.CapitalLevelEncoder,
+			encodeTime:  zapcore.EpochNanosTimeEncoder,
+			traceID: func(id string) zap.Field {
+				return zap.String("TraceId", id)
+			},
+			spanID: func(id string) zap.Field {
+				return zap.String("SpanId", id)
+			},
+			fields: func(ent zapcore.Entry) []zapcore.Field {
+				return []zapcore.Field{zap.Int("SeverityNumber", otelSeverityNumbers[ent.Level])}
+			},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown log preset %q, expected %s", cfg.Preset, strings.Join([]string{PresetGCP, PresetAWS, PresetOTel}, ", "))
+	}
+}
+
+// apply sets the keys and encoders of the preset.
+func (p *preset) apply(config *zapcore.EncoderConfig) {
+	config.MessageKey = p.messageKey
+	config.LevelKey = p.levelKey
+	config.TimeKey = p.timeKey
+	config.EncodeLevel = p.encodeLevel
+	config.EncodeTime = p.encodeTime
+}
+
+// wrap returns a core writing the fields of the preset.
+func (p *preset) wrap(core zapcore.Core) zapcore.Core {
+	return &presetCore{core, p}
+}
+
+type presetCore struct {
+	zapcore.Core
+	preset *preset
+}
+
+func (c *presetCore) With(fields []zapcore.Field) zapcore.Core {
+	return &presetCore{c.Core.With(c.preset.rename(fields)), c.preset}
+}
+
+func (c *presetCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *presetCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	fields = c.preset.rename(fields)
+	if c.preset.fields != nil {
+		fields = append(c.preset.fields(ent), fields...)
+	}
+	return c.Core.Write(ent, fields)
+}
+
+// rename replaces the trace fields added by FromContext with those of the
+// preset, copying fields if any is replaced.
+func (p *preset) rename(fields []zapcore.Field) []zapcore.Field {
+	var renamed []zapcore.Field
+	for i, f := range fields {
+		if f.Type != zapcore.StringType || (f.Key != "trace_id" && f.Key != "span_id") {
+			continue
+		}
+		if renamed == nil {
+			renamed = append([]zapcore.Field{}, fields...)
+		}
+		if f.Key == "trace_id" {
+			renamed[i] = p.traceID(f.String)
+		} else {
+			renamed[i] = p.spanID(f.String)
+		}
+	}
+	if renamed == nil {
+		return fields
+	}
+	return renamed
+}
@@ -0,0 +1,129 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap/zapcore"
+)
+
+// newFileLogger returns a logger created by New writing to a file instead of
+// stdout, and a function returning the entries written, decoded.
+func newFileLogger(t *testing.T, cfg Config) (context.Context, func() []map[string]interface{}) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "log.json")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	t.Cleanup(func() { f.Close() })
+
+	stdout := os.Stdout
+	os.Stdout = f
+	l := New(zapcore.DebugLevel, cfg)
+	os.Stdout = stdout
+
+	return ToContext(context.Background(), l), func() []map[string]interface{} {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		var records []map[string]interface{}
+		dec := json.NewDecoder(bytes.NewReader(content))
+		for dec.More() {
+			var record map[string]interface{}
+			if err := dec.Decode(&record); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			records = append(records, record)
+		}
+		return records
+	}
+}
+
+func TestPresets(t *testing.T) {
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	traced := func(ctx context.Context) context.Context {
+		return trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: traceID,
+			SpanID:  spanID,
+		}))
+	}
+
+	tests := []struct {
+		name     string
+		cfg      Config
+		expected map[string]interface{}
+		absent   []string
+	}{
+		{
+			name: "GCP",
+			cfg:  Config{Preset: PresetGCP, GCPProject: "my-project"},
+			expected: map[string]interface{}{
+				"message":                       "disk full",
+				"severity":                      "WARNING",
+				"logging.googleapis.com/trace":  "projects/my-project/traces/4bf92f3577b34da6a3ce929d0e0e4736",
+				"logging.googleapis.com/spanId": "00f067aa0ba902b7",
+			},
+			absent: []string{"trace_id", "span_id"},
+		},
+		{
+			name: "AWS",
+			cfg:  Config{Preset: PresetAWS},
+			expected: map[string]interface{}{
+				"message":       "disk full",
+				"level":         "WARN",
+				"xray_trace_id": "1-4bf92f35-77b34da6a3ce929d0e0e4736",
+				"span_id":       "00f067aa0ba902b7",
+			},
+			absent: []string{"trace_id"},
+		},
+		{
+			name: "OTel",
+			cfg:  Config{Preset: PresetOTel},
+			expected: map[string]interface{}{
+				"Body":           "disk full",
+				"SeverityText":   "WARN",
+				"SeverityNumber": float64(13),
+				"TraceId":        "4bf92f3577b34da6a3ce929d0e0e4736",
+				"SpanId":         "00f067aa0ba902b7",
+			},
+			absent: []string{"trace_id", "span_id"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, records := newFileLogger(t, tt.cfg)
+			Warn(traced(ctx), "disk full")
+
+			entries := records()
+			if len(entries) != 1 {
+				t.Fatalf("Expected 1 entry, got %d", len(entries))
+			}
+			for k, v := range tt.expected {
+				if entries[0][k] != v {
+					t.Errorf("Expected %s=%v, got %v", k, v, entries[0][k])
+				}
+			}
+			for _, k := range tt.absent {
+				if _, ok := entries[0][k]; ok {
+					t.Errorf("Expected no %s field", k)
+				}
+			}
+		})
+	}
+
+	t.Run("Unknown", func(t *testing.T) {
+		if _, err := InitLogger(Config{Level: "info", Preset: "azure"}, ""); err == nil {
+			t.Error("Expected error for unknown preset")
+		}
+	})
+}
@@ -0,0 +1,172 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// prettyTimeLayout is the shortened timestamp of pretty console entries.
+const prettyTimeLayout = "15:04:05.000"
+
+var (
+	prettyPool = buffer.NewPool()
+
+	prettyLevelColors = map[zapcore.Level]string{
+		zapcore.DebugLevel:  "\x1b[35m",
+		zapcore.InfoLevel:   "\x1b[34m",
+		zapcore.WarnLevel:   "\x1b[33m",
+		zapcore.ErrorLevel:  "\x1b[31m",
+		zapcore.DPanicLevel: "\x1b[31m",
+		zapcore.PanicLevel:  "\x1b[31m",
+		zapcore.FatalLevel:  "\x1b[31m",
+	}
+)
+
+const (
+	prettyReset = "\x1b[0m"
+	prettyDim   = "\x1b[2m"
+)
+
+// prettyEncoder encodes entries for reading in a terminal, see Config.DevPretty:
+// a line with the time, the colored level, the message and the caller,
+// followed by a line per field, sorted by key and aligned, with maps, structs
+// and slices pretty-printed over several lines.
+type prettyEncoder struct {
+	*zapcore.MapObjectEncoder
+}
+
+func newPrettyEncoder() zapcore.Encoder {
+	return &prettyEncoder{zapcore.NewMapObjectEncoder()}
+}
+
+func (e *prettyEncoder) Clone() zapcore.Encoder {
+	clone := zapcore.NewMapObjectEncoder()
+	for k, v := range e.Fields {
+		clone.Fields[k] = v
+	}
+	return &prettyEncoder{clone}
+}
+
+func (e *prettyEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	enc := e.Clone().(*prettyEncoder)
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+
+	buf := prettyPool.Get()
+	buf.AppendString(prettyDim)
+	buf.AppendString(ent.Time.Format(prettyTimeLayout))
+	buf.AppendString(prettyReset)
+	buf.AppendByte(' ')
+	buf.AppendString(prettyLevelColors[ent.Level])
+	buf.AppendString(fmt.Sprintf("%-5s", ent.Level.CapitalString()))
+	buf.AppendString(prettyReset)
+	buf.AppendByte(' ')
+	if ent.LoggerName != "" {
+		buf.AppendString(ent.LoggerName)
+		buf.AppendString(": ")
+	}
+	buf.AppendString(ent.Message)
+	if caller := prettyCaller(); caller != "" {
+		// The absolute path and line are opened by IDEs and terminals on click.
+		buf.AppendString(prettyDim)
+		buf.AppendString("  ")
+		buf.AppendString(caller)
+		buf.AppendString(prettyReset)
+	}
+	buf.AppendByte('\n')
+
+	keys := make([]string, 0, len(enc.Fields))
+	width := 0
+	for k := range enc.Fields {
+		keys = append(keys, k)
+		if len(k) > width {
+			width = len(k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		buf.AppendString("    ")
+		buf.AppendString(prettyDim)
+		buf.AppendString(fmt.Sprintf("%-*s", width, k))
+		buf.AppendString(prettyReset)
+		buf.AppendString("  ")
+		buf.AppendString(prettyValue(enc.Fields[k], width+6))
+		buf.AppendByte('\n')
+	}
+
+	if ent.Stack != "" {
+		buf.AppendString(ent.Stack)
+		buf.AppendByte('\n')
+	}
+	return buf, nil
+}
+
+// prettyCallerSkipped are the prefixes of the functions between the caller
+// and the encoder, those of the logging packages.
+var prettyCallerSkipped = []string{
+	"go.uber.org/zap",
+	"github.com/katalabut/fast-app/logger.",
+	"log/slog.",
+	"runtime.",
+}
+
+// prettyCaller returns the file and line of the function that logged the
+// entry being encoded, outside of zap and this package, whose functions
+// would otherwise be reported by zap.AddCaller.
+func prettyCaller() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		skipped := false
+		for _, prefix := range prettyCallerSkipped {
+			if strings.HasPrefix(frame.Function, prefix) {
+				skipped = true
+				break
+			}
+		}
+		if !skipped {
+			return fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// prettyValue formats a field value, indenting the lines after the first
+// of multi-line values by indent spaces.
+func prettyValue(v interface{}, indent int) string {
+	var s string
+	switch v := v.(type) {
+	case string:
+		s = v
+	case time.Time:
+		s = v.Format(time.RFC3339Nano)
+	case time.Duration:
+		s = v.String()
+	case fmt.Stringer:
+		s = v.String()
+	case error:
+		s = v.Error()
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, uintptr, float32, float64, complex64, complex128:
+		s = fmt.Sprint(v)
+	default:
+		b, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			s = fmt.Sprintf("%+v", v)
+		} else {
+			s = string(b)
+		}
+	}
+	return strings.ReplaceAll(s, "\n", "\n"+strings.Repeat(" ", indent))
+}
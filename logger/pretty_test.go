@@ -0,0 +1,66 @@
+package logger
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestPrettyEncoder(t *testing.T) {
+	enc := newPrettyEncoder()
+	enc.AddString("application_name", "app")
+
+	ent := zapcore.Entry{
+		Level:   zapcore.WarnLevel,
+		Time:    time.Date(2024, 1, 2, 15, 4, 5, 123e6, time.UTC),
+		Message: "Slow request",
+	}
+	buf, err := enc.EncodeEntry(ent, []zapcore.Field{
+		zap.Int("status", 200),
+		zap.Any("headers", map[string]string{"Accept": "application/json"}),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	out := buf.String()
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+
+	t.Run("Header", func(t *testing.T) {
+		header := lines[0]
+		for _, expected := range []string{"15:04:05.123", "WARN", "Slow request", ".go:"} {
+			if !strings.Contains(header, expected) {
+				t.Errorf("Expected %q in header %q", expected, header)
+			}
+		}
+		if strings.Contains(header, "2024") {
+			t.Errorf("Expected a shortened timestamp, got %q", header)
+		}
+	})
+
+	t.Run("AlignedFields", func(t *testing.T) {
+		if len(lines) != 6 {
+			t.Fatalf("Expected 6 lines, got %d:\n%s", len(lines), out)
+		}
+		// Fields are sorted, the map spans lines 2 to 4.
+		column := -1
+		for key, line := range map[string]string{"application_name": lines[1], "headers": lines[2], "status": lines[5]} {
+			if !strings.Contains(line, key) {
+				t.Fatalf("Expected field %s on line %q", key, line)
+			}
+			value := strings.Index(line, prettyReset+"  ") + len(prettyReset+"  ")
+			if column >= 0 && value != column {
+				t.Errorf("Expected values in the same column, got\n%s", out)
+			}
+			column = value
+		}
+	})
+
+	t.Run("PrettyPrintsMaps", func(t *testing.T) {
+		if !strings.Contains(out, "{\n") || !strings.Contains(lines[3], `"Accept": "application/json"`) {
+			t.Errorf("Expected the map over several lines, got\n%s", out)
+		}
+	})
+}
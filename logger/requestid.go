@@ -0,0 +1,84 @@
+package logger
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// RequestIDHeader is the HTTP header carrying the request ID.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength is the length above which a request ID received in a
+// header is replaced, so clients can't flood the logs.
+const maxRequestIDLength = 128
+
+type requestIDKey struct{}
+
+// WithRequestID returns a context with a new request ID, unless ctx already
+// holds one, whose logger includes it in all log entries as `request_id`.
+func WithRequestID(ctx context.Context) context.Context {
+	if RequestID(ctx) != "" {
+		return ctx
+	}
+	return ContextWithRequestID(ctx, NewRequestID())
+}
+
+// ContextWithRequestID returns a context with the given request ID, e.g. one
+// received from another service, whose logger includes it in all log entries.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	ctx = context.WithValue(ctx, requestIDKey{}, id)
+	return ContextWithKV(ctx, "request_id", id)
+}
+
+// RequestID returns the request ID of the context, or an empty string.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// NewRequestID returns a random 128-bit request ID, hex-encoded.
+func NewRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// RequestIDMiddleware returns a handler storing the ID of each request, the
+// X-Request-ID header or a new one, in its context, so the log entries of the
+// request share it, and setting it on the response. Handlers propagate it to
+// other services with SetRequestIDHeader.
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID(id) {
+			id = NewRequestID()
+		}
+
+		w.Header().Set(RequestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(ContextWithRequestID(r.Context(), id)))
+	})
+}
+
+// SetRequestIDHeader sets the X-Request-ID header of an outgoing request to
+// the request ID of the context, if any.
+func SetRequestIDHeader(ctx context.Context, header http.Header) {
+	if id := RequestID(ctx); id != "" {
+		header.Set(RequestIDHeader, id)
+	}
+}
+
+// validRequestID reports whether a received request ID is safe to log: not
+// empty, not too long and made of printable ASCII characters.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
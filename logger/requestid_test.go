@@ -0,0 +1,75 @@
+package logger
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestWithRequestID(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	ctx := WithRequestID(ToContext(context.Background(), zap.New(core).Sugar()))
+
+	id := RequestID(ctx)
+	if len(id) != 32 {
+		t.Fatalf("Expected a 32 character request ID, got %q", id)
+	}
+	if again := RequestID(WithRequestID(ctx)); again != id {
+		t.Errorf("Expected request ID %s to be kept, got %s", id, again)
+	}
+
+	Info(ctx, "handled")
+	if got := logs.All()[0].ContextMap()["request_id"]; got != id {
+		t.Errorf("Expected request_id %s, got %v", id, got)
+	}
+
+	header := http.Header{}
+	SetRequestIDHeader(ctx, header)
+	if header.Get(RequestIDHeader) != id {
+		t.Errorf("Expected header %s, got %q", id, header.Get(RequestIDHeader))
+	}
+}
+
+func TestRequestIDMiddleware(t *testing.T) {
+	var got string
+	handler := RequestIDMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = RequestID(r.Context())
+	}))
+
+	tests := []struct {
+		name     string
+		header   string
+		expected string
+	}{
+		{"Propagated", "abc-123", "abc-123"},
+		{"Generated", "", ""},
+		{"TooLong", strings.Repeat("a", 129), ""},
+		{"NotPrintable", "abc\n123", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set(RequestIDHeader, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if tt.expected != "" && got != tt.expected {
+				t.Errorf("Expected request ID %q, got %q", tt.expected, got)
+			}
+			if tt.expected == "" && (len(got) != 32 || got == tt.header) {
+				t.Errorf("Expected a generated request ID, got %q", got)
+			}
+			if rec.Header().Get(RequestIDHeader) != got {
+				t.Errorf("Expected response header %q, got %q", got, rec.Header().Get(RequestIDHeader))
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package logger
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// sentryFlushTimeout is how long to wait for pending events to be sent on
+// Sync and before a fatal entry exits the process.
+const sentryFlushTimeout = 2 * time.Second
+
+// SentryConfig contains configuration for forwarding errors to Sentry.
+type SentryConfig struct {
+	// DSN of the Sentry project; errors are only forwarded when it is set
+	DSN string
+	// Environment the application runs in, e.g. "prod"
+	Environment string
+	// Release is the application version
+	Release string
+	// SampleRate is the fraction of errors sent, from 0 to 1; 0 sends all of them
+	SampleRate float64
+}
+
+// NewSentryCore returns a core forwarding error and more severe entries to
+// Sentry, with the message, the structured fields and a stack trace, or the
+// stack trace of an error field created with zap.Error.
+func NewSentryCore(cfg SentryConfig) (zapcore.Core, error) {
+	client, err := sentry.NewClient(sentry.ClientOptions{
+		Dsn:         cfg.DSN,
+		Environment: cfg.Environment,
+		Release:     cfg.Release,
+		SampleRate:  cfg.SampleRate,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sentry client: %w", err)
+	}
+	return newSentryCore(client), nil
+}
+
+// WithSentry returns `zap.Option` adding a core forwarding errors to Sentry to a logger.
+//
+// Usage:
+//
+//	core, err := logger.NewSentryCore(logger.SentryConfig{DSN: dsn})
+//	l := logger.Logger().Desugar().WithOptions(logger.WithSentry(core)).Sugar()
+func WithSentry(core zapcore.Core) zap.Option {
+	return zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+		return zapcore.NewTee(c, core)
+	})
+}
+
+type sentryCore struct {
+	client *sentry.Client
+	fields []zapcore.Field
+}
+
+func newSentryCore(client *sentry.Client) *sentryCore {
+	return &sentryCore{client: client}
+}
+
+func (c *sentryCore) Enabled(l zapcore.Level) bool {
+	return l >= zapcore.ErrorLevel
+}
+
+func (c *sentryCore) With(fields []zapcore.Field) zapcore.Core {
+	return &sentryCore{
+		client: c.client,
+		fields: append(c.fields[:len(c.fields):len(c.fields)], fields...),
+	}
+}
+
+func (c *sentryCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *sentryCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	// Writes to a tee are not checked when its level is lowered, e.g. by Named.
+	if !c.Enabled(ent.Level) {
+		return nil
+	}
+
+	enc := zapcore.NewMapObjectEncoder()
+	var errField error
+	for _, f := range append(c.fields[:len(c.fields):len(c.fields)], fields...) {
+		if err, ok := f.Interface.(error); ok && f.Type == zapcore.ErrorType {
+			errField = err
+			// Reports the error logged by ErrorE rather than its wrapper.
+			if se, ok := err.(structuredError); ok {
+				errField = se.error
+			}
+		}
+		f.AddTo(enc)
+	}
+
+	event := sentry.NewEvent()
+	event.Level = sentryLevel(ent.Level)
+	event.Message = ent.Message
+	event.Timestamp = ent.Time
+	event.Logger = ent.LoggerName
+	event.Extra = enc.Fields
+
+	exception := sentry.Exception{Type: ent.Message, Value: ent.Message, Stacktrace: sentry.NewStacktrace()}
+	if errField != nil {
+		exception.Type = reflect.TypeOf(errField).String()
+		exception.Value = errField.Error()
+		if st := sentry.ExtractStacktrace(errField); st != nil {
+			exception.Stacktrace = st
+		}
+	}
+	event.Exception = []sentry.Exception{exception}
+
+	c.client.CaptureEvent(event, nil, nil)
+
+	// The process exits or panics right after the entry is written.
+	if ent.Level > zapcore.ErrorLevel {
+		c.client.Flush(sentryFlushTimeout)
+	}
+	return nil
+}
+
+func (c *sentryCore) Sync() error {
+	c.client.Flush(sentryFlushTimeout)
+	return nil
+}
+
+func sentryLevel(l zapcore.Level) sentry.Level {
+	if l > zapcore.ErrorLevel {
+		return sentry.LevelFatal
+	}
+	return sentry.LevelError
+}
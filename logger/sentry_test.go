@@ -0,0 +1,82 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestSentryCore(t *testing.T) {
+	transport := &sentry.MockTransport{}
+	client, err := sentry.NewClient(sentry.ClientOptions{
+		Dsn:         "https://key@sentry.example.com/1",
+		Environment: "prod",
+		Release:     "1.2.3",
+		Transport:   transport,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	l := zap.New(zapcore.NewNopCore(), WithSentry(newSentryCore(client))).Sugar().With("order_id", 42)
+
+	t.Run("IgnoresBelowError", func(t *testing.T) {
+		l.Infow("processing", "step", 1)
+		l.Warn("slow")
+
+		if len(transport.Events()) != 0 {
+			t.Errorf("Expected no events, got %d", len(transport.Events()))
+		}
+	})
+
+	t.Run("ForwardsErrors", func(t *testing.T) {
+		l.Errorw("Failed to charge", zap.Error(errors.New("card declined")), "amount", 10)
+
+		events := transport.Events()
+		if len(events) != 1 {
+			t.Fatalf("Expected 1 event, got %d", len(events))
+		}
+		event := events[0]
+		if event.Level != sentry.LevelError {
+			t.Errorf("Expected level error, got %s", event.Level)
+		}
+		if event.Message != "Failed to charge" {
+			t.Errorf("Expected message 'Failed to charge', got %q", event.Message)
+		}
+		if event.Environment != "prod" || event.Release != "1.2.3" {
+			t.Errorf("Expected environment prod and release 1.2.3, got %s and %s", event.Environment, event.Release)
+		}
+		if event.Extra["order_id"] != int64(42) || event.Extra["amount"] != int64(10) {
+			t.Errorf("Expected structured fields, got %v", event.Extra)
+		}
+		if len(event.Exception) != 1 || event.Exception[0].Value != "card declined" {
+			t.Fatalf("Expected exception 'card declined', got %+v", event.Exception)
+		}
+		if st := event.Exception[0].Stacktrace; st == nil || len(st.Frames) == 0 {
+			t.Error("Expected stack trace of the error")
+		}
+	})
+
+	t.Run("StackTraceWithoutError", func(t *testing.T) {
+		l.Error("Something went wrong")
+
+		events := transport.Events()
+		event := events[len(events)-1]
+		if len(event.Exception) != 1 || event.Exception[0].Stacktrace == nil {
+			t.Errorf("Expected exception with stack trace, got %+v", event.Exception)
+		}
+	})
+}
+
+func TestInitLoggerSentry(t *testing.T) {
+	defer SetLogger(New(level, defaultCfg))
+	defer SetLevel("info")
+
+	_, err := InitLogger(Config{Level: "info", Sentry: SentryConfig{DSN: "not a dsn"}}, "1.0.0")
+	if err == nil {
+		t.Error("Expected error for invalid DSN")
+	}
+}
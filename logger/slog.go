@@ -0,0 +1,141 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// SlogHandler returns `slog.Handler` writing to the logger returned by
+// FromContext for the context of each record, so libraries using log/slog
+// log with the same core, level, fields and trace IDs as the application.
+//
+// Usage:
+//
+//	slog.SetDefault(slog.New(logger.SlogHandler()))
+func SlogHandler() slog.Handler {
+	return &slogHandler{}
+}
+
+type slogHandler struct {
+	// fields are the attributes and groups added with WithAttrs and WithGroup;
+	// a group is a zap.Namespace holding the fields after it.
+	fields []zap.Field
+}
+
+func (h *slogHandler) Enabled(ctx context.Context, l slog.Level) bool {
+	return loggerFrom(ctx).Desugar().Core().Enabled(zapLevel(l))
+}
+
+func (h *slogHandler) Handle(ctx context.Context, r slog.Record) error {
+	core := FromContext(ctx).Desugar().Core()
+
+	ent := zapcore.Entry{
+		Level:   zapLevel(r.Level),
+		Time:    r.Time,
+		Message: r.Message,
+	}
+	ce := core.Check(ent, nil)
+	if ce == nil {
+		return nil
+	}
+
+	fields := make([]zap.Field, 0, len(h.fields)+r.NumAttrs())
+	if ent.Level >= zapcore.ErrorLevel {
+		// Added first, outside of the groups of the handler.
+		fields = append(fields, contextErrFields(ctx)...)
+	}
+	fields = append(fields, h.fields...)
+	r.Attrs(func(a slog.Attr) bool {
+		fields = appendAttr(fields, a)
+		return true
+	})
+	ce.Write(fields...)
+	return nil
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	fields := h.fields[:len(h.fields):len(h.fields)]
+	for _, a := range attrs {
+		fields = appendAttr(fields, a)
+	}
+	return &slogHandler{fields: fields}
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &slogHandler{fields: append(h.fields[:len(h.fields):len(h.fields)], zap.Namespace(name))}
+}
+
+// zapLevel maps a slog level to the zap level at or below it; levels above
+// error are logged as errors, so a library can't terminate the process.
+func zapLevel(l slog.Level) zapcore.Level {
+	switch {
+	case l >= slog.LevelError:
+		return zapcore.ErrorLevel
+	case l >= slog.LevelWarn:
+		return zapcore.WarnLevel
+	case l >= slog.LevelInfo:
+		return zapcore.InfoLevel
+	default:
+		return zapcore.DebugLevel
+	}
+}
+
+// appendAttr appends the zap field of a slog attribute, following the rules of
+// slog handlers: empty attributes are ignored and the attributes of a group
+// without a key are inlined.
+func appendAttr(fields []zap.Field, a slog.Attr) []zap.Field {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return fields
+	}
+
+	switch v := a.Value; v.Kind() {
+	case slog.KindGroup:
+		attrs := v.Group()
+		if len(attrs) == 0 {
+			return fields
+		}
+		if a.Key == "" {
+			for _, ga := range attrs {
+				fields = appendAttr(fields, ga)
+			}
+			return fields
+		}
+		return append(fields, zap.Object(a.Key, slogGroup(attrs)))
+	case slog.KindString:
+		return append(fields, zap.String(a.Key, v.String()))
+	case slog.KindInt64:
+		return append(fields, zap.Int64(a.Key, v.Int64()))
+	case slog.KindUint64:
+		return append(fields, zap.Uint64(a.Key, v.Uint64()))
+	case slog.KindFloat64:
+		return append(fields, zap.Float64(a.Key, v.Float64()))
+	case slog.KindBool:
+		return append(fields, zap.Bool(a.Key, v.Bool()))
+	case slog.KindDuration:
+		return append(fields, zap.Duration(a.Key, v.Duration()))
+	case slog.KindTime:
+		return append(fields, zap.Time(a.Key, v.Time()))
+	default:
+		if err, ok := v.Any().(error); ok {
+			return append(fields, zap.NamedError(a.Key, err))
+		}
+		return append(fields, zap.Any(a.Key, v.Any()))
+	}
+}
+
+// slogGroup encodes the attributes of a slog group as an object.
+type slogGroup []slog.Attr
+
+func (g slogGroup) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for _, f := range appendAttr(nil, slog.Attr{Value: slog.GroupValue(g...)}) {
+		f.AddTo(enc)
+	}
+	return nil
+}
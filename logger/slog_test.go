@@ -0,0 +1,92 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestSlogHandler(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	ctx := ContextWithKV(ToContext(context.Background(), zap.New(core).Sugar()), "request_id", "r1")
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx = trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  spanID,
+	}))
+
+	l := slog.New(SlogHandler()).With("lib", "client")
+
+	t.Run("Fields", func(t *testing.T) {
+		logs.TakeAll()
+		l.ErrorContext(ctx, "request failed", "status", 503, "err", errors.New("unavailable"))
+
+		entries := logs.TakeAll()
+		if len(entries) != 1 {
+			t.Fatalf("Expected 1 entry, got %d", len(entries))
+		}
+		if entries[0].Level != zap.ErrorLevel {
+			t.Errorf("Expected level error, got %s", entries[0].Level)
+		}
+		if entries[0].Message != "request failed" {
+			t.Errorf("Expected message 'request failed', got %q", entries[0].Message)
+		}
+
+		fields := entries[0].ContextMap()
+		expected := map[string]interface{}{
+			"request_id": "r1",
+			"trace_id":   "4bf92f3577b34da6a3ce929d0e0e4736",
+			"span_id":    "00f067aa0ba902b7",
+			"lib":        "client",
+			"status":     int64(503),
+			"err":        "unavailable",
+		}
+		for k, v := range expected {
+			if fields[k] != v {
+				t.Errorf("Expected %s=%v, got %v", k, v, fields[k])
+			}
+		}
+	})
+
+	t.Run("Level", func(t *testing.T) {
+		logs.TakeAll()
+		l.DebugContext(ctx, "hidden")
+		l.WarnContext(ctx, "shown")
+
+		if l.Enabled(ctx, slog.LevelDebug) {
+			t.Error("Expected debug to be disabled")
+		}
+		entries := logs.TakeAll()
+		if len(entries) != 1 || entries[0].Level != zap.WarnLevel {
+			t.Errorf("Expected one warn entry, got %v", entries)
+		}
+	})
+
+	t.Run("Groups", func(t *testing.T) {
+		logs.TakeAll()
+		l.WithGroup("http").InfoContext(ctx, "done", "method", "GET", slog.Group("resp", "code", 200))
+
+		entries := logs.TakeAll()
+		if len(entries) != 1 {
+			t.Fatalf("Expected 1 entry, got %d", len(entries))
+		}
+		group, ok := entries[0].ContextMap()["http"].(map[string]interface{})
+		if !ok {
+			t.Fatalf("Expected http group, got %v", entries[0].ContextMap())
+		}
+		if group["method"] != "GET" {
+			t.Errorf("Expected method GET in group, got %v", group["method"])
+		}
+		resp, ok := group["resp"].(map[string]interface{})
+		if !ok || resp["code"] != int64(200) {
+			t.Errorf("Expected nested resp group with code 200, got %v", group["resp"])
+		}
+	})
+}
@@ -0,0 +1,239 @@
+package fastapp
+
+import (
+	"runtime"
+	"runtime/debug"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const metricsNamespace = "fastapp"
+
+// serviceCollector exports per-service runtime metrics of an application,
+// and its health score if the health strategy computes one.
+type serviceCollector struct {
+	app *App
+
+	state         *prometheus.Desc
+	restarts      *prometheus.Desc
+	uptime        *prometheus.Desc
+	startTime     *prometheus.Desc
+	lastErrorTime *prometheus.Desc
+	healthScore   *prometheus.Desc
+
+	configReloads    *prometheus.Desc
+	configLastReload *prometheus.Desc
+	configHash       *prometheus.Desc
+	configHashInfo   *prometheus.Desc
+
+	appStartTime *prometheus.Desc
+	appUptime    *prometheus.Desc
+
+	buildInfo *prometheus.Desc
+	build     buildInfo
+}
+
+// buildInfo describes the build of the running binary.
+type buildInfo struct {
+	version     string
+	goVersion   string
+	vcsRevision string
+	buildDate   string
+}
+
+// readBuildInfo returns the build of the running binary: the version set with
+// WithVersion, or the module version when built with go install, and the
+// commit and commit time stamped by the go command.
+func readBuildInfo(version string) buildInfo {
+	info := buildInfo{version: version, goVersion: runtime.Version()}
+
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	if info.version == "" && bi.Main.Version != "(devel)" {
+		info.version = bi.Main.Version
+	}
+	for _, s := range bi.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			info.vcsRevision = s.Value
+		case "vcs.time":
+			info.buildDate = s.Value
+		}
+	}
+	return info
+}
+
+func newServiceCollector(app *App) *serviceCollector {
+	return &serviceCollector{
+		app: app,
+		state: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "service", "state"),
+			"Current state of the service, 1 for the active state and 0 otherwise.",
+			[]string{"service", "state"}, nil,
+		),
+		restarts: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "service", "restarts_total"),
+			"Number of times the service has been restarted.",
+			[]string{"service"}, nil,
+		),
+		uptime: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "service", "uptime_seconds"),
+			"Seconds since the service was started, 0 if it is not running.",
+			[]string{"service"}, nil,
+		),
+		startTime: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "service", "start_time_seconds"),
+			"Unix time the service was last started, 0 if it never started.",
+			[]string{"service"}, nil,
+		),
+		lastErrorTime: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "service", "last_error_timestamp_seconds"),
+			"Unix time of the last error returned by the service, 0 if it never failed.",
+			[]string{"service"}, nil,
+		),
+		healthScore: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "health", "score"),
+			"Health score from 0 to 100 computed by the last evaluation of all health checks.",
+			nil, nil,
+		),
+		configReloads: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "config", "reloads_total"),
+			"Number of configuration reloads by result, success or failure.",
+			[]string{"result"}, nil,
+		),
+		configLastReload: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "config", "last_reload_timestamp_seconds"),
+			"Unix time of the last successful configuration reload, 0 if there was none.",
+			nil, nil,
+		),
+		configHash: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "config", "hash"),
+			"Hash of the current configuration, changing with any value but secrets.",
+			nil, nil,
+		),
+		configHashInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "config", "hash_info"),
+			"Hash of the current configuration with secrets masked, always 1, to verify every replica runs the same configuration.",
+			[]string{"hash"}, nil,
+		),
+		appStartTime: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "", "start_time_seconds"),
+			"Unix time the application was started.",
+			nil, nil,
+		),
+		appUptime: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "", "uptime_seconds"),
+			"Seconds since the application was started.",
+			nil, nil,
+		),
+		buildInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "", "build_info"),
+			"Build of the running binary, always 1, to slice metrics by deployed version.",
+			[]string{"version", "go_version", "vcs_revision", "build_date"}, nil,
+		),
+		build: readBuildInfo(app.opts.version),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *serviceCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.state
+	ch <- c.restarts
+	ch <- c.uptime
+	ch <- c.startTime
+	ch <- c.lastErrorTime
+	ch <- c.healthScore
+	ch <- c.configReloads
+	ch <- c.configLastReload
+	ch <- c.configHash
+	ch <- c.configHashInfo
+	ch <- c.appStartTime
+	ch <- c.appUptime
+	ch <- c.buildInfo
+}
+
+// Collect implements prometheus.Collector.
+func (c *serviceCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, r := range c.app.runners {
+		st := r.status()
+
+		for _, state := range serviceStates {
+			var v float64
+			if st.state == state {
+				v = 1
+			}
+			ch <- prometheus.MustNewConstMetric(c.state, prometheus.GaugeValue, v, r.name, string(state))
+		}
+
+		ch <- prometheus.MustNewConstMetric(c.restarts, prometheus.CounterValue, float64(st.restarts), r.name)
+
+		var uptime float64
+		if st.state == StateRunning {
+			uptime = c.app.opts.clock.Since(st.startedAt).Seconds()
+		}
+		ch <- prometheus.MustNewConstMetric(c.uptime, prometheus.GaugeValue, uptime, r.name)
+
+		var startTime float64
+		if !st.startedAt.IsZero() {
+			startTime = float64(st.startedAt.UnixNano()) / 1e9
+		}
+		ch <- prometheus.MustNewConstMetric(c.startTime, prometheus.GaugeValue, startTime, r.name)
+
+		var lastErrorTime float64
+		if !st.lastErrAt.IsZero() {
+			lastErrorTime = float64(st.lastErrAt.UnixNano()) / 1e9
+		}
+		ch <- prometheus.MustNewConstMetric(c.lastErrorTime, prometheus.GaugeValue, lastErrorTime, r.name)
+	}
+
+	if score, ok := c.app.healthManager.LastScore(); ok {
+		ch <- prometheus.MustNewConstMetric(c.healthScore, prometheus.GaugeValue, score)
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.configHashInfo, prometheus.GaugeValue, 1, c.app.configHash())
+
+	if startedAt := c.app.startedAt; !startedAt.IsZero() {
+		ch <- prometheus.MustNewConstMetric(c.appStartTime, prometheus.GaugeValue, float64(startedAt.UnixNano())/1e9)
+		ch <- prometheus.MustNewConstMetric(c.appUptime, prometheus.GaugeValue, c.app.opts.clock.Since(startedAt).Seconds())
+	}
+
+	bi := c.build
+	ch <- prometheus.MustNewConstMetric(c.buildInfo, prometheus.GaugeValue, 1, bi.version, bi.goVersion, bi.vcsRevision, bi.buildDate)
+
+	if c.app.opts.configLoader != nil {
+		stats := c.app.configs.stats()
+		ch <- prometheus.MustNewConstMetric(c.configReloads, prometheus.CounterValue, float64(stats.successes), "success")
+		ch <- prometheus.MustNewConstMetric(c.configReloads, prometheus.CounterValue, float64(stats.failures), "failure")
+
+		var lastReload float64
+		if !stats.lastReload.IsZero() {
+			lastReload = float64(stats.lastReload.UnixNano()) / 1e9
+		}
+		ch <- prometheus.MustNewConstMetric(c.configLastReload, prometheus.GaugeValue, lastReload)
+		if stats.hash != "" {
+			ch <- prometheus.MustNewConstMetric(c.configHash, prometheus.GaugeValue, stats.hashValue())
+		}
+	}
+}
+
+// registerMetrics registers the service metrics with the configured registerer.
+// The returned function unregisters them.
+func (a *App) registerMetrics() func() {
+	reg := a.opts.metricsRegisterer
+	if reg == nil {
+		if !a.config.Observability.Metrics.Enabled {
+			return func() {}
+		}
+		reg = prometheus.DefaultRegisterer
+	}
+
+	collector := newServiceCollector(a)
+	if err := reg.Register(collector); err != nil {
+		a.logger.Warnw("Failed to register service metrics", "error", err)
+		return func() {}
+	}
+
+	return func() { reg.Unregister(collector) }
+}
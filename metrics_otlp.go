@@ -0,0 +1,92 @@
+package fastapp
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	promBridge "go.opentelemetry.io/contrib/bridges/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+
+	"github.com/katalabut/fast-app/config"
+)
+
+// startMetricsExport starts pushing metrics over OTLP when the metrics
+// exporter is "otlp" or "both": the Prometheus metrics registered with the
+// metrics registerer, through a bridge, and those recorded with the global
+// OpenTelemetry meter provider, which is set to the one of the application.
+// The returned function pushes the metrics a last time and stops the export.
+func (a *App) startMetricsExport(ctx context.Context) (func(context.Context) error, error) {
+	cfg := a.config.Observability.Metrics
+	noop := func(context.Context) error { return nil }
+
+	switch cfg.Exporter {
+	case "", config.MetricsExporterPrometheus:
+		return noop, nil
+	case config.MetricsExporterOTLP, config.MetricsExporterBoth:
+	default:
+		return nil, errors.Errorf(
+			"unknown metrics exporter %q, expected %s, %s or %s",
+			cfg.Exporter, config.MetricsExporterPrometheus, config.MetricsExporterOTLP, config.MetricsExporterBoth,
+		)
+	}
+	if !cfg.Enabled {
+		return noop, nil
+	}
+
+	var opts []otlpmetrichttp.Option
+	if cfg.OTLP.Endpoint != "" {
+		opts = append(opts, otlpmetrichttp.WithEndpointURL(cfg.OTLP.Endpoint))
+	}
+	if len(cfg.OTLP.Headers) > 0 {
+		headers := make(map[string]string, len(cfg.OTLP.Headers))
+		for k, v := range cfg.OTLP.Headers {
+			headers[k] = v.Reveal()
+		}
+		opts = append(opts, otlpmetrichttp.WithHeaders(headers))
+	}
+
+	exporter, err := otlpmetrichttp.New(ctx, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "create OTLP metrics exporter")
+	}
+
+	gatherer := a.opts.metricsGatherer
+	if gatherer == nil {
+		gatherer = prometheus.DefaultGatherer
+		if g, ok := a.opts.metricsRegisterer.(prometheus.Gatherer); ok {
+			gatherer = g
+		}
+	}
+
+	readerOpts := []sdkmetric.PeriodicReaderOption{
+		sdkmetric.WithProducer(promBridge.NewMetricProducer(promBridge.WithGatherer(gatherer))),
+	}
+	if cfg.OTLP.Interval > 0 {
+		readerOpts = append(readerOpts, sdkmetric.WithInterval(cfg.OTLP.Interval))
+	}
+
+	attrs := []attribute.KeyValue{}
+	if a.config.Logger.AppName != "" {
+		attrs = append(attrs, attribute.String("service.name", a.config.Logger.AppName))
+	}
+	if a.opts.version != "" {
+		attrs = append(attrs, attribute.String("service.version", a.opts.version))
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(attrs...))
+	if err != nil {
+		res = resource.Default()
+	}
+
+	provider := sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exporter, readerOpts...)),
+		sdkmetric.WithResource(res),
+	)
+	otel.SetMeterProvider(provider)
+
+	return provider.Shutdown, nil
+}
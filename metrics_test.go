@@ -0,0 +1,300 @@
+package fastapp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/katalabut/fast-app/config"
+	"github.com/katalabut/fast-app/health"
+	"github.com/katalabut/fast-app/health/strategies"
+)
+
+type namedService struct {
+	*testService
+}
+
+func (s namedService) Name() string {
+	return "worker"
+}
+
+// gatherMetric returns the value of the metric with the given name and labels.
+func gatherMetric(t *testing.T, reg *prometheus.Registry, name string, labels map[string]string) (float64, bool) {
+	t.Helper()
+
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+
+	for _, mf := range families {
+		if mf.GetName() != name {
+			continue
+		}
+		for _, m := range mf.GetMetric() {
+			if matchLabels(m, labels) {
+				if m.GetGauge() != nil {
+					return m.GetGauge().GetValue(), true
+				}
+				return m.GetCounter().GetValue(), true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+func matchLabels(m *dto.Metric, labels map[string]string) bool {
+	matched := 0
+	for _, lp := range m.GetLabel() {
+		if v, ok := labels[lp.GetName()]; ok && v == lp.GetValue() {
+			matched++
+		}
+	}
+	return matched == len(labels)
+}
+
+func TestServiceMetrics(t *testing.T) {
+	t.Run("Namer", func(t *testing.T) {
+		app, _, _ := newTestApp()
+		app.Add(namedService{newTestService()})
+
+		if name := app.runners[0].Name(); name != "worker" {
+			t.Errorf("Expected name worker, got %s", name)
+		}
+	})
+
+	t.Run("RunningService", func(t *testing.T) {
+		reg := prometheus.NewRegistry()
+		app, cancel, exitCode := newTestApp(WithMetricsRegisterer(reg))
+
+		svc := newTestService()
+		app.Add(svc, WithName("api"))
+		stop := startTestApp(t, app, cancel, exitCode)
+		<-svc.started
+
+		if v, _ := gatherMetric(t, reg, "fastapp_service_state", map[string]string{"service": "api", "state": "running"}); v != 1 {
+			t.Errorf("Expected running state to be 1, got %v", v)
+		}
+		if v, _ := gatherMetric(t, reg, "fastapp_service_state", map[string]string{"service": "api", "state": "starting"}); v != 0 {
+			t.Errorf("Expected starting state to be 0, got %v", v)
+		}
+		if _, ok := gatherMetric(t, reg, "fastapp_service_restarts_total", map[string]string{"service": "api"}); !ok {
+			t.Error("Expected restarts metric to be exported")
+		}
+		if v, _ := gatherMetric(t, reg, "fastapp_service_last_error_timestamp_seconds", map[string]string{"service": "api"}); v != 0 {
+			t.Errorf("Expected no last error, got %v", v)
+		}
+		if v, _ := gatherMetric(t, reg, "fastapp_service_start_time_seconds", map[string]string{"service": "api"}); time.Since(time.Unix(int64(v), 0)) > time.Minute {
+			t.Errorf("Expected service start time to be set, got %v", v)
+		}
+		if v, _ := gatherMetric(t, reg, "fastapp_start_time_seconds", nil); time.Since(time.Unix(int64(v), 0)) > time.Minute {
+			t.Errorf("Expected application start time to be set, got %v", v)
+		}
+		if _, ok := gatherMetric(t, reg, "fastapp_uptime_seconds", nil); !ok {
+			t.Error("Expected uptime metric to be exported")
+		}
+
+		if code := stop(); code != exitCodeOk {
+			t.Errorf("Expected exit code %d, got %d", exitCodeOk, code)
+		}
+		if _, ok := gatherMetric(t, reg, "fastapp_service_state", nil); ok {
+			t.Error("Expected metrics to be unregistered after the application stopped")
+		}
+	})
+
+	t.Run("FailedService", func(t *testing.T) {
+		app, _, _ := newTestApp()
+		svc := newTestService()
+		app.Add(svc, WithName("api"))
+
+		r := app.runners[0]
+		r.markStarted()
+		r.markFinished(errors.New("boom"))
+
+		st := r.status()
+		if st.state != StateFailed {
+			t.Errorf("Expected state %s, got %s", StateFailed, st.state)
+		}
+		if st.lastErrAt.IsZero() || time.Since(st.lastErrAt) > time.Minute {
+			t.Errorf("Expected last error time to be set, got %v", st.lastErrAt)
+		}
+	})
+
+	t.Run("HealthScore", func(t *testing.T) {
+		app, _, _ := newTestApp(WithHealthStrategy(strategies.NewScoreStrategy(nil)))
+		app.WithHealthChecks(
+			health.NewCustomCheck("db", func(ctx context.Context) health.HealthResult {
+				return health.NewHealthyResult("ok")
+			}),
+			health.NewCustomCheck("search", func(ctx context.Context) health.HealthResult {
+				return health.NewDegradedResult("slow")
+			}),
+		)
+
+		reg := prometheus.NewRegistry()
+		reg.MustRegister(newServiceCollector(app))
+
+		if _, ok := gatherMetric(t, reg, "fastapp_health_score", nil); ok {
+			t.Error("Expected no health score before the checks ran")
+		}
+
+		app.HealthManager().CheckAllWithStatus(context.Background())
+		if v, _ := gatherMetric(t, reg, "fastapp_health_score", nil); v != 75 {
+			t.Errorf("Expected health score 75, got %v", v)
+		}
+	})
+	t.Run("BuildInfo", func(t *testing.T) {
+		app, _, _ := newTestApp(WithVersion("v1.2.3"))
+
+		reg := prometheus.NewRegistry()
+		reg.MustRegister(newServiceCollector(app))
+
+		labels := map[string]string{"version": "v1.2.3", "go_version": runtime.Version()}
+		if v, ok := gatherMetric(t, reg, "fastapp_build_info", labels); !ok || v != 1 {
+			t.Errorf("Expected build info with version v1.2.3 to be 1, got %v", v)
+		}
+	})
+}
+
+func TestMetricsExport(t *testing.T) {
+	t.Run("OTLP", func(t *testing.T) {
+		var (
+			mu    sync.Mutex
+			names = map[string]bool{}
+		)
+		collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/v1/metrics" {
+				t.Errorf("Expected metrics pushed to /v1/metrics, got %s", r.URL.Path)
+			}
+			if key := r.Header.Get("X-Api-Key"); key != "secret" {
+				t.Errorf("Expected API key header secret, got %q", key)
+			}
+
+			body, _ := io.ReadAll(r.Body)
+			var req colmetricpb.ExportMetricsServiceRequest
+			if err := proto.Unmarshal(body, &req); err != nil {
+				t.Errorf("Expected OTLP protobuf request, got %v", err)
+			}
+
+			mu.Lock()
+			for _, rm := range req.ResourceMetrics {
+				for _, sm := range rm.ScopeMetrics {
+					for _, m := range sm.Metrics {
+						names[m.Name] = true
+					}
+				}
+			}
+			mu.Unlock()
+
+			w.Header().Set("Content-Type", "application/x-protobuf")
+			_, _ = w.Write(nil)
+		}))
+		defer collector.Close()
+
+		reg := prometheus.NewRegistry()
+		app, cancel, exitCode := newTestApp(WithMetricsRegisterer(reg))
+		app.config.Observability.Metrics = config.Metrics{
+			Enabled:  true,
+			Exporter: config.MetricsExporterOTLP,
+			OTLP: config.OTLPMetrics{
+				Endpoint: collector.URL + "/v1/metrics",
+				Headers:  map[string]config.Secret{"X-Api-Key": "secret"},
+				Interval: time.Hour,
+			},
+		}
+
+		svc := newTestService()
+		app.Add(svc, WithName("api"))
+		stop := startTestApp(t, app, cancel, exitCode)
+		<-svc.started
+
+		if code := stop(); code != exitCodeOk {
+			t.Errorf("Expected exit code %d, got %d", exitCodeOk, code)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if !names["fastapp_service_state"] {
+			t.Errorf("Expected service metrics pushed on shutdown, got %v", names)
+		}
+	})
+
+	t.Run("UnknownExporter", func(t *testing.T) {
+		app, _, _ := newTestApp()
+		app.config.Observability.Metrics.Exporter = "statsd"
+
+		if _, err := app.startMetricsExport(context.Background()); err == nil {
+			t.Error("Expected error for unknown exporter")
+		}
+	})
+
+	t.Run("Prometheus", func(t *testing.T) {
+		app, _, _ := newTestApp()
+		app.config.Observability.Metrics = config.Metrics{Enabled: true, Exporter: config.MetricsExporterPrometheus}
+
+		stop, err := app.startMetricsExport(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if err := stop(context.Background()); err != nil {
+			t.Errorf("Expected no error stopping, got %v", err)
+		}
+	})
+}
+
+func TestPrometheusRegistry(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	ctx, cancel := context.WithCancel(context.Background())
+	exitCode := make(chan int, 2)
+	app := New(
+		Config{Observability: config.Observability{
+			Enabled: true,
+			Metrics: config.Metrics{Enabled: true, Path: "/metrics"},
+		}},
+		WithPrometheusRegistry(reg),
+		WithContext(ctx),
+		WithExitFunc(func(code int) { exitCode <- code }),
+	)
+
+	svc := newTestService()
+	app.Add(svc, WithName("api"))
+	stop := startTestApp(t, app, cancel, exitCode)
+	defer stop()
+	<-svc.started
+
+	if _, ok := gatherMetric(t, reg, "fastapp_service_state", nil); !ok {
+		t.Error("Expected service metrics to be registered with the registry")
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for app.ObservabilityAddr() == "" && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	resp, err := http.Get("http://" + app.ObservabilityAddr() + "/metrics")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+
+	if !strings.Contains(string(body), "fastapp_service_state") {
+		t.Errorf("Expected the registry metrics to be served, got %s", body)
+	}
+	if strings.Contains(string(body), "go_goroutines") {
+		t.Error("Expected the default registry not to be served")
+	}
+}
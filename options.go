@@ -3,7 +3,14 @@ package fastapp
 import (
 	"context"
 	"net/http"
+	"os"
 	"time"
+
+	"github.com/katalabut/fast-app/clock"
+	"github.com/katalabut/fast-app/configloader"
+	"github.com/katalabut/fast-app/health"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
 )
 
 type options struct {
@@ -11,8 +18,34 @@ type options struct {
 	stopAllOnErr    bool
 	shutdownTimeout time.Duration
 
-	ctx context.Context
-	mux *http.ServeMux
+	ctx    context.Context
+	mux    *http.ServeMux
+	logger *zap.SugaredLogger
+	exit   func(code int)
+	clock  clock.Clock
+
+	healthManager  *health.Manager
+	healthStrategy health.AggregationStrategy
+	healthRedactor health.Redactor
+
+	shutdownSignals []os.Signal
+	dumpOnQuit      bool
+	noDiagnostics   bool
+
+	watchdogTimeout  time.Duration
+	watchdogDisabled bool
+	onWatchdog       func()
+	gracefulFatal    bool
+
+	metricsRegisterer prometheus.Registerer
+	metricsGatherer   prometheus.Gatherer
+	manualReadiness   bool
+
+	configLoader  func() (interface{}, error)
+	configWatch   func(reload func()) (stop func())
+	configCurrent func() interface{}
+
+	explainConfig func() ([]configloader.Entry, error)
 }
 
 type optionFunc func(*options)
@@ -57,8 +90,8 @@ func WithDisableStopAllOnErr() Option {
 }
 
 // WithShutdownTimeout sets the maximum time to wait for services to shut down gracefully.
-// If services don't shut down within this timeout, the application will be forcefully terminated.
-// Default timeout is 5 seconds.
+// If services don't shut down within this timeout, the application will be forcefully terminated
+// by the watchdog (see WithWatchdogTimeout). Default timeout is 5 seconds.
 func WithShutdownTimeout(timeout time.Duration) Option {
 	return optionFunc(
 		func(o *options) {
@@ -66,3 +99,190 @@ func WithShutdownTimeout(timeout time.Duration) Option {
 		},
 	)
 }
+
+// WithLogger sets a pre-built logger for the application instead of creating one
+// from the logger configuration. The logger is also installed as the global logger.
+func WithLogger(l *zap.SugaredLogger) Option {
+	return optionFunc(
+		func(o *options) {
+			o.logger = l
+		},
+	)
+}
+
+// WithExitFunc replaces os.Exit as the function called with the final exit code
+// when the application stops or the shutdown watchdog fires. When a custom exit
+// function returns, Start returns as well, which makes it possible to run the
+// application inside tests or host programs.
+func WithExitFunc(exit func(code int)) Option {
+	return optionFunc(
+		func(o *options) {
+			o.exit = exit
+		},
+	)
+}
+
+// WithNoExit prevents the application from exiting the process when it stops
+// or the shutdown watchdog fires. Start returns instead and the exit code is
+// available from App.ExitCode.
+func WithNoExit() Option {
+	return WithExitFunc(func(int) {})
+}
+
+// WithClock sets the clock used by the application for timers such as the
+// shutdown watchdog and health check cache expiry. The system clock is used by default.
+// Passing a clock.Fake allows time-dependent behavior to be tested deterministically.
+func WithClock(c clock.Clock) Option {
+	return optionFunc(
+		func(o *options) {
+			o.clock = c
+		},
+	)
+}
+
+// WithHealthManager sets a pre-configured health manager for the application.
+// When set, the health configuration (cache TTL), WithHealthStrategy and WithHealthRedactor are ignored,
+// since the manager is used as is.
+func WithHealthManager(m *health.Manager) Option {
+	return optionFunc(
+		func(o *options) {
+			o.healthManager = m
+		},
+	)
+}
+
+// WithHealthStrategy sets the aggregation strategy used to compute the overall
+// application health, e.g. strategies.MajorityHealthyStrategy or strategies.WeightedStrategy.
+// health.AllHealthyStrategy is used by default.
+func WithHealthStrategy(s health.AggregationStrategy) Option {
+	return optionFunc(
+		func(o *options) {
+			o.healthStrategy = s
+		},
+	)
+}
+
+// WithHealthRedactor sets the redactor applied to health check results before
+// they are served, e.g. health.RedactDetails("dsn"). health.RedactCredentials
+// is used by default.
+func WithHealthRedactor(r health.Redactor) Option {
+	return optionFunc(
+		func(o *options) {
+			o.healthRedactor = r
+		},
+	)
+}
+
+// WithShutdownSignals sets the OS signals that trigger a graceful shutdown.
+// By default the application shuts down on SIGINT and SIGTERM.
+func WithShutdownSignals(signals ...os.Signal) Option {
+	return optionFunc(
+		func(o *options) {
+			o.shutdownSignals = signals
+		},
+	)
+}
+
+// WithGoroutineDumpOnQuit makes SIGQUIT trigger a graceful shutdown that first
+// logs the stack traces of all goroutines, which helps diagnosing stuck processes.
+// Without this option SIGQUIT keeps the Go runtime default behavior.
+func WithGoroutineDumpOnQuit() Option {
+	return optionFunc(
+		func(o *options) {
+			o.dumpOnQuit = true
+		},
+	)
+}
+
+// WithDiagnosticDumpDisabled stops SIGUSR1 from logging a diagnostic dump
+// (goroutine stacks, memory and GC stats and the health snapshot), for
+// applications that use SIGUSR1 for other purposes.
+func WithDiagnosticDumpDisabled() Option {
+	return optionFunc(
+		func(o *options) {
+			o.noDiagnostics = true
+		},
+	)
+}
+
+// WithWatchdogTimeout sets how long the application may take to stop after shutdown
+// has been initiated before the watchdog forcefully terminates it.
+// By default it is the drain delay plus the shutdown timeout plus 5 seconds.
+func WithWatchdogTimeout(timeout time.Duration) Option {
+	return optionFunc(
+		func(o *options) {
+			o.watchdogTimeout = timeout
+		},
+	)
+}
+
+// WithWatchdogDisabled disables the shutdown watchdog, so the application waits
+// for all services to stop no matter how long it takes. Use it for services that
+// need an unbounded drain, e.g. consumers committing offsets.
+func WithWatchdogDisabled() Option {
+	return optionFunc(
+		func(o *options) {
+			o.watchdogDisabled = true
+		},
+	)
+}
+
+// WithWatchdogHook sets a callback executed when the watchdog fires,
+// right before the application is forcefully terminated.
+// It can be used to dump diagnostics or flush critical data.
+func WithWatchdogHook(f func()) Option {
+	return optionFunc(
+		func(o *options) {
+			o.onWatchdog = f
+		},
+	)
+}
+
+// WithGracefulFatal routes entries logged with logger.Fatal, e.g. by library
+// code, through the graceful shutdown instead of exiting the process
+// immediately: the functions registered with logger.OnFatal run, the
+// goroutine that logged the entry is terminated and the application is
+// stopped, then exits with code 1. Before Start, the application exits
+// immediately.
+func WithGracefulFatal() Option {
+	return optionFunc(
+		func(o *options) {
+			o.gracefulFatal = true
+		},
+	)
+}
+
+// WithMetricsRegisterer sets the Prometheus registerer used for per-service metrics.
+// By default they are registered with the default registry, which is served by
+// the observability server, when metrics are enabled in the configuration.
+func WithMetricsRegisterer(reg prometheus.Registerer) Option {
+	return optionFunc(
+		func(o *options) {
+			o.metricsRegisterer = reg
+		},
+	)
+}
+
+// WithPrometheusRegistry sets the Prometheus registry of the application
+// instead of the default one: per-service metrics are registered with it, and
+// it is the registry served at the metrics endpoint and pushed over OTLP. It
+// isolates the metrics of tests and of several applications in one process.
+// Collectors such as the Go and process ones must be registered explicitly.
+func WithPrometheusRegistry(reg *prometheus.Registry) Option {
+	return optionFunc(
+		func(o *options) {
+			o.metricsRegisterer = reg
+			o.metricsGatherer = reg
+		},
+	)
+}
+
+// WithManualReadiness disables deriving the application readiness from its services.
+// Readiness is then only controlled with App.SetReady.
+func WithManualReadiness() Option {
+	return optionFunc(
+		func(o *options) {
+			o.manualReadiness = true
+		},
+	)
+}
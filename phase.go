@@ -0,0 +1,77 @@
+package fastapp
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// Phase is a startup group. Services in a phase are only started once every
+// service in all earlier phases is ready; jobs must have completed.
+// Services are shut down phase by phase in reverse order.
+type Phase int
+
+const (
+	// PhaseInfra is for infrastructure such as database pools, caches and message brokers.
+	PhaseInfra Phase = 10
+	// PhaseMigrations is for jobs preparing the infrastructure, e.g. schema migrations.
+	PhaseMigrations Phase = 20
+	// PhaseServing is for services handling traffic. It is the default phase.
+	PhaseServing Phase = 30
+)
+
+// String returns the name of the phase.
+func (p Phase) String() string {
+	switch p {
+	case PhaseInfra:
+		return "infra"
+	case PhaseMigrations:
+		return "migrations"
+	case PhaseServing:
+		return "serving"
+	default:
+		return fmt.Sprintf("phase-%d", int(p))
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (p Phase) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (p *Phase) UnmarshalText(text []byte) error {
+	switch s := string(text); s {
+	case "infra":
+		*p = PhaseInfra
+	case "migrations":
+		*p = PhaseMigrations
+	case "serving":
+		*p = PhaseServing
+	default:
+		var n int
+		if _, err := fmt.Sscanf(s, "phase-%d", &n); err != nil {
+			return errors.Errorf("unknown phase %q", s)
+		}
+		*p = Phase(n)
+	}
+
+	return nil
+}
+
+// InPhase sets the startup phase of the service. Custom phases may be placed
+// between the predefined ones, e.g. PhaseInfra + 1. A service may only depend
+// on services in the same or an earlier phase.
+//
+// Example:
+//
+//	app.Add(db, fastapp.InPhase(fastapp.PhaseInfra))
+//	app.AddJob(migrations, fastapp.InPhase(fastapp.PhaseMigrations))
+//	app.Add(api) // PhaseServing
+func InPhase(p Phase) ServiceOption {
+	return serviceOptionFunc(
+		func(o *serviceOptions) {
+			o.phase = p
+		},
+	)
+}
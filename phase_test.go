@@ -0,0 +1,82 @@
+package fastapp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPhases(t *testing.T) {
+	t.Run("StartsPhasesInOrder", func(t *testing.T) {
+		app, cancel, exitCode := newTestApp()
+		db := newTestService()
+		job := &testJob{release: make(chan struct{})}
+		api := newTestService()
+
+		app.Add(api)
+		app.AddJob(job, WithName("migrations"), InPhase(PhaseMigrations))
+		app.Add(db, WithName("database"), InPhase(PhaseInfra))
+		stop := startTestApp(t, app, cancel, exitCode)
+
+		<-db.started
+		close(job.release)
+		select {
+		case <-api.started:
+			t.Fatal("Expected api not to start before infra is ready")
+		case <-time.After(3 * readinessPollInterval):
+		}
+		if state := app.Services()[1].State; state != StateStarting {
+			t.Errorf("Expected migrations to wait for infra, got %s", state)
+		}
+
+		db.SetReady(true)
+		select {
+		case <-api.started:
+		case <-time.After(time.Second):
+			t.Fatal("Expected api to start once earlier phases are done")
+		}
+
+		if code := stop(); code != exitCodeOk {
+			t.Errorf("Expected exit code %d, got %d", exitCodeOk, code)
+		}
+	})
+
+	t.Run("ShutdownOrder", func(t *testing.T) {
+		app, _, _ := newTestApp()
+		app.Add(newTestService(), WithName("api"))
+		app.Add(newTestService(), WithName("database"), InPhase(PhaseInfra))
+		app.Add(newTestService(), WithName("worker"))
+
+		var names []string
+		for _, r := range app.shutdownOrder() {
+			names = append(names, r.Name())
+		}
+
+		want := []string{"worker", "api", "database"}
+		for i := range want {
+			if names[i] != want[i] {
+				t.Fatalf("Expected shutdown order %v, got %v", want, names)
+			}
+		}
+	})
+
+	t.Run("DependencyOnLaterPhase", func(t *testing.T) {
+		app, _, _ := newTestApp()
+		app.Add(newTestService(), WithName("api"))
+		app.Add(newTestService(), WithName("database"), InPhase(PhaseInfra), DependsOn("api"))
+
+		if err := app.validateDependencies(); err == nil {
+			t.Error("Expected dependency on a later phase to be rejected")
+		}
+	})
+
+	t.Run("Text", func(t *testing.T) {
+		for _, p := range []Phase{PhaseInfra, PhaseMigrations, PhaseServing, PhaseInfra + 1} {
+			text, _ := p.MarshalText()
+
+			var got Phase
+			if err := got.UnmarshalText(text); err != nil || got != p {
+				t.Errorf("Expected %v, got %v (%v)", p, got, err)
+			}
+		}
+	})
+}
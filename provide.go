@@ -0,0 +1,39 @@
+package fastapp
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Provide registers constructors with the application's dependency injection
+// container. A constructor is a function whose parameters are resolved from the
+// container and which returns one or more values, optionally followed by an error.
+// Constructors are called lazily, at most once, when their result is needed.
+// If a constructor is invalid, Start fails.
+//
+// Constructed values implementing io.Closer or a Close() method are closed in
+// reverse construction order once all services have been shut down.
+//
+// Example:
+//
+//	app.Provide(NewConfig, NewDBPool, NewAPIService)
+//	if err := app.Invoke(func(api *APIService) { app.Add(api) }); err != nil {
+//	    ...
+//	}
+func (a *App) Provide(constructors ...interface{}) *App {
+	if err := a.container.Provide(constructors...); err != nil && a.provideErr == nil {
+		a.provideErr = errors.Wrap(err, "invalid constructor")
+	}
+
+	return a
+}
+
+// Invoke calls fn with its parameters resolved from the dependency injection
+// container, constructing them as needed. If fn returns an error as its last
+// result, it is returned.
+func (a *App) Invoke(fn interface{}) error {
+	if a.provideErr != nil {
+		return a.provideErr
+	}
+
+	return a.container.Invoke(fn)
+}
@@ -0,0 +1,63 @@
+package fastapp
+
+import (
+	"testing"
+)
+
+type testPool struct {
+	closed bool
+}
+
+func (p *testPool) Close() error {
+	p.closed = true
+	return nil
+}
+
+func TestProvide(t *testing.T) {
+	t.Run("InvokeAndClose", func(t *testing.T) {
+		app, cancel, exitCode := newTestApp()
+		pool := &testPool{}
+
+		app.Provide(
+			func() *testPool { return pool },
+			func(p *testPool) *testService { return newTestService() },
+		)
+
+		var svc *testService
+		err := app.Invoke(func(s *testService) {
+			svc = s
+			app.Add(s)
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		stop := startTestApp(t, app, cancel, exitCode)
+		<-svc.started
+		if pool.closed {
+			t.Error("Expected pool not to be closed while running")
+		}
+
+		if code := stop(); code != exitCodeOk {
+			t.Errorf("Expected exit code %d, got %d", exitCodeOk, code)
+		}
+		if !pool.closed {
+			t.Error("Expected pool to be closed on shutdown")
+		}
+	})
+
+	t.Run("InvalidConstructor", func(t *testing.T) {
+		app, cancel, exitCode := newTestApp()
+		defer cancel()
+
+		app.Provide(42)
+		if err := app.Invoke(func() {}); err == nil {
+			t.Error("Expected Invoke to report the invalid constructor")
+		}
+
+		app.Start()
+		if code := <-exitCode; code != exitCodeApplicationErr {
+			t.Errorf("Expected exit code %d, got %d", exitCodeApplicationErr, code)
+		}
+	})
+}
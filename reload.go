@@ -0,0 +1,267 @@
+package fastapp
+
+import (
+	"context"
+	"encoding/hex"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/katalabut/fast-app/config"
+	"github.com/katalabut/fast-app/configloader"
+	"github.com/katalabut/fast-app/logger"
+	"github.com/pkg/errors"
+)
+
+// ConfigReloader is implemented by services that can apply a new configuration
+// without a restart, e.g. timeouts or feature flags. The configuration is the
+// value returned by the loader set with WithConfigLoader or WithConfigFrom,
+// typically a pointer to the application configuration struct.
+type ConfigReloader interface {
+	ReloadConfig(ctx context.Context, cfg interface{}) error
+}
+
+// WithConfigLoader enables configuration reloads on SIGHUP and App.ReloadConfig.
+// The load function is called to obtain the fresh configuration, which is passed
+// to every service implementing ConfigReloader. If the configuration contains
+// a config.App field, the log level is updated as well.
+func WithConfigLoader(load func() (interface{}, error)) Option {
+	return optionFunc(
+		func(o *options) {
+			o.configLoader = load
+		},
+	)
+}
+
+// WithConfig sets the full application configuration, whose config.App field
+// is passed to New. Its hash is reported by the fastapp_config_hash_info metric
+// and at /info instead of the hash of config.App, and it is the baseline the
+// first reload is compared with.
+//
+// Example:
+//
+//	cfg, _ := configloader.New[AppConfig](configloader.WithFile("config.yaml"))
+//	app := fastapp.New(cfg.App, fastapp.WithConfig(cfg), fastapp.WithConfigFrom[AppConfig](configloader.WithFile("config.yaml")))
+func WithConfig(cfg interface{}) Option {
+	return optionFunc(
+		func(o *options) {
+			o.configCurrent = func() interface{} {
+				return cfg
+			}
+		},
+	)
+}
+
+// WithConfigFrom is like WithConfigLoader but re-runs configloader.New with the given options.
+//
+// Example:
+//
+//	cfg, _ := configloader.New[AppConfig](configloader.WithFile("config.yaml"))
+//	app := fastapp.New(cfg.App, fastapp.WithConfigFrom[AppConfig](configloader.WithFile("config.yaml")))
+func WithConfigFrom[T any](opts ...configloader.Option) Option {
+	return WithConfigLoader(func() (interface{}, error) {
+		return configloader.New[T](opts...)
+	})
+}
+
+// WithConfigWatcher reloads the services implementing ConfigReloader each time
+// the watcher's configuration changes, e.g. when its file is edited with
+// configloader.WithWatch. The services receive a pointer to the new configuration.
+// SIGHUP and App.ReloadConfig pass the current configuration of the watcher.
+//
+// Example:
+//
+//	w, _ := configloader.NewWatcher[AppConfig](configloader.WithFile("config.yaml"), configloader.WithWatch())
+//	app := fastapp.New(w.Config().App, fastapp.WithConfigWatcher(w))
+func WithConfigWatcher[T any](w *configloader.Watcher[T]) Option {
+	return optionFunc(
+		func(o *options) {
+			o.configLoader = func() (interface{}, error) {
+				cfg := w.Config()
+				return &cfg, nil
+			}
+			o.configCurrent = func() interface{} {
+				cfg := w.Config()
+				return &cfg
+			}
+			o.configWatch = func(reload func()) func() {
+				return w.OnChange(func(_, _ T) {
+					reload()
+				})
+			}
+		},
+	)
+}
+
+// ReloadConfig loads a fresh configuration and notifies the services implementing
+// ConfigReloader. All services are notified even if some fail; the first error is returned.
+// It is called automatically when the process receives SIGHUP.
+//
+// The keys changed since the previous configuration are logged, with secrets
+// masked, and the fastapp_config_reloads_total, fastapp_config_last_reload_timestamp_seconds
+// and fastapp_config_hash metrics are updated. The previous configuration is
+// the watcher's initial one with WithConfigWatcher, or the one loaded by the
+// first reload otherwise.
+func (a *App) ReloadConfig(ctx context.Context) error {
+	err := a.reloadConfig(ctx)
+	if err != nil {
+		a.logger.Errorw("Configuration reload failed", "error", err)
+	} else {
+		a.logger.Infow("Configuration reloaded", "hash", a.configs.stats().hash)
+	}
+	a.configs.recordReload(err == nil, a.opts.clock.Now())
+
+	a.events.Publish(Event{Type: EventConfigReloaded, Time: a.opts.clock.Now(), Err: err})
+	return err
+}
+
+func (a *App) reloadConfig(ctx context.Context) error {
+	if a.opts.configLoader == nil {
+		return errors.New("configuration reload is not enabled, see WithConfigLoader")
+	}
+
+	cfg, err := a.opts.configLoader()
+	if err != nil {
+		return errors.Wrap(err, "failed to load configuration")
+	}
+
+	if changes, ok := a.configs.set(cfg); ok && len(changes) > 0 {
+		a.logger.Infow("Configuration changed", "changes", changes)
+	}
+
+	var firstErr error
+
+	if appCfg, ok := appConfigOf(cfg); ok {
+		if appCfg.Logger.Level != "" {
+			if err := logger.SetLevel(appCfg.Logger.Level); err != nil {
+				firstErr = err
+			}
+		}
+		if err := logger.SetComponentLevels(appCfg.Logger.Levels); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	for _, r := range a.runners {
+		reloader, ok := r.service.(ConfigReloader)
+		if !ok {
+			continue
+		}
+
+		if err := reloader.ReloadConfig(ctx, cfg); err != nil {
+			a.logger.Errorw("Service failed to reload configuration", "service", r.name, "error", err)
+			if firstErr == nil {
+				firstErr = errors.Wrapf(err, "service %q", r.name)
+			}
+		}
+	}
+
+	return firstErr
+}
+
+var appConfigType = reflect.TypeOf(config.App{})
+
+// appConfigOf returns the application configuration contained in cfg: cfg itself
+// or a top-level field of a struct.
+func appConfigOf(cfg interface{}) (config.App, bool) {
+	v := reflect.ValueOf(cfg)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return config.App{}, false
+		}
+		v = v.Elem()
+	}
+
+	if v.Type() == appConfigType {
+		return v.Interface().(config.App), true
+	}
+	if v.Kind() != reflect.Struct {
+		return config.App{}, false
+	}
+
+	for i := 0; i < v.NumField(); i++ {
+		if f := v.Field(i); f.Type() == appConfigType && f.CanInterface() {
+			return f.Interface().(config.App), true
+		}
+	}
+
+	return config.App{}, false
+}
+
+// configState tracks the current configuration and its reloads for the
+// configuration metrics.
+type configState struct {
+	mu         sync.Mutex
+	current    interface{}
+	hash       string
+	successes  int
+	failures   int
+	lastReload time.Time
+}
+
+// configStats is a snapshot of a configState.
+type configStats struct {
+	hash       string
+	successes  int
+	failures   int
+	lastReload time.Time
+}
+
+// set replaces the current configuration, returning the keys changed and
+// whether there was a previous configuration to compare with.
+func (s *configState) set(cfg interface{}) ([]configloader.Change, bool) {
+	hash := configloader.Hash(cfg)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prev := s.current
+	s.current = cfg
+	s.hash = hash
+	if prev == nil {
+		return nil, false
+	}
+	return configloader.Diff(prev, cfg), true
+}
+
+// configHash returns the hash of the current configuration, the hash of the
+// application configuration if the full one is unknown.
+func (a *App) configHash() string {
+	if hash := a.configs.stats().hash; hash != "" {
+		return hash
+	}
+	return configloader.Hash(a.config)
+}
+
+func (s *configState) recordReload(success bool, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !success {
+		s.failures++
+		return
+	}
+	s.successes++
+	s.lastReload = now
+}
+
+func (s *configState) stats() configStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return configStats{hash: s.hash, successes: s.successes, failures: s.failures, lastReload: s.lastReload}
+}
+
+// hashValue returns the first 48 bits of the hash as a number, which a
+// float64 gauge holds exactly.
+func (s configStats) hashValue() float64 {
+	b, err := hex.DecodeString(s.hash)
+	if err != nil || len(b) < 6 {
+		return 0
+	}
+
+	var v uint64
+	for _, c := range b[:6] {
+		v = v<<8 | uint64(c)
+	}
+	return float64(v)
+}
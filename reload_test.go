@@ -0,0 +1,257 @@
+package fastapp
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/katalabut/fast-app/config"
+	"github.com/katalabut/fast-app/configloader"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type reloadConfig struct {
+	App     config.App
+	Timeout int
+	Token   config.Secret
+}
+
+type reloadingService struct {
+	*testService
+
+	mu       sync.Mutex
+	reloaded []interface{}
+	err      error
+}
+
+func (s *reloadingService) ReloadConfig(ctx context.Context, cfg interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reloaded = append(s.reloaded, cfg)
+	return s.err
+}
+
+func (s *reloadingService) reloads() []interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]interface{}(nil), s.reloaded...)
+}
+
+func TestReloadConfig(t *testing.T) {
+	t.Run("NotifiesServices", func(t *testing.T) {
+		loads := 0
+		app, _, _ := newTestApp(WithConfigLoader(func() (interface{}, error) {
+			loads++
+			return &reloadConfig{Timeout: loads}, nil
+		}))
+
+		svc := &reloadingService{testService: newTestService()}
+		app.Add(svc)
+		app.Add(newTestService())
+
+		var events []Event
+		app.Events().Subscribe(EventConfigReloaded, func(e Event) {
+			events = append(events, e)
+		})
+
+		if err := app.ReloadConfig(context.Background()); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		reloads := svc.reloads()
+		if len(reloads) != 1 {
+			t.Fatalf("Expected 1 reload, got %d", len(reloads))
+		}
+		if cfg, ok := reloads[0].(*reloadConfig); !ok || cfg.Timeout != 1 {
+			t.Errorf("Expected fresh configuration, got %+v", reloads[0])
+		}
+		if len(events) != 1 || events[0].Err != nil {
+			t.Errorf("Expected a successful reload event, got %+v", events)
+		}
+	})
+
+	t.Run("ServiceError", func(t *testing.T) {
+		app, _, _ := newTestApp(WithConfigLoader(func() (interface{}, error) {
+			return &reloadConfig{}, nil
+		}))
+
+		failing := &reloadingService{testService: newTestService(), err: errors.New("bad flag")}
+		other := &reloadingService{testService: newTestService()}
+		app.Add(failing, WithName("failing"))
+		app.Add(other, WithName("other"))
+
+		var events []Event
+		app.Events().Subscribe(EventConfigReloaded, func(e Event) {
+			events = append(events, e)
+		})
+
+		err := app.ReloadConfig(context.Background())
+		if err == nil {
+			t.Fatal("Expected reload error")
+		}
+		if len(other.reloads()) != 1 {
+			t.Error("Expected other services to be notified despite the error")
+		}
+		if len(events) != 1 || events[0].Err == nil {
+			t.Errorf("Expected a failed reload event, got %+v", events)
+		}
+	})
+
+	t.Run("LoadError", func(t *testing.T) {
+		app, _, _ := newTestApp(WithConfigLoader(func() (interface{}, error) {
+			return nil, errors.New("invalid yaml")
+		}))
+
+		svc := &reloadingService{testService: newTestService()}
+		app.Add(svc)
+
+		if err := app.ReloadConfig(context.Background()); err == nil {
+			t.Error("Expected load error")
+		}
+		if len(svc.reloads()) != 0 {
+			t.Error("Expected services not to be notified when loading fails")
+		}
+	})
+
+	t.Run("Watcher", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		if err := os.WriteFile(path, []byte("timeout: 1\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		w, err := configloader.NewWatcher[reloadConfig](configloader.WithoutEnv(), configloader.WithFile(path))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		defer w.Close()
+
+		app, cancel, exitCode := newTestApp(WithConfigWatcher(w))
+		svc := &reloadingService{testService: newTestService()}
+		app.Add(svc)
+
+		stop := startTestApp(t, app, cancel, exitCode)
+		<-svc.started
+
+		if err := os.WriteFile(path, []byte("timeout: 2\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Reload(); err != nil {
+			t.Fatalf("Unexpected reload error: %v", err)
+		}
+
+		reloads := svc.reloads()
+		if len(reloads) != 1 {
+			t.Fatalf("Expected 1 reload, got %d", len(reloads))
+		}
+		if cfg, ok := reloads[0].(*reloadConfig); !ok || cfg.Timeout != 2 {
+			t.Errorf("Expected the watched configuration, got %+v", reloads[0])
+		}
+
+		stop()
+	})
+
+	t.Run("DiffAndMetrics", func(t *testing.T) {
+		loads := []*reloadConfig{
+			{Timeout: 1, Token: "first"},
+			{Timeout: 2, Token: "second"},
+		}
+		var next *reloadConfig
+		core, logs := observer.New(zap.InfoLevel)
+		app, _, _ := newTestApp(
+			WithLogger(zap.New(core).Sugar()),
+			WithConfigLoader(func() (interface{}, error) {
+				if next == nil {
+					return nil, errors.New("invalid yaml")
+				}
+				return next, nil
+			}),
+		)
+
+		reg := prometheus.NewRegistry()
+		reg.MustRegister(newServiceCollector(app))
+
+		if _, ok := gatherMetric(t, reg, "fastapp_config_hash", nil); ok {
+			t.Error("Expected no configuration hash before the first reload")
+		}
+
+		for _, cfg := range loads {
+			next = cfg
+			if err := app.ReloadConfig(context.Background()); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}
+		next = nil
+		if err := app.ReloadConfig(context.Background()); err == nil {
+			t.Fatal("Expected load error")
+		}
+
+		changed := logs.FilterMessage("Configuration changed").All()
+		if len(changed) != 1 {
+			t.Fatalf("Expected 1 configuration change log, got %d", len(changed))
+		}
+		changes, _ := changed[0].ContextMap()["changes"].([]configloader.Change)
+		expected := []configloader.Change{
+			{Key: "Timeout", Old: 1, New: 2},
+			{Key: "Token", Old: "***", New: "***"},
+		}
+		if !reflect.DeepEqual(changes, expected) {
+			t.Errorf("Expected changes %+v, got %+v", expected, changed[0].ContextMap()["changes"])
+		}
+
+		if v, _ := gatherMetric(t, reg, "fastapp_config_reloads_total", map[string]string{"result": "success"}); v != 2 {
+			t.Errorf("Expected 2 successful reloads, got %v", v)
+		}
+		if v, _ := gatherMetric(t, reg, "fastapp_config_reloads_total", map[string]string{"result": "failure"}); v != 1 {
+			t.Errorf("Expected 1 failed reload, got %v", v)
+		}
+		if v, _ := gatherMetric(t, reg, "fastapp_config_last_reload_timestamp_seconds", nil); v == 0 {
+			t.Error("Expected the last reload time to be set")
+		}
+		if v, ok := gatherMetric(t, reg, "fastapp_config_hash", nil); !ok || v == 0 {
+			t.Errorf("Expected a configuration hash, got %v", v)
+		}
+	})
+
+	t.Run("NotEnabled", func(t *testing.T) {
+		app, _, _ := newTestApp()
+
+		if err := app.ReloadConfig(context.Background()); err == nil {
+			t.Error("Expected error without a configuration loader")
+		}
+	})
+}
+
+func TestAppConfigOf(t *testing.T) {
+	appCfg := config.App{Logger: config.Logger{Level: "debug"}}
+
+	tests := []struct {
+		name string
+		cfg  interface{}
+		ok   bool
+	}{
+		{"App", appCfg, true},
+		{"AppPointer", &appCfg, true},
+		{"Field", reloadConfig{App: appCfg}, true},
+		{"FieldPointer", &reloadConfig{App: appCfg}, true},
+		{"NilPointer", (*reloadConfig)(nil), false},
+		{"Other", "config", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := appConfigOf(tt.cfg)
+			if ok != tt.ok {
+				t.Fatalf("Expected ok %v, got %v", tt.ok, ok)
+			}
+			if ok && got.Logger.Level != "debug" {
+				t.Errorf("Expected level debug, got %q", got.Logger.Level)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package fastapp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrAppNotRunning is returned when an operation requires a running application.
+	ErrAppNotRunning = errors.New("application is not running")
+
+	// ErrServiceNotFound is returned when no service with the given name is registered.
+	ErrServiceNotFound = errors.New("service not found")
+
+	// ErrServiceNotRunning is returned when a service must be running but is not.
+	ErrServiceNotRunning = errors.New("service is not running")
+)
+
+// Restart shuts down a single running service and starts it again without
+// restarting the process, e.g. to bounce a wedged consumer. The service has its
+// context cancelled and its Shutdown method called within the shutdown timeout,
+// then Run is invoked again once its dependencies are ready. Services that
+// support restarts must allow Run to be called again after Shutdown.
+//
+// Restart returns once the service has stopped; it is started again in the background.
+func (a *App) Restart(name string) error {
+	ctx := a.runningContext()
+	if ctx == nil || ctx.Err() != nil {
+		return ErrAppNotRunning
+	}
+
+	r, ok := a.runnerByName(name)
+	if !ok {
+		return errors.Wrapf(ErrServiceNotFound, "service %q", name)
+	}
+
+	if st := r.status(); st.state != StateRunning {
+		return errors.Wrapf(ErrServiceNotRunning, "service %q is %s", name, st.state)
+	}
+
+	a.logger.Infow("Restart requested", "service", name)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), a.opts.shutdownTimeout)
+	defer cancel()
+
+	r.requestRestart()
+	if err := a.stopRunner(shutdownCtx, r); err != nil {
+		return errors.Wrap(err, "failed to stop service for restart")
+	}
+
+	return nil
+}
+
+// setRunning stores the context of the running application.
+func (a *App) setRunning(ctx context.Context) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.running = ctx
+}
+
+// runningContext returns the context of the running application, or nil if it has not been started.
+func (a *App) runningContext() context.Context {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.running
+}
+
+// handleRestart handles service restart requests, authorized with the admin
+// token by the observability server.
+func (a *App) handleRestart(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+
+	response := map[string]interface{}{
+		"service":   name,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	}
+
+	statusCode := http.StatusAccepted
+	if err := a.Restart(name); err != nil {
+		response["error"] = err.Error()
+
+		switch {
+		case errors.Is(err, ErrServiceNotFound):
+			statusCode = http.StatusNotFound
+		case errors.Is(err, ErrServiceNotRunning), errors.Is(err, ErrAppNotRunning):
+			statusCode = http.StatusConflict
+		default:
+			statusCode = http.StatusInternalServerError
+		}
+	} else {
+		response["status"] = "restarting"
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(response)
+}
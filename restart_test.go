@@ -0,0 +1,156 @@
+package fastapp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/katalabut/fast-app/config"
+)
+
+type restartableService struct {
+	runs     atomic.Int32
+	started  chan struct{}
+	shutdown atomic.Int32
+}
+
+func newRestartableService() *restartableService {
+	return &restartableService{started: make(chan struct{}, 10)}
+}
+
+func (s *restartableService) Run(ctx context.Context) error {
+	s.runs.Add(1)
+	s.started <- struct{}{}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (s *restartableService) Shutdown(ctx context.Context) error {
+	s.shutdown.Add(1)
+	return nil
+}
+
+func TestRestart(t *testing.T) {
+	t.Run("RestartsService", func(t *testing.T) {
+		app, cancel, exitCode := newTestApp()
+		svc := newRestartableService()
+		app.Add(svc, WithName("consumer"))
+		app.Add(newTestService(), WithName("api"))
+		stop := startTestApp(t, app, cancel, exitCode)
+
+		<-svc.started
+		if err := app.Restart("consumer"); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		select {
+		case <-svc.started:
+		case <-time.After(5 * time.Second):
+			t.Fatal("Expected service to be started again")
+		}
+
+		if runs := svc.runs.Load(); runs != 2 {
+			t.Errorf("Expected 2 runs, got %d", runs)
+		}
+		if info := app.Services()[0]; info.Restarts != 1 || info.State != StateRunning {
+			t.Errorf("Expected running service with 1 restart, got %+v", info)
+		}
+
+		if code := stop(); code != exitCodeOk {
+			t.Errorf("Expected exit code %d, got %d", exitCodeOk, code)
+		}
+		if n := svc.shutdown.Load(); n != 2 {
+			t.Errorf("Expected 2 shutdowns, got %d", n)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		app, cancel, exitCode := newTestApp()
+		svc := newRestartableService()
+		app.Add(svc, WithName("consumer"))
+
+		if err := app.Restart("consumer"); !errors.Is(err, ErrAppNotRunning) {
+			t.Errorf("Expected ErrAppNotRunning, got %v", err)
+		}
+
+		stop := startTestApp(t, app, cancel, exitCode)
+		<-svc.started
+
+		if err := app.Restart("missing"); !errors.Is(err, ErrServiceNotFound) {
+			t.Errorf("Expected ErrServiceNotFound, got %v", err)
+		}
+
+		stop()
+	})
+
+	t.Run("Endpoint", func(t *testing.T) {
+		app, cancel, exitCode := newTestApp()
+		svc := newRestartableService()
+		app.Add(svc, WithName("consumer"))
+		stop := startTestApp(t, app, cancel, exitCode)
+		<-svc.started
+
+		for name, want := range map[string]int{"consumer": http.StatusAccepted, "missing": http.StatusNotFound} {
+			req := httptest.NewRequest(http.MethodPost, "/debug/services/"+name+"/restart", nil)
+			req.SetPathValue("name", name)
+			rec := httptest.NewRecorder()
+			app.handleRestart(rec, req)
+
+			if rec.Code != want {
+				t.Errorf("Expected status %d for %s, got %d", want, name, rec.Code)
+			}
+		}
+
+		stop()
+	})
+
+	t.Run("EndpointAuthorization", func(t *testing.T) {
+		client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+		for token, want := range map[string]map[string]int{
+			"":       {"": http.StatusNotFound, "secret": http.StatusNotFound},
+			"secret": {"": http.StatusUnauthorized, "wrong": http.StatusUnauthorized, "secret": http.StatusAccepted},
+		} {
+			ctx, cancel := context.WithCancel(context.Background())
+			exitCode := make(chan int, 2)
+			app := New(
+				Config{Observability: config.Observability{
+					Enabled: true,
+					Debug:   config.Debug{Enabled: true, PathPrefix: "/debug"},
+					Health:  config.Health{AdminToken: config.Secret(token)},
+				}},
+				WithContext(ctx),
+				WithExitFunc(func(code int) { exitCode <- code }),
+			)
+			svc := newRestartableService()
+			app.Add(svc, WithName("consumer"))
+			stop := startTestApp(t, app, cancel, exitCode)
+			<-svc.started
+
+			deadline := time.Now().Add(5 * time.Second)
+			for app.ObservabilityAddr() == "" && time.Now().Before(deadline) {
+				time.Sleep(10 * time.Millisecond)
+			}
+
+			for bearer, status := range want {
+				req, _ := http.NewRequest(http.MethodPost, "http://"+app.ObservabilityAddr()+"/debug/services/consumer/restart", nil)
+				if bearer != "" {
+					req.Header.Set("Authorization", "Bearer "+bearer)
+				}
+				resp, err := client.Do(req)
+				if err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+				resp.Body.Close()
+				if resp.StatusCode != status {
+					t.Errorf("Expected status %d with admin token %q and bearer %q, got %d", status, token, bearer, resp.StatusCode)
+				}
+			}
+
+			stop()
+		}
+	})
+}
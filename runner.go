@@ -0,0 +1,470 @@
+package fastapp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/katalabut/fast-app/clock"
+	"github.com/katalabut/fast-app/health"
+	"github.com/pkg/errors"
+)
+
+// readinessPollInterval is how often a dependent service polls
+// the readiness of its dependencies before starting.
+const readinessPollInterval = 100 * time.Millisecond
+
+// ServiceState is the lifecycle state of a registered service.
+type ServiceState string
+
+const (
+	// StateStarting means the service is waiting for its dependencies or for Start to be called.
+	StateStarting ServiceState = "starting"
+	// StateRunning means the service Run method has been invoked and has not returned.
+	StateRunning ServiceState = "running"
+	// StateStopping means the service is being shut down.
+	StateStopping ServiceState = "stopping"
+	// StateStopped means the service Run method has returned without an error.
+	StateStopped ServiceState = "stopped"
+	// StateFailed means the service Run method has returned an error or panicked.
+	StateFailed ServiceState = "failed"
+	// StateCompleted means a job has run to completion without an error.
+	StateCompleted ServiceState = "completed"
+)
+
+// serviceStates lists all service states, e.g. to export them as metrics.
+var serviceStates = []ServiceState{StateStarting, StateRunning, StateStopping, StateStopped, StateFailed, StateCompleted}
+
+// Namer may be implemented by a service to provide its default name.
+// A name set with WithName takes precedence.
+type Namer interface {
+	Name() string
+}
+
+// Runner wraps a service for execution within the application.
+type Runner struct {
+	service   Service
+	name      string
+	dependsOn []string
+	phase     Phase
+	job       bool
+
+	startupDeadline time.Duration
+	startupPolicy   StartupPolicy
+	readinessGate   func() bool
+	healthChecks    []string
+
+	clock       clock.Clock
+	startedOnce sync.Once
+	started     chan struct{}
+
+	mu             sync.RWMutex
+	state          ServiceState
+	startedAt      time.Time
+	restarts       int
+	lastErr        error
+	lastErrAt      time.Time
+	cancelRun      context.CancelFunc
+	finished       chan struct{} // closed when the current run returns
+	restartPending bool
+}
+
+// runnerStatus is a point-in-time snapshot of a runner.
+type runnerStatus struct {
+	state     ServiceState
+	startedAt time.Time
+	restarts  int
+	lastErr   error
+	lastErrAt time.Time
+}
+
+type serviceOptions struct {
+	name      string
+	dependsOn []string
+	phase     Phase
+
+	startupDeadline time.Duration
+	startupPolicy   StartupPolicy
+	readinessGate   func() bool
+}
+
+type serviceOptionFunc func(*serviceOptions)
+
+func (f serviceOptionFunc) apply(o *serviceOptions) {
+	f(o)
+}
+
+// ServiceOption is a functional option for a service registered with App.Add.
+type ServiceOption interface {
+	apply(o *serviceOptions)
+}
+
+// WithName sets the name of the service. Names are used to declare dependencies
+// between services and must be unique within the application.
+// By default the name is derived from the service type.
+func WithName(name string) ServiceOption {
+	return serviceOptionFunc(
+		func(o *serviceOptions) {
+			o.name = name
+		},
+	)
+}
+
+// DependsOn declares that the service must only be started once the named
+// services are ready. A dependency is ready when it is running and its readiness
+// gate or, if it implements health.ReadinessController, IsReady() reports true.
+//
+// Example:
+//
+//	app.Add(db, fastapp.WithName("database"))
+//	app.Add(api, fastapp.DependsOn("database"))
+func DependsOn(names ...string) ServiceOption {
+	return serviceOptionFunc(
+		func(o *serviceOptions) {
+			o.dependsOn = append(o.dependsOn, names...)
+		},
+	)
+}
+
+func newRunner(svc Service, opts serviceOptions, clk clock.Clock) *Runner {
+	return &Runner{
+		clock:     clk,
+		state:     StateStarting,
+		service:   svc,
+		name:      opts.name,
+		dependsOn: opts.dependsOn,
+		phase:     opts.phase,
+		started:   make(chan struct{}),
+		finished:  make(chan struct{}),
+
+		startupDeadline: opts.startupDeadline,
+		startupPolicy:   opts.startupPolicy,
+		readinessGate:   opts.readinessGate,
+	}
+}
+
+// prepare creates the context passed to the service Run method. It carries the
+// values of ctx but is only cancelled when the service itself is being stopped,
+// so that services can be stopped one at a time.
+func (r *Runner) prepare(ctx context.Context) context.Context {
+	runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+
+	r.mu.Lock()
+	r.cancelRun = cancel
+	r.mu.Unlock()
+
+	return runCtx
+}
+
+// requestRestart marks the service to be started again once its current run returns.
+func (r *Runner) requestRestart() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.restartPending = true
+}
+
+// restart prepares a new run of the service if a restart has been requested
+// and the application is not shutting down. It returns the context for the new run.
+func (r *Runner) restart(ctx context.Context) (context.Context, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.restartPending || ctx.Err() != nil {
+		return nil, false
+	}
+	r.restartPending = false
+
+	// Checking ctx and replacing cancelRun under the lock guarantees that a
+	// concurrent application shutdown cancels the new run.
+	runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	r.cancelRun = cancel
+	r.finished = make(chan struct{})
+	r.state = StateStarting
+	r.restarts++
+
+	return runCtx, true
+}
+
+// done returns a channel that is closed when the current run of the service returns.
+func (r *Runner) done() <-chan struct{} {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.finished
+}
+
+// cancel cancels the context passed to the service Run method.
+func (r *Runner) cancel() {
+	r.mu.RLock()
+	cancel := r.cancelRun
+	r.mu.RUnlock()
+
+	if cancel != nil {
+		cancel()
+	}
+}
+
+// Name returns the name of the service.
+func (r *Runner) Name() string {
+	return r.name
+}
+
+// markStarted records that the service Run method has been invoked.
+func (r *Runner) markStarted() {
+	r.mu.Lock()
+	r.state = StateRunning
+	r.startedAt = r.clock.Now()
+	r.mu.Unlock()
+
+	r.startedOnce.Do(func() { close(r.started) })
+}
+
+// markStopping records that the service is being shut down.
+func (r *Runner) markStopping() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.state == StateStarting || r.state == StateRunning {
+		r.state = StateStopping
+	}
+}
+
+// markFinished records that the service Run method has returned with the given error.
+func (r *Runner) markFinished(err error) {
+	r.mu.Lock()
+	r.lastErr = err
+	switch {
+	case err != nil:
+		r.state = StateFailed
+		r.lastErrAt = r.clock.Now()
+	case r.job && r.state == StateRunning:
+		r.state = StateCompleted
+	default:
+		r.state = StateStopped
+	}
+
+	select {
+	case <-r.finished:
+	default:
+		close(r.finished)
+	}
+	r.mu.Unlock()
+}
+
+// err returns the error the service Run method returned, if any.
+func (r *Runner) err() error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.lastErr
+}
+
+// status returns a snapshot of the runner state.
+func (r *Runner) status() runnerStatus {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return runnerStatus{
+		state:     r.state,
+		startedAt: r.startedAt,
+		restarts:  r.restarts,
+		lastErr:   r.lastErr,
+		lastErrAt: r.lastErrAt,
+	}
+}
+
+// hasStarted reports whether the service Run method has been invoked.
+func (r *Runner) hasStarted() bool {
+	select {
+	case <-r.started:
+		return true
+	default:
+		return false
+	}
+}
+
+// isReady reports whether dependents of the service may start.
+func (r *Runner) isReady() bool {
+	if !r.hasStarted() {
+		return false
+	}
+
+	select {
+	case <-r.done():
+		// A service that completed its work has nothing left to wait for,
+		// while a failed one will never become ready.
+		if r.job {
+			return r.status().state == StateCompleted
+		}
+		return r.err() == nil
+	default:
+	}
+
+	if r.job {
+		// A job is only ready once it has completed.
+		return false
+	}
+
+	if r.readinessGate != nil {
+		return r.readinessGate()
+	}
+
+	if rc, ok := r.service.(health.ReadinessController); ok {
+		return rc.IsReady()
+	}
+
+	return true
+}
+
+// shutdown stops the service if it has been started.
+func (r *Runner) shutdown(ctx context.Context) error {
+	if !r.hasStarted() {
+		return nil
+	}
+
+	return r.service.Shutdown(ctx)
+}
+
+// defaultServiceName derives a service name from its type, e.g. "*main.APIService" -> "APIService".
+func defaultServiceName(svc interface{}) string {
+	name := fmt.Sprintf("%T", svc)
+	name = strings.TrimLeft(name, "*")
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+
+	return name
+}
+
+// runnerByName returns the registered runner with the given name.
+func (a *App) runnerByName(name string) (*Runner, bool) {
+	for _, r := range a.runners {
+		if r.name == name {
+			return r, true
+		}
+	}
+
+	return nil, false
+}
+
+// uniqueServiceName returns name, or name with a numeric suffix if it is already taken.
+func (a *App) uniqueServiceName(name string) string {
+	if _, exists := a.runnerByName(name); !exists {
+		return name
+	}
+
+	for i := 2; ; i++ {
+		candidate := fmt.Sprintf("%s-%d", name, i)
+		if _, exists := a.runnerByName(candidate); !exists {
+			return candidate
+		}
+	}
+}
+
+// validateDependencies checks that service names are unique, that every
+// dependency refers to a registered service and that there are no cycles.
+func (a *App) validateDependencies() error {
+	seen := make(map[string]struct{}, len(a.runners))
+	for _, r := range a.runners {
+		if _, exists := seen[r.name]; exists {
+			return errors.Errorf("duplicate service name %q", r.name)
+		}
+		seen[r.name] = struct{}{}
+	}
+
+	for _, r := range a.runners {
+		for _, dep := range r.dependsOn {
+			d, exists := a.runnerByName(dep)
+			if !exists {
+				return errors.Errorf("service %q depends on unknown service %q", r.name, dep)
+			}
+			if d.phase > r.phase {
+				return errors.Errorf("service %q in phase %s depends on service %q in later phase %s", r.name, r.phase, dep, d.phase)
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(a.runners))
+
+	var visit func(r *Runner, path []string) error
+	visit = func(r *Runner, path []string) error {
+		switch state[r.name] {
+		case visiting:
+			return errors.Errorf("dependency cycle detected: %s", strings.Join(append(path, r.name), " -> "))
+		case visited:
+			return nil
+		}
+
+		state[r.name] = visiting
+		for _, dep := range r.dependsOn {
+			d, _ := a.runnerByName(dep)
+			if err := visit(d, append(path, r.name)); err != nil {
+				return err
+			}
+		}
+		state[r.name] = visited
+
+		return nil
+	}
+
+	for _, r := range a.runners {
+		if err := visit(r, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// dependencies returns the runners that must be ready before r is started:
+// its declared dependencies and every service in an earlier phase.
+func (a *App) dependencies(r *Runner) []*Runner {
+	var deps []*Runner
+
+	for _, other := range a.runners {
+		if other.phase < r.phase {
+			deps = append(deps, other)
+		}
+	}
+
+	for _, name := range r.dependsOn {
+		if dep, ok := a.runnerByName(name); ok && dep.phase == r.phase {
+			deps = append(deps, dep)
+		}
+	}
+
+	return deps
+}
+
+// waitForDependencies blocks until all dependencies of the runner are ready
+// or the context is cancelled.
+func (a *App) waitForDependencies(ctx context.Context, r *Runner) error {
+	deps := a.dependencies(r)
+	if len(deps) == 0 {
+		return nil
+	}
+
+	ticker := a.opts.clock.NewTicker(readinessPollInterval)
+	defer ticker.Stop()
+
+	for _, dep := range deps {
+		if !dep.isReady() {
+			a.logger.Debugw("Waiting for dependency", "service", r.name, "dependency", dep.name)
+		}
+
+		for !dep.isReady() {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-ticker.C():
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"net/http"
+	"sort"
+)
+
+// Endpoint groups of the observability server, each served on the main
+// address unless configured with its own.
+const (
+	groupMain    = "main"
+	groupHealth  = "health"
+	groupMetrics = "metrics"
+	groupDebug   = "debug"
+)
+
+// listeners assigns the endpoint groups to the addresses they are served on,
+// with a mux per address.
+type listeners struct {
+	mainAddr string
+	// addresses are in the order they were first used, the main one first.
+	addresses []string
+	muxes     map[string]*http.ServeMux
+	groups    map[string]string
+}
+
+func newListeners(mainAddr string) *listeners {
+	return &listeners{
+		mainAddr:  mainAddr,
+		addresses: []string{mainAddr},
+		muxes:     map[string]*http.ServeMux{mainAddr: http.NewServeMux()},
+		groups:    map[string]string{groupMain: mainAddr},
+	}
+}
+
+// mux returns the mux of the address of a group, the main one when empty.
+func (l *listeners) mux(group, address string) *http.ServeMux {
+	if address == "" {
+		address = l.mainAddr
+	}
+	l.groups[group] = address
+
+	mux, ok := l.muxes[address]
+	if !ok {
+		mux = http.NewServeMux()
+		l.muxes[address] = mux
+		l.addresses = append(l.addresses, address)
+	}
+	return mux
+}
+
+// groupsOf returns the groups served on an address, sorted.
+func (l *listeners) groupsOf(address string) []string {
+	var groups []string
+	for group, groupAddress := range l.groups {
+		if groupAddress == address {
+			groups = append(groups, group)
+		}
+	}
+	sort.Strings(groups)
+	return groups
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/katalabut/fast-app/config"
+	"github.com/katalabut/fast-app/health"
+)
+
+func TestObservabilityAddresses(t *testing.T) {
+	s := NewObservabilityService(config.Observability{
+		Enabled: true,
+		Metrics: config.Metrics{Enabled: true, Path: "/metrics"},
+		Health: config.Health{
+			Enabled:   true,
+			Address:   "127.0.0.1:0",
+			LivePath:  "/health/live",
+			ReadyPath: "/health/ready",
+			CheckPath: "/health/checks",
+		},
+		Debug: config.Debug{Enabled: true, PathPrefix: "/debug", Address: "localhost:0"},
+	}, health.NewManager(health.ManagerConfig{}))
+	s.HandleDebug("/debug/services", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.Run(ctx) }()
+	defer func() {
+		_ = s.Shutdown(context.Background())
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for s.Addr() == "" && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if s.HealthAddr() == s.Addr() || s.DebugAddr() == s.Addr() || s.HealthAddr() == s.DebugAddr() {
+		t.Fatalf("Expected health and debug on their own addresses, got %s, %s and %s", s.Addr(), s.HealthAddr(), s.DebugAddr())
+	}
+	if s.MetricsAddr() != s.Addr() {
+		t.Errorf("Expected metrics on the main address %s, got %s", s.Addr(), s.MetricsAddr())
+	}
+
+	tests := []struct {
+		addr   string
+		path   string
+		status int
+	}{
+		{s.HealthAddr(), "/health/live", http.StatusOK},
+		{s.Addr(), "/health/live", http.StatusNotFound},
+		{s.Addr(), "/metrics", http.StatusOK},
+		{s.HealthAddr(), "/metrics", http.StatusNotFound},
+		{s.DebugAddr(), "/debug/services", http.StatusOK},
+		{s.Addr(), "/debug/services", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		resp, err := http.Get("http://" + tt.addr + tt.path)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tt.status {
+			t.Errorf("Expected status code %d for %s on %s, got %d", tt.status, tt.path, tt.addr, resp.StatusCode)
+		}
+	}
+}
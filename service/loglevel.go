@@ -0,0 +1,84 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/katalabut/fast-app/logger"
+)
+
+// logLevelRequest is the body of a log level change.
+type logLevelRequest struct {
+	Level string `json:"level"`
+	// TTL is how long the level is kept before the previous one is
+	// restored, e.g. "15m", or empty to keep it.
+	TTL string `json:"ttl"`
+	// Component, when set, is the name of the component whose level is
+	// changed, see logger.Named; an empty level restores the global one.
+	Component string `json:"component"`
+}
+
+// handleLogLevel handles log level requests: GET returns the current level
+// and those of components and PUT changes one of them, the global level
+// optionally for a limited time.
+func (s *ObservabilityService) handleLogLevel(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		writeJSON(w, http.StatusUnauthorized, map[string]interface{}{"error": "unauthorized"})
+		return
+	}
+
+	response := map[string]interface{}{}
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		var req logLevelRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]interface{}{"error": "invalid request body: " + err.Error()})
+			return
+		}
+
+		if req.Component != "" {
+			if req.TTL != "" {
+				writeJSON(w, http.StatusBadRequest, map[string]interface{}{"error": "ttl is not supported for components"})
+				return
+			}
+			if err := logger.SetComponentLevel(req.Component, req.Level); err != nil {
+				writeJSON(w, http.StatusBadRequest, map[string]interface{}{"error": "level must be debug, info, warn, error, dpanic, panic, fatal or empty"})
+				return
+			}
+			logger.InfoKV(r.Context(), "Component log level changed", "component", req.Component, "level", req.Level)
+			break
+		}
+
+		var ttl time.Duration
+		if req.TTL != "" {
+			var err error
+			ttl, err = time.ParseDuration(req.TTL)
+			if err != nil || ttl <= 0 {
+				writeJSON(w, http.StatusBadRequest, map[string]interface{}{"error": "ttl must be a positive duration, e.g. 15m"})
+				return
+			}
+		}
+
+		previous := logger.Level()
+		if err := logger.SetLevelFor(req.Level, ttl); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]interface{}{"error": "level must be debug, info, warn, error, dpanic, panic or fatal"})
+			return
+		}
+		logger.InfoKV(r.Context(), "Log level changed", "previous", previous, "level", logger.Level(), "ttl", req.TTL)
+		if ttl > 0 {
+			response["revert_at"] = time.Now().Add(ttl).UTC().Format(time.RFC3339)
+		}
+	default:
+		w.Header().Set("Allow", "GET, PUT")
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]interface{}{"error": "method not allowed"})
+		return
+	}
+
+	response["level"] = logger.Level()
+	response["components"] = logger.ComponentLevels()
+	response["timestamp"] = time.Now().UTC().Format(time.RFC3339)
+	writeJSON(w, http.StatusOK, response)
+}
@@ -3,25 +3,45 @@ package service
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"net"
 	"net/http"
 	_ "net/http/pprof" // Register pprof handlers
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/katalabut/fast-app/config"
 	"github.com/katalabut/fast-app/health"
 	"github.com/katalabut/fast-app/logger"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"golang.org/x/sync/errgroup"
 )
 
 // ObservabilityService provides a unified HTTP server for metrics, health checks, and debugging.
-// It combines all observability endpoints on a single port to reduce resource usage.
+// It combines all observability endpoints on a single port to reduce resource usage,
+// unless the health, metrics or debug endpoints are configured with their own address.
 type ObservabilityService struct {
 	config        config.Observability
 	healthManager *health.Manager
-	server        *http.Server
+	gatherer      prometheus.Gatherer
+
+	handlers []handler
+
+	mu      sync.RWMutex
+	servers []*http.Server
+	// addrs are the addresses each endpoint group is listening on.
+	addrs map[string]string
+}
+
+type handler struct {
+	pattern string
+	handler http.Handler
+	debug   bool
 }
 
 // NewObservabilityService creates a new observability service with the given configuration.
@@ -44,54 +64,200 @@ func (s *ObservabilityService) Run(ctx context.Context) error {
 		return nil
 	}
 
-	mux := http.NewServeMux()
+	mainAddr := fmt.Sprintf(":%d", s.config.Port)
+	l := newListeners(mainAddr)
+	mux := l.mux(groupMain, "")
 
 	// Register metrics endpoint
-	if s.config.Metrics.Enabled {
-		mux.Handle(s.config.Metrics.Path, promhttp.Handler())
+	if s.config.Metrics.Enabled && s.config.Metrics.Exporter != config.MetricsExporterOTLP {
+		l.mux(groupMetrics, s.config.Metrics.Address).Handle(s.config.Metrics.Path, s.metricsHandler())
 		logger.InfoKV(ctx, "Registered metrics endpoint", "path", s.config.Metrics.Path)
 	}
 
 	// Register health check endpoints
 	if s.config.Health.Enabled {
-		s.registerHealthEndpoints(mux)
+		s.registerHealthEndpoints(l.mux(groupHealth, s.config.Health.Address))
+	}
+
+	// Register log level endpoint, only available with an admin token
+	if s.config.LogLevelPath != "" && s.config.Health.AdminToken.IsSet() {
+		mux.HandleFunc(s.config.LogLevelPath, s.handleLogLevel)
+		logger.InfoKV(ctx, "Registered log level endpoint", "path", s.config.LogLevelPath)
 	}
 
 	// Register debug endpoints (pprof is automatically registered via import)
 	if s.config.Debug.Enabled {
-		s.registerDebugEndpoints(mux)
+		s.registerDebugEndpoints(l.mux(groupDebug, s.config.Debug.Address))
 	}
 
-	s.server = &http.Server{
-		Addr:         fmt.Sprintf(":%d", s.config.Port),
-		Handler:      mux,
-		ReadTimeout:  10 * time.Second,
-		WriteTimeout: 10 * time.Second,
-		IdleTimeout:  120 * time.Second,
+	// Register additional endpoints
+	for _, h := range s.handlers {
+		if h.debug {
+			l.mux(groupDebug, s.config.Debug.Address).Handle(h.pattern, h.handler)
+		} else {
+			mux.Handle(h.pattern, h.handler)
+		}
+		logger.InfoKV(ctx, "Registered observability endpoint", "path", h.pattern)
 	}
 
-	logger.InfoKV(ctx, "Starting observability server",
-		"address", s.server.Addr,
-		"metrics_enabled", s.config.Metrics.Enabled,
-		"health_enabled", s.config.Health.Enabled,
-		"debug_enabled", s.config.Debug.Enabled,
-	)
+	var reloader *tlsReloader
+	if s.config.TLS.Enabled() {
+		var err error
+		if reloader, err = newTLSReloader(s.config.TLS); err != nil {
+			return err
+		}
+	}
+
+	servers := make([]*http.Server, 0, len(l.addresses))
+	listeners := make([]net.Listener, 0, len(l.addresses))
+	addrs := make(map[string]string, len(l.groups))
+	for _, address := range l.addresses {
+		ln, err := net.Listen("tcp", address)
+		if err != nil {
+			for _, ln := range listeners {
+				_ = ln.Close()
+			}
+			return errors.Wrapf(err, "failed to listen on observability address %s", address)
+		}
+
+		var tlsConfig *tls.Config
+		if reloader != nil {
+			// Kubelet probes can't present client certificates, so a listener
+			// serving only the health endpoints doesn't require them.
+			groups := l.groupsOf(address)
+			tlsConfig = reloader.TLSConfig(len(groups) == 1 && groups[0] == groupHealth)
+			ln = tls.NewListener(ln, tlsConfig)
+		}
+
+		servers = append(servers, &http.Server{
+			Addr:         address,
+			Handler:      logger.RequestIDMiddleware(l.muxes[address]),
+			TLSConfig:    tlsConfig,
+			ReadTimeout:  10 * time.Second,
+			WriteTimeout: 10 * time.Second,
+			IdleTimeout:  120 * time.Second,
+		})
+		listeners = append(listeners, ln)
+		for group, groupAddress := range l.groups {
+			if groupAddress == address {
+				addrs[group] = ln.Addr().String()
+			}
+		}
+	}
 
-	if err := s.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-		return errors.Wrap(err, "failed to start observability server")
+	s.mu.Lock()
+	s.servers = servers
+	s.addrs = addrs
+	s.mu.Unlock()
+
+	g := new(errgroup.Group)
+	for i, server := range servers {
+		ln := listeners[i]
+		logger.InfoKV(ctx, "Starting observability server",
+			"address", ln.Addr().String(),
+			"endpoints", l.groupsOf(server.Addr),
+			"metrics_enabled", s.config.Metrics.Enabled,
+			"health_enabled", s.config.Health.Enabled,
+			"debug_enabled", s.config.Debug.Enabled,
+			"tls_enabled", server.TLSConfig != nil,
+		)
+
+		g.Go(func() error {
+			if err := server.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				// The endpoints are served by all the servers or none.
+				for _, other := range servers {
+					_ = other.Close()
+				}
+				return errors.Wrap(err, "failed to start observability server")
+			}
+			return nil
+		})
 	}
 
-	return nil
+	return g.Wait()
 }
 
-// Shutdown gracefully stops the observability server within the given context timeout.
+// Handle registers an additional endpoint on the observability server.
+// It must be called before Run.
+func (s *ObservabilityService) Handle(pattern string, h http.Handler) {
+	s.handlers = append(s.handlers, handler{pattern: pattern, handler: h})
+}
+
+// HandleDebug registers an additional debug endpoint, served along with the
+// debug endpoints. It must be called before Run.
+func (s *ObservabilityService) HandleDebug(pattern string, h http.Handler) {
+	s.handlers = append(s.handlers, handler{pattern: pattern, handler: h, debug: true})
+}
+
+// SetGatherer sets the Prometheus gatherer served at the metrics endpoint,
+// the default registry otherwise. It must be called before Run.
+func (s *ObservabilityService) SetGatherer(g prometheus.Gatherer) {
+	s.gatherer = g
+}
+
+// metricsHandler returns the handler of the metrics endpoint.
+func (s *ObservabilityService) metricsHandler() http.Handler {
+	if s.gatherer == nil {
+		return promhttp.Handler()
+	}
+	return promhttp.HandlerFor(s.gatherer, promhttp.HandlerOpts{})
+}
+
+// Shutdown gracefully stops the observability servers within the given context timeout.
 func (s *ObservabilityService) Shutdown(ctx context.Context) error {
-	if s.server == nil {
+	s.mu.RLock()
+	servers := s.servers
+	s.mu.RUnlock()
+
+	if len(servers) == 0 {
 		return nil
 	}
 
 	logger.InfoKV(ctx, "Shutting down observability server")
-	return s.server.Shutdown(ctx)
+
+	var firstErr error
+	for _, server := range servers {
+		if err := server.Shutdown(ctx); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Addr returns the address the server is listening on, or an empty string
+// if the server has not started yet.
+func (s *ObservabilityService) Addr() string {
+	return s.groupAddr(groupMain)
+}
+
+// HealthAddr returns the address the health endpoints are served on, that of
+// Addr unless Health.Address is set, or an empty string if the server has not
+// started yet.
+func (s *ObservabilityService) HealthAddr() string {
+	return s.groupAddr(groupHealth)
+}
+
+// MetricsAddr returns the address the metrics endpoint is served on, that of
+// Addr unless Metrics.Address is set, or an empty string if the server has
+// not started yet.
+func (s *ObservabilityService) MetricsAddr() string {
+	return s.groupAddr(groupMetrics)
+}
+
+// DebugAddr returns the address the debug endpoints are served on, that of
+// Addr unless Debug.Address is set, or an empty string if the server has not
+// started yet.
+func (s *ObservabilityService) DebugAddr() string {
+	return s.groupAddr(groupDebug)
+}
+
+func (s *ObservabilityService) groupAddr(group string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if addr, ok := s.addrs[group]; ok {
+		return addr
+	}
+	return s.addrs[groupMain]
 }
 
 // registerHealthEndpoints registers all health check endpoints.
@@ -102,13 +268,30 @@ func (s *ObservabilityService) registerHealthEndpoints(mux *http.ServeMux) {
 	// Readiness endpoint - returns 200 if the application is ready to serve traffic
 	mux.HandleFunc(s.config.Health.ReadyPath, s.handleReadiness)
 
+	// Startup endpoint - returns 200 once the startup checks are healthy
+	if s.config.Health.StartupPath != "" {
+		mux.HandleFunc(s.config.Health.StartupPath, s.handleStartup)
+	}
+
 	// Detailed health checks endpoint
 	mux.HandleFunc(s.config.Health.CheckPath, s.handleHealthChecks)
 
+	// Recent results of every health check
+	if s.config.Health.HistoryPath != "" {
+		mux.HandleFunc(s.config.Health.HistoryPath, s.handleHealthHistory)
+	}
+
+	// Manual overrides, only available with an admin token
+	if s.config.Health.OverridePath != "" && s.config.Health.AdminToken.IsSet() {
+		mux.HandleFunc(s.config.Health.OverridePath, s.handleHealthOverride)
+	}
+
 	logger.InfoKV(context.Background(), "Registered health endpoints",
 		"live_path", s.config.Health.LivePath,
 		"ready_path", s.config.Health.ReadyPath,
+		"startup_path", s.config.Health.StartupPath,
 		"check_path", s.config.Health.CheckPath,
+		"history_path", s.config.Health.HistoryPath,
 	)
 }
 
@@ -130,15 +313,27 @@ func (s *ObservabilityService) registerDebugEndpoints(mux *http.ServeMux) {
 	)
 }
 
-// handleLiveness handles liveness probe requests.
+// handleLiveness handles liveness probe requests. It returns 200 unless a
+// check registered for the liveness probe is unhealthy.
 func (s *ObservabilityService) handleLiveness(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), s.config.Health.Timeout)
+	defer cancel()
+
+	status := s.healthManager.GetGroupStatus(ctx, health.ForLiveness())
+
 	response := map[string]interface{}{
 		"status":    "alive",
 		"timestamp": time.Now().UTC().Format(time.RFC3339),
 	}
 
+	statusCode := http.StatusOK
+	if status == health.StatusUnhealthy {
+		response["status"] = status
+		statusCode = http.StatusServiceUnavailable
+	}
+
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
+	w.WriteHeader(statusCode)
 	json.NewEncoder(w).Encode(response)
 }
 
@@ -148,18 +343,54 @@ func (s *ObservabilityService) handleReadiness(w http.ResponseWriter, r *http.Re
 	defer cancel()
 
 	ready := s.healthManager.IsReady()
-	overallStatus := s.healthManager.GetOverallStatus(ctx)
+	results, overallStatus := s.healthManager.CheckAllWithStatus(ctx)
+	status := s.healthManager.GroupStatus(results, health.ForReadiness())
+
+	maintenance, message := s.healthManager.Maintenance()
+	if maintenance {
+		status = health.StatusMaintenance
+	}
+
+	statusCode := health.ReadyStatusCode(status, s.config.Health.ReadyStatusCodes)
+	if !ready && !maintenance {
+		statusCode = http.StatusServiceUnavailable
+	}
 
 	response := map[string]interface{}{
-		"status":         overallStatus,
-		"ready":          ready && overallStatus == health.StatusHealthy,
+		"status":         status,
+		"ready":          ready && statusCode < http.StatusMultipleChoices,
 		"timestamp":      time.Now().UTC().Format(time.RFC3339),
 		"manager_ready":  ready,
 		"overall_status": overallStatus,
 	}
+	if maintenance {
+		response["message"] = message
+	}
+	if pending := s.healthManager.PendingGates(); len(pending) > 0 {
+		response["pending_gates"] = pending
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(response)
+}
+
+// handleStartup handles startup probe requests. It returns 200 once every
+// check registered for the startup probe is healthy.
+func (s *ObservabilityService) handleStartup(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), s.config.Health.Timeout)
+	defer cancel()
+
+	status := s.healthManager.GetGroupStatus(ctx, health.ForStartup())
+
+	response := map[string]interface{}{
+		"status":    status,
+		"started":   status == health.StatusHealthy,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	}
 
 	statusCode := http.StatusOK
-	if !ready || overallStatus != health.StatusHealthy {
+	if status != health.StatusHealthy {
 		statusCode = http.StatusServiceUnavailable
 	}
 
@@ -169,27 +400,91 @@ func (s *ObservabilityService) handleReadiness(w http.ResponseWriter, r *http.Re
 }
 
 // handleHealthChecks handles detailed health check requests.
+// The "check", "exclude", "tag" and "label" query parameters select a subset of checks,
+// and the "verbose" query parameter overrides the configured terse mode.
 func (s *ObservabilityService) handleHealthChecks(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), s.config.Health.Timeout)
 	defer cancel()
 
-	results := s.healthManager.CheckAll(ctx)
-	overallStatus := s.healthManager.GetOverallStatus(ctx)
+	filter := health.ParseCheckFilter(r.URL.Query())
+	verbose := health.WantsVerbose(s.config.Health.Terse, r.URL.Query())
+	healthJSON := health.WantsHealthJSON(s.config.Health.Format, r.Header.Get("Accept"))
+	ready := s.healthManager.IsReady()
+	maintenance, message := s.healthManager.Maintenance()
+
+	etag := func(status health.HealthStatus, results map[string]health.HealthResult) string {
+		return health.ETag(status, results, strconv.FormatBool(healthJSON), strconv.FormatBool(verbose),
+			strconv.FormatBool(ready), strconv.FormatBool(maintenance), message)
+	}
+
+	// Revalidations are answered from the latest results, without running the checks
+	if r.Header.Get("If-None-Match") != "" {
+		if results, status, ok := s.healthManager.LatestMatching(filter); ok && health.NotModified(w, r, etag(status, results)) {
+			return
+		}
+	}
+
+	results, overallStatus := s.healthManager.CheckMatching(ctx, filter)
+	if health.NotModified(w, r, etag(overallStatus, results)) {
+		return
+	}
+
+	if healthJSON {
+		statusCode := health.CheckStatusCode(overallStatus, s.config.Health.CheckStatusCodes)
+
+		doc := health.NewHealthJSON(overallStatus, results, time.Now())
+		if !verbose {
+			doc = doc.Terse()
+		}
+
+		w.Header().Set("Content-Type", health.HealthJSONContentType)
+		w.WriteHeader(statusCode)
+		json.NewEncoder(w).Encode(doc)
+		return
+	}
 
 	response := map[string]interface{}{
 		"status":     overallStatus,
 		"timestamp":  time.Now().UTC().Format(time.RFC3339),
-		"checks":     results,
-		"ready":      s.healthManager.IsReady(),
+		"checks":     s.healthManager.Reports(results),
+		"ready":      ready,
 		"check_count": len(results),
 	}
+	if !verbose {
+		response["checks"] = health.Statuses(results)
+	}
+	if tags := s.healthManager.TagSummaries(results); len(tags) > 0 {
+		response["tags"] = tags
+	}
+	if score, ok := s.healthManager.Score(results); ok {
+		response["score"] = score
+	}
+	if maintenance {
+		response["maintenance"] = map[string]interface{}{"enabled": true, "message": message}
+	}
 
-	statusCode := http.StatusOK
-	if overallStatus != health.StatusHealthy {
-		statusCode = http.StatusServiceUnavailable
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(health.CheckStatusCode(overallStatus, s.config.Health.CheckStatusCodes))
+	json.NewEncoder(w).Encode(response)
+}
+
+// handleHealthHistory handles health check history requests.
+// The "check" query parameter limits the response to a single check.
+func (s *ObservabilityService) handleHealthHistory(w http.ResponseWriter, r *http.Request) {
+	history := s.healthManager.AllHistory()
+	if name := r.URL.Query().Get("check"); name != "" {
+		history = map[string][]health.HistoryEntry{name: s.healthManager.History(name)}
+	}
+	if !health.WantsVerbose(s.config.Health.Terse, r.URL.Query()) {
+		history = health.TerseHistory(history)
+	}
+
+	response := map[string]interface{}{
+		"history":   history,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
+	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(response)
 }
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/katalabut/fast-app/health"
+)
+
+// overrideRequest is the body of a request setting a health override.
+type overrideRequest struct {
+	// Check is the name of the check, or empty for the overall status.
+	Check  string              `json:"check"`
+	Status health.HealthStatus `json:"status"`
+	Reason string              `json:"reason"`
+	// TTL is how long the override lasts, e.g. "15m".
+	TTL string `json:"ttl"`
+}
+
+// handleHealthOverride handles health override requests. GET lists the active
+// overrides, POST sets one and DELETE clears the override of the check given
+// by the "check" query parameter. Forcing the overall status into maintenance
+// enables maintenance mode until the overall override is cleared, so it takes
+// no TTL. Requests must carry the admin token as a bearer token.
+func (s *ObservabilityService) handleHealthOverride(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		writeJSON(w, http.StatusUnauthorized, map[string]interface{}{"error": "unauthorized"})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		var req overrideRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]interface{}{"error": "invalid request body: " + err.Error()})
+			return
+		}
+
+		switch req.Status {
+		case health.StatusHealthy, health.StatusDegraded, health.StatusUnhealthy:
+		case health.StatusMaintenance:
+			if req.Check != "" {
+				writeJSON(w, http.StatusBadRequest, map[string]interface{}{"error": "maintenance only applies to the overall status"})
+				return
+			}
+			s.healthManager.SetMaintenance(true, req.Reason)
+			s.writeOverrides(w)
+			return
+		default:
+			writeJSON(w, http.StatusBadRequest, map[string]interface{}{"error": "status must be healthy, degraded, unhealthy or maintenance"})
+			return
+		}
+
+		ttl, err := time.ParseDuration(req.TTL)
+		if err != nil || ttl <= 0 {
+			writeJSON(w, http.StatusBadRequest, map[string]interface{}{"error": "ttl must be a positive duration, e.g. 15m"})
+			return
+		}
+
+		s.healthManager.SetOverride(req.Check, req.Status, req.Reason, ttl)
+	case http.MethodDelete:
+		check := r.URL.Query().Get("check")
+		s.healthManager.ClearOverride(check)
+		if enabled, _ := s.healthManager.Maintenance(); check == "" && enabled {
+			s.healthManager.SetMaintenance(false, "")
+		}
+	default:
+		w.Header().Set("Allow", "GET, POST, DELETE")
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]interface{}{"error": "method not allowed"})
+		return
+	}
+
+	s.writeOverrides(w)
+}
+
+// writeOverrides writes the active overrides and the maintenance mode.
+func (s *ObservabilityService) writeOverrides(w http.ResponseWriter) {
+	maintenance, message := s.healthManager.Maintenance()
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"overrides":           s.healthManager.Overrides(),
+		"maintenance":         maintenance,
+		"maintenance_message": message,
+		"timestamp":           time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+// authorized reports whether the request carries the admin token.
+func (s *ObservabilityService) authorized(r *http.Request) bool {
+	want := "Bearer " + s.config.Health.AdminToken.Reveal()
+	got := r.Header.Get("Authorization")
+	return subtle.ConstantTimeCompare([]byte(got), []byte(want)) == 1
+}
+
+// RequireAdmin returns a handler serving h only to requests carrying the admin
+// token as a bearer token, see Health.AdminToken.
+func (s *ObservabilityService) RequireAdmin(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.authorized(r) {
+			writeJSON(w, http.StatusUnauthorized, map[string]interface{}{"error": "unauthorized"})
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/katalabut/fast-app/config"
+	"github.com/katalabut/fast-app/health"
+)
+
+func TestHealthOverrideMaintenance(t *testing.T) {
+	manager := health.NewManager(health.ManagerConfig{})
+	s := NewObservabilityService(config.Observability{
+		Health: config.Health{Enabled: true, AdminToken: "secret"},
+	}, manager)
+
+	do := func(method, target, body string) int {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer secret")
+		rec := httptest.NewRecorder()
+		s.handleHealthOverride(rec, req)
+		return rec.Code
+	}
+
+	t.Run("EnableOverall", func(t *testing.T) {
+		if code := do(http.MethodPost, "/health/override", `{"status":"maintenance","reason":"migration"}`); code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", code)
+		}
+		enabled, message := manager.Maintenance()
+		if !enabled {
+			t.Error("Expected maintenance mode to be enabled")
+		}
+		if message != "migration" {
+			t.Errorf("Expected message migration, got %q", message)
+		}
+	})
+
+	t.Run("RejectCheck", func(t *testing.T) {
+		if code := do(http.MethodPost, "/health/override", `{"check":"db","status":"maintenance"}`); code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", code)
+		}
+	})
+
+	t.Run("ClearOverall", func(t *testing.T) {
+		if code := do(http.MethodDelete, "/health/override?check=", ""); code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", code)
+		}
+		if enabled, _ := manager.Maintenance(); enabled {
+			t.Error("Expected maintenance mode to be disabled")
+		}
+	})
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/katalabut/fast-app/config"
+	"github.com/katalabut/fast-app/logger"
+	"github.com/pkg/errors"
+)
+
+// defaultTLSReloadInterval is how often TLS files are checked for changes
+// without TLS.ReloadInterval.
+const defaultTLSReloadInterval = time.Minute
+
+// tlsReloader serves the TLS configuration of the certificate, key and client
+// CA files, reloaded on a handshake once any of them has changed, so renewed
+// certificates are used without restarting the server. The files are checked
+// at most once per reload interval.
+type tlsReloader struct {
+	cfg config.TLS
+
+	mu        sync.Mutex
+	modTime   time.Time
+	checkedAt time.Time
+	current   *tls.Config
+}
+
+func newTLSReloader(cfg config.TLS) (*tlsReloader, error) {
+	if cfg.CertFile == "" || cfg.KeyFile == "" {
+		return nil, errors.New("both TLS certificate and key files must be set")
+	}
+
+	r := &tlsReloader{cfg: cfg}
+	modTime, err := r.lastModified()
+	if err != nil {
+		return nil, err
+	}
+	if r.current, err = r.load(); err != nil {
+		return nil, err
+	}
+	r.modTime, r.checkedAt = modTime, time.Now()
+	if r.cfg.ReloadInterval <= 0 {
+		r.cfg.ReloadInterval = defaultTLSReloadInterval
+	}
+	return r, nil
+}
+
+// TLSConfig returns the configuration of a listener, resolving that of each
+// connection with the reloader. With optionalClientCert, client certificates
+// are verified if given but not required.
+func (r *tlsReloader) TLSConfig(optionalClientCert bool) *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			cfg := r.config()
+			if optionalClientCert && cfg.ClientAuth == tls.RequireAndVerifyClientCert {
+				cfg = cfg.Clone()
+				cfg.ClientAuth = tls.VerifyClientCertIfGiven
+			}
+			return cfg, nil
+		},
+	}
+}
+
+// config returns the current configuration, reloading the files if they
+// changed. The previous configuration is kept when they can't be loaded,
+// e.g. while a certificate and its key are being replaced.
+func (r *tlsReloader) config() *tls.Config {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if time.Since(r.checkedAt) < r.cfg.ReloadInterval {
+		return r.current
+	}
+	r.checkedAt = time.Now()
+
+	modTime, err := r.lastModified()
+	if err != nil || modTime.Equal(r.modTime) {
+		return r.current
+	}
+
+	cfg, err := r.load()
+	if err != nil {
+		logger.WarnKV(context.Background(), "Failed to reload TLS certificate", "error", err)
+		return r.current
+	}
+
+	logger.InfoKV(context.Background(), "Reloaded TLS certificate", "cert_file", r.cfg.CertFile)
+	r.current, r.modTime = cfg, modTime
+	return cfg
+}
+
+// load reads the certificate, key and client CA files.
+func (r *tlsReloader) load() (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load TLS certificate")
+	}
+
+	cfg := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+	}
+
+	if r.cfg.ClientCAFile != "" {
+		pem, err := os.ReadFile(r.cfg.ClientCAFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read TLS client CA file")
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.Errorf("no certificate found in TLS client CA file %s", r.cfg.ClientCAFile)
+		}
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return cfg, nil
+}
+
+// lastModified returns the latest modification time of the files.
+func (r *tlsReloader) lastModified() (time.Time, error) {
+	var latest time.Time
+	for _, name := range []string{r.cfg.CertFile, r.cfg.KeyFile, r.cfg.ClientCAFile} {
+		if name == "" {
+			continue
+		}
+		info, err := os.Stat(name)
+		if err != nil {
+			return time.Time{}, errors.Wrap(err, "failed to stat TLS file")
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
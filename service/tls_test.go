@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/katalabut/fast-app/config"
+	"github.com/katalabut/fast-app/health"
+)
+
+// testCert is a certificate with its key, signed by parent or self-signed.
+type testCert struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+func newTestCert(t *testing.T, cn string, parent *testCert) *testCert {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IsCA:         parent == nil,
+
+		BasicConstraintsValid: true,
+	}
+
+	signer, signerKey := tmpl, key
+	if parent != nil {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+
+	return &testCert{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+func writeFile(t *testing.T, name string, data []byte) {
+	t.Helper()
+	if err := os.WriteFile(name, data, 0o600); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
+
+// startTLSService starts an enabled observability service with the
+// configuration, serving /ping on the main address.
+func startTLSService(t *testing.T, cfg config.Observability) *ObservabilityService {
+	t.Helper()
+
+	cfg.Enabled = true
+	s := NewObservabilityService(cfg, health.NewManager(health.ManagerConfig{}))
+	s.Handle("/ping", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.Run(ctx) }()
+	t.Cleanup(func() {
+		_ = s.Shutdown(context.Background())
+		cancel()
+		<-done
+	})
+
+	deadline := time.Now().Add(5 * time.Second)
+	for s.Addr() == "" {
+		select {
+		case err := <-done:
+			t.Fatalf("Expected server to start, got %v", err)
+		default:
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Server did not start")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return s
+}
+
+func TestObservabilityTLS(t *testing.T) {
+	ca := newTestCert(t, "ca", nil)
+	server := newTestCert(t, "server", ca)
+	client := newTestCert(t, "client", ca)
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	caFile := filepath.Join(dir, "ca.crt")
+	writeFile(t, certFile, server.certPEM)
+	writeFile(t, keyFile, server.keyPEM)
+	writeFile(t, caFile, ca.certPEM)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+
+	getPath := func(addr, path string, certs ...tls.Certificate) (*tls.ConnectionState, error) {
+		c := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+			RootCAs:      roots,
+			ServerName:   "localhost",
+			Certificates: certs,
+		}}}
+		resp, err := c.Get("https://" + addr + path)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("Expected status code %d, got %d", http.StatusOK, resp.StatusCode)
+		}
+		return resp.TLS, nil
+	}
+	get := func(addr string, certs ...tls.Certificate) (*tls.ConnectionState, error) {
+		return getPath(addr, "/ping", certs...)
+	}
+
+	t.Run("ServerCertificate", func(t *testing.T) {
+		addr := startTLSService(t, config.Observability{TLS: config.TLS{CertFile: certFile, KeyFile: keyFile}}).Addr()
+
+		state, err := get(addr)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if cn := state.PeerCertificates[0].Subject.CommonName; cn != "server" {
+			t.Errorf("Expected server certificate, got %s", cn)
+		}
+	})
+
+	t.Run("ClientCertificate", func(t *testing.T) {
+		addr := startTLSService(t, config.Observability{TLS: config.TLS{CertFile: certFile, KeyFile: keyFile, ClientCAFile: caFile}}).Addr()
+
+		if _, err := get(addr); err == nil {
+			t.Error("Expected request without client certificate to fail")
+		}
+
+		pair, err := tls.X509KeyPair(client.certPEM, client.keyPEM)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if _, err := get(addr, pair); err != nil {
+			t.Errorf("Expected request with client certificate to succeed, got %v", err)
+		}
+	})
+
+	t.Run("Reload", func(t *testing.T) {
+		renewedCert := filepath.Join(dir, "renewed.crt")
+		renewedKey := filepath.Join(dir, "renewed.key")
+		writeFile(t, renewedCert, server.certPEM)
+		writeFile(t, renewedKey, server.keyPEM)
+		addr := startTLSService(t, config.Observability{TLS: config.TLS{
+			CertFile:       renewedCert,
+			KeyFile:        renewedKey,
+			ReloadInterval: time.Millisecond,
+		}}).Addr()
+
+		renewed := newTestCert(t, "renewed", ca)
+		writeFile(t, renewedCert, renewed.certPEM)
+		writeFile(t, renewedKey, renewed.keyPEM)
+		later := time.Now().Add(time.Minute)
+		_ = os.Chtimes(renewedCert, later, later)
+		_ = os.Chtimes(renewedKey, later, later)
+		time.Sleep(10 * time.Millisecond)
+
+		state, err := get(addr)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if cn := state.PeerCertificates[0].Subject.CommonName; cn != "renewed" {
+			t.Errorf("Expected renewed certificate, got %s", cn)
+		}
+	})
+
+	t.Run("ReloadInterval", func(t *testing.T) {
+		cachedCert := filepath.Join(dir, "cached.crt")
+		cachedKey := filepath.Join(dir, "cached.key")
+		writeFile(t, cachedCert, server.certPEM)
+		writeFile(t, cachedKey, server.keyPEM)
+		addr := startTLSService(t, config.Observability{TLS: config.TLS{CertFile: cachedCert, KeyFile: cachedKey}}).Addr()
+
+		renewed := newTestCert(t, "renewed", ca)
+		writeFile(t, cachedCert, renewed.certPEM)
+		writeFile(t, cachedKey, renewed.keyPEM)
+		later := time.Now().Add(time.Minute)
+		_ = os.Chtimes(cachedCert, later, later)
+		_ = os.Chtimes(cachedKey, later, later)
+
+		state, err := get(addr)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if cn := state.PeerCertificates[0].Subject.CommonName; cn != "server" {
+			t.Errorf("Expected files not to be checked again within the reload interval, got %s", cn)
+		}
+	})
+
+	t.Run("HealthAddressWithoutClientCertificate", func(t *testing.T) {
+		s := startTLSService(t, config.Observability{
+			TLS: config.TLS{CertFile: certFile, KeyFile: keyFile, ClientCAFile: caFile},
+			Health: config.Health{
+				Enabled:   true,
+				Address:   "127.0.0.1:0",
+				LivePath:  "/health/live",
+				ReadyPath: "/health/ready",
+				CheckPath: "/health/checks",
+			},
+		})
+
+		if _, err := getPath(s.HealthAddr(), "/health/live"); err != nil {
+			t.Errorf("Expected probe without client certificate to succeed, got %v", err)
+		}
+		if _, err := get(s.Addr()); err == nil {
+			t.Error("Expected request without client certificate to the main address to fail")
+		}
+	})
+
+	t.Run("MissingKey", func(t *testing.T) {
+		s := NewObservabilityService(config.Observability{
+			Enabled: true,
+			TLS:     config.TLS{CertFile: certFile},
+		}, health.NewManager(health.ManagerConfig{}))
+
+		if err := s.Run(context.Background()); err == nil {
+			t.Error("Expected error without key file")
+		}
+	})
+}
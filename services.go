@@ -0,0 +1,73 @@
+package fastapp
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// servicesPath is the path of the service status endpoint, relative to the debug path prefix.
+const servicesPath = "/services"
+
+// ServiceInfo describes the current status of a registered service.
+type ServiceInfo struct {
+	Name         string       `json:"name"`
+	Job          bool         `json:"job,omitempty"`
+	Phase        Phase        `json:"phase"`
+	State        ServiceState `json:"state"`
+	Ready        bool         `json:"ready"`
+	StartedAt    *time.Time   `json:"started_at,omitempty"`
+	Restarts     int          `json:"restarts"`
+	LastError    string       `json:"last_error,omitempty"`
+	LastErrorAt  *time.Time   `json:"last_error_at,omitempty"`
+	DependsOn    []string     `json:"depends_on,omitempty"`
+	HealthChecks []string     `json:"health_checks,omitempty"`
+}
+
+// Services returns the status of every registered service in registration order.
+func (a *App) Services() []ServiceInfo {
+	infos := make([]ServiceInfo, 0, len(a.runners))
+
+	for _, r := range a.runners {
+		st := r.status()
+
+		info := ServiceInfo{
+			Name:         r.name,
+			Job:          r.job,
+			Phase:        r.phase,
+			State:        st.state,
+			Ready:        r.isReady(),
+			Restarts:     st.restarts,
+			DependsOn:    r.dependsOn,
+			HealthChecks: r.healthChecks,
+		}
+		if !st.startedAt.IsZero() {
+			startedAt := st.startedAt
+			info.StartedAt = &startedAt
+		}
+		if st.lastErr != nil {
+			lastErrorAt := st.lastErrAt
+			info.LastError = st.lastErr.Error()
+			info.LastErrorAt = &lastErrorAt
+		}
+
+		infos = append(infos, info)
+	}
+
+	return infos
+}
+
+// handleServices handles service status requests.
+func (a *App) handleServices(w http.ResponseWriter, r *http.Request) {
+	services := a.Services()
+
+	response := map[string]interface{}{
+		"services":  services,
+		"count":     len(services),
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,73 @@
+package fastapp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/katalabut/fast-app/health"
+	"github.com/katalabut/fast-app/health/healthtest"
+)
+
+type checkedService struct {
+	*testService
+}
+
+func (s checkedService) HealthChecks() []health.HealthChecker {
+	return []health.HealthChecker{healthtest.AlwaysHealthy("queue")}
+}
+
+func TestServices(t *testing.T) {
+	app, cancel, exitCode := newTestApp()
+
+	db := newTestService()
+	db.SetReady(true)
+	app.Add(db, WithName("database"))
+	app.Add(checkedService{newTestService()}, WithName("consumer"), DependsOn("database"))
+
+	infos := app.Services()
+	if len(infos) != 2 {
+		t.Fatalf("Expected 2 services, got %d", len(infos))
+	}
+	if infos[0].State != StateStarting || infos[0].StartedAt != nil {
+		t.Errorf("Expected database to be starting, got %s", infos[0].State)
+	}
+
+	stop := startTestApp(t, app, cancel, exitCode)
+	<-db.started
+
+	rec := httptest.NewRecorder()
+	app.handleServices(rec, httptest.NewRequest(http.MethodGet, "/debug/services", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+
+	var response struct {
+		Services []ServiceInfo `json:"services"`
+		Count    int           `json:"count"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if response.Count != 2 {
+		t.Errorf("Expected count 2, got %d", response.Count)
+	}
+	database := response.Services[0]
+	if database.Name != "database" || database.State != StateRunning || !database.Ready || database.StartedAt == nil {
+		t.Errorf("Unexpected database status %+v", database)
+	}
+	consumer := response.Services[1]
+	if len(consumer.DependsOn) != 1 || consumer.DependsOn[0] != "database" {
+		t.Errorf("Expected consumer to depend on database, got %v", consumer.DependsOn)
+	}
+	if len(consumer.HealthChecks) != 1 || consumer.HealthChecks[0] != "queue" {
+		t.Errorf("Expected consumer health checks [queue], got %v", consumer.HealthChecks)
+	}
+
+	if code := stop(); code != exitCodeOk {
+		t.Errorf("Expected exit code %d, got %d", exitCodeOk, code)
+	}
+}
@@ -0,0 +1,125 @@
+package fastapp
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// shutdown runs the before shutdown hooks, stops all services in shutdown order,
+// closes the values constructed by the dependency injection container and finally
+// shuts down the observability server, so health probes keep answering while services drain.
+func (a *App) shutdown(ctx context.Context) error {
+	if err := a.runHooks(ctx, stageBeforeShutdown, a.hooks.beforeShutdown); err != nil {
+		a.logger.Errorw("Shutdown hook failed", "error", err)
+	}
+
+	err := a.shutdownServices(ctx)
+
+	if cerr := a.container.Close(); cerr != nil {
+		a.logger.Errorw("Failed to close provided values", "error", cerr)
+		if err == nil {
+			err = cerr
+		}
+	}
+
+	if a.notifier != nil {
+		_ = a.notifier.Close()
+	}
+
+	if a.config.Observability.Enabled {
+		if oerr := a.observabilityService.Shutdown(ctx); oerr != nil && err == nil {
+			err = errors.Wrap(oerr, "observability server")
+		}
+	}
+
+	return err
+}
+
+// shutdownServices stops services one at a time in shutdown order. Each service
+// has its context cancelled and its Shutdown method called, and the next service
+// is only stopped once the previous one's Run method has returned.
+func (a *App) shutdownServices(ctx context.Context) error {
+	var firstErr error
+
+	for _, r := range a.shutdownOrder() {
+		if err := a.stopRunner(ctx, r); err != nil {
+			a.logger.Errorw("Failed to shut down service", "service", r.name, "error", err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+
+	return firstErr
+}
+
+// stopRunner stops a single service and waits for its Run method to return.
+func (a *App) stopRunner(ctx context.Context, r *Runner) error {
+	a.logger.Debugw("Stopping service", "service", r.name)
+
+	done := r.done()
+	r.markStopping()
+	r.cancel()
+	err := r.shutdown(ctx)
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		if err == nil {
+			err = errors.Wrap(ctx.Err(), "waiting for service to stop")
+		}
+	}
+
+	if err != nil {
+		return errors.Wrapf(err, "service %q", r.name)
+	}
+
+	return nil
+}
+
+// startOrder returns the runners ordered by phase so that every service comes
+// after its dependencies, keeping registration order otherwise.
+func (a *App) startOrder() []*Runner {
+	order := make([]*Runner, 0, len(a.runners))
+	visited := make(map[*Runner]bool, len(a.runners))
+
+	runners := make([]*Runner, len(a.runners))
+	copy(runners, a.runners)
+	sort.SliceStable(runners, func(i, j int) bool {
+		return runners[i].phase < runners[j].phase
+	})
+
+	var visit func(r *Runner)
+	visit = func(r *Runner) {
+		if visited[r] {
+			return
+		}
+		visited[r] = true
+
+		for _, name := range r.dependsOn {
+			if dep, ok := a.runnerByName(name); ok {
+				visit(dep)
+			}
+		}
+		order = append(order, r)
+	}
+
+	for _, r := range runners {
+		visit(r)
+	}
+
+	return order
+}
+
+// shutdownOrder returns the runners in reverse start order: later phases and
+// dependents are stopped before their dependencies and later registered services first.
+func (a *App) shutdownOrder() []*Runner {
+	order := a.startOrder()
+	for i, j := 0, len(order)-1; i < j; i, j = i+1, j-1 {
+		order[i], order[j] = order[j], order[i]
+	}
+
+	return order
+}
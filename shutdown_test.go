@@ -0,0 +1,167 @@
+package fastapp
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/katalabut/fast-app/clock"
+	"github.com/katalabut/fast-app/config"
+)
+
+type orderedService struct {
+	name    string
+	record  func(string)
+	started chan struct{}
+	running chan struct{}
+}
+
+func newOrderedService(name string, record func(string)) *orderedService {
+	return &orderedService{
+		name:    name,
+		record:  record,
+		started: make(chan struct{}),
+		running: make(chan struct{}),
+	}
+}
+
+func (s *orderedService) Run(ctx context.Context) error {
+	close(s.started)
+	<-ctx.Done()
+	close(s.running)
+	return nil
+}
+
+func (s *orderedService) Shutdown(ctx context.Context) error {
+	s.record(s.name)
+	return nil
+}
+
+func TestShutdownOrder(t *testing.T) {
+	run := func(t *testing.T, register func(app *App, record func(string)) []*orderedService) []string {
+		app, cancel, exitCode := newTestApp()
+
+		var (
+			mu    sync.Mutex
+			order []string
+		)
+		record := func(name string) {
+			mu.Lock()
+			defer mu.Unlock()
+			order = append(order, name)
+		}
+
+		services := register(app, record)
+		stop := startTestApp(t, app, cancel, exitCode)
+		for _, svc := range services {
+			<-svc.started
+		}
+		if code := stop(); code != exitCodeOk {
+			t.Errorf("Expected exit code %d, got %d", exitCodeOk, code)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		return order
+	}
+
+	assertOrder := func(t *testing.T, got, want []string) {
+		t.Helper()
+		if len(got) != len(want) {
+			t.Fatalf("Expected shutdown order %v, got %v", want, got)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("Expected shutdown order %v, got %v", want, got)
+			}
+		}
+	}
+
+	t.Run("ReverseRegistration", func(t *testing.T) {
+		order := run(t, func(app *App, record func(string)) []*orderedService {
+			var services []*orderedService
+			for _, name := range []string{"a", "b", "c"} {
+				svc := newOrderedService(name, record)
+				app.Add(svc, WithName(name))
+				services = append(services, svc)
+			}
+			return services
+		})
+
+		assertOrder(t, order, []string{"c", "b", "a"})
+	})
+
+	t.Run("ReverseDependency", func(t *testing.T) {
+		order := run(t, func(app *App, record func(string)) []*orderedService {
+			db := newOrderedService("database", record)
+			api := newOrderedService("api", func(name string) {
+				// The database must still be running while the API drains.
+				select {
+				case <-db.running:
+					t.Error("Expected database to be running during api shutdown")
+				default:
+				}
+				record(name)
+			})
+
+			app.Add(db, WithName("database"))
+			app.Add(api, WithName("api"), DependsOn("database"))
+			cache := newOrderedService("cache", record)
+			app.Add(cache, WithName("cache"))
+			return []*orderedService{db, api, cache}
+		})
+
+		assertOrder(t, order, []string{"cache", "api", "database"})
+	})
+}
+
+func TestDrainDelay(t *testing.T) {
+	fake := clock.NewFake(time.Now())
+	exitCode := make(chan int, 1)
+	app := New(
+		Config{Shutdown: config.Shutdown{DrainDelay: time.Minute}},
+		WithClock(fake),
+		WithExitFunc(func(code int) { exitCode <- code }),
+	)
+
+	svc := newTestService()
+	app.Add(svc)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		app.Start()
+	}()
+
+	<-svc.started
+	svc.SetReady(true)
+	if !app.IsReady() {
+		t.Fatal("Expected application to be ready")
+	}
+
+	app.Stop("rollout")
+
+	// Drain delay and watchdog timers.
+	fake.BlockUntil(2)
+	if app.IsReady() {
+		t.Error("Expected application not to be ready while draining")
+	}
+	if state := app.Services()[0].State; state != StateRunning {
+		t.Errorf("Expected service to keep running while draining, got %s", state)
+	}
+
+	fake.Advance(time.Minute)
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected application to stop after the drain delay")
+	}
+	if code := <-exitCode; code != exitCodeOk {
+		t.Errorf("Expected exit code %d, got %d", exitCodeOk, code)
+	}
+
+	if got, want := app.watchdogTimeout(), time.Minute+defaultShutdownTimeout+watchdogGracePeriod; got != want {
+		t.Errorf("Expected watchdog timeout %v, got %v", want, got)
+	}
+}
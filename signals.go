@@ -0,0 +1,78 @@
+package fastapp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"runtime/pprof"
+	"syscall"
+)
+
+// defaultShutdownSignals are the signals that trigger a graceful shutdown by default.
+// SIGTERM is what Kubernetes and most process supervisors send.
+var defaultShutdownSignals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+
+// notifyShutdown returns a context that is cancelled when one of the configured
+// shutdown signals is received. The returned function releases the signal handler.
+func (a *App) notifyShutdown(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(parent)
+
+	signals := a.opts.shutdownSignals
+	if a.opts.dumpOnQuit {
+		signals = append(signals[:len(signals):len(signals)], syscall.SIGQUIT)
+	}
+
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, signals...)
+
+	go func() {
+		select {
+		case sig := <-ch:
+			if sig == syscall.SIGQUIT && a.opts.dumpOnQuit {
+				a.dumpGoroutines()
+			}
+
+			a.logger.Infow("Received shutdown signal", "signal", sig.String())
+			cancel(fmt.Errorf("received signal %s", sig))
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, func() {
+		signal.Stop(ch)
+		cancel(context.Canceled)
+	}
+}
+
+// notifyReload reloads the configuration each time SIGHUP is received until ctx is done.
+func (a *App) notifyReload(ctx context.Context) {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGHUP)
+
+	go func() {
+		defer signal.Stop(ch)
+
+		for {
+			select {
+			case <-ch:
+				a.logger.Info("Received SIGHUP, reloading configuration")
+				_ = a.ReloadConfig(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// dumpGoroutines logs the stack traces of all goroutines.
+func (a *App) dumpGoroutines() {
+	var buf bytes.Buffer
+	if err := pprof.Lookup("goroutine").WriteTo(&buf, 2); err != nil {
+		a.logger.Warnw("Failed to dump goroutines", "error", err)
+		return
+	}
+
+	a.logger.Warnw("Goroutine dump", "goroutines", buf.String())
+}
@@ -0,0 +1,104 @@
+//go:build !windows
+
+package fastapp
+
+import (
+	"syscall"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestShutdownSignals(t *testing.T) {
+	app, cancel, exitCode := newTestApp(WithShutdownSignals(syscall.SIGUSR2))
+	defer cancel()
+
+	svc := newTestService()
+	app.Add(svc)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		app.Start()
+	}()
+
+	<-svc.started
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGUSR2); err != nil {
+		t.Fatalf("Failed to send signal: %v", err)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected application to stop on signal")
+	}
+	if code := <-exitCode; code != exitCodeOk {
+		t.Errorf("Expected exit code %d, got %d", exitCodeOk, code)
+	}
+}
+
+func TestReloadSignal(t *testing.T) {
+	reloaded := make(chan struct{}, 1)
+	app, cancel, exitCode := newTestApp(WithConfigLoader(func() (interface{}, error) {
+		return &reloadConfig{}, nil
+	}))
+	app.Events().Subscribe(EventConfigReloaded, func(e Event) {
+		reloaded <- struct{}{}
+	})
+
+	svc := &reloadingService{testService: newTestService()}
+	app.Add(svc)
+	stop := startTestApp(t, app, cancel, exitCode)
+
+	<-svc.started
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGHUP); err != nil {
+		t.Fatalf("Failed to send signal: %v", err)
+	}
+
+	select {
+	case <-reloaded:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected configuration to be reloaded on SIGHUP")
+	}
+	if len(svc.reloads()) != 1 {
+		t.Errorf("Expected 1 reload, got %d", len(svc.reloads()))
+	}
+
+	if code := stop(); code != exitCodeOk {
+		t.Errorf("Expected exit code %d, got %d", exitCodeOk, code)
+	}
+}
+
+func TestDiagnosticSignal(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	app, cancel, exitCode := newTestApp(WithLogger(zap.New(core).Sugar()))
+
+	svc := newTestService()
+	app.Add(svc, WithName("api"))
+	stop := startTestApp(t, app, cancel, exitCode)
+
+	<-svc.started
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatalf("Failed to send signal: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for logs.FilterMessage("Health snapshot").Len() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected diagnostic dump on SIGUSR1")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	for _, msg := range []string{"Goroutine dump", "Memory stats", "GC stats"} {
+		if logs.FilterMessage(msg).Len() != 1 {
+			t.Errorf("Expected %q to be logged", msg)
+		}
+	}
+
+	if code := stop(); code != exitCodeOk {
+		t.Errorf("Expected exit code %d, got %d", exitCodeOk, code)
+	}
+}
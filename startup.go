@@ -0,0 +1,112 @@
+package fastapp
+
+import (
+	"context"
+	"time"
+
+	"github.com/katalabut/fast-app/health"
+	"github.com/pkg/errors"
+)
+
+// StartupPolicy defines what happens when a service does not become ready
+// within its startup deadline.
+type StartupPolicy int
+
+const (
+	// StartupFailFast shuts the application down with an error.
+	StartupFailFast StartupPolicy = iota
+
+	// StartupDegrade keeps the application running and reports it as degraded
+	// until the service becomes ready.
+	StartupDegrade
+)
+
+// String returns the name of the policy.
+func (p StartupPolicy) String() string {
+	switch p {
+	case StartupFailFast:
+		return "fail_fast"
+	case StartupDegrade:
+		return "degrade"
+	default:
+		return "unknown"
+	}
+}
+
+// WithStartupDeadline requires the service to become ready within d of Start().
+// A service is ready when it is running and its readiness gate (see WithReadinessGate)
+// or, if it implements health.ReadinessController, IsReady() reports true.
+// If the deadline is missed, policy decides whether the application fails fast
+// or keeps running in a degraded state.
+//
+// Example:
+//
+//	app.Add(db, fastapp.WithStartupDeadline(30*time.Second, fastapp.StartupFailFast))
+func WithStartupDeadline(d time.Duration, policy StartupPolicy) ServiceOption {
+	return serviceOptionFunc(
+		func(o *serviceOptions) {
+			o.startupDeadline = d
+			o.startupPolicy = policy
+		},
+	)
+}
+
+// WithReadinessGate sets a function reporting whether the service is ready.
+// It takes precedence over health.ReadinessController and is used both for
+// dependencies declared with DependsOn and for the startup deadline.
+func WithReadinessGate(gate func() bool) ServiceOption {
+	return serviceOptionFunc(
+		func(o *serviceOptions) {
+			o.readinessGate = gate
+		},
+	)
+}
+
+// startupCheckName returns the name of the health check registered for
+// a service that missed its startup deadline.
+func startupCheckName(service string) string {
+	return "startup:" + service
+}
+
+// watchStartup waits for the runner to become ready and applies its startup
+// policy if it does not within the deadline. It returns an error only when
+// the application must fail fast.
+func (a *App) watchStartup(ctx context.Context, r *Runner) error {
+	deadline := a.opts.clock.After(r.startupDeadline)
+	ticker := a.opts.clock.NewTicker(readinessPollInterval)
+	defer ticker.Stop()
+
+	for !r.isReady() {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C():
+		case <-deadline:
+			return a.startupDeadlineMissed(r)
+		}
+	}
+
+	return nil
+}
+
+// startupDeadlineMissed applies the startup policy of a runner that did not become ready in time.
+func (a *App) startupDeadlineMissed(r *Runner) error {
+	err := errors.Errorf("service %q did not become ready within %s", r.name, r.startupDeadline)
+
+	if r.startupPolicy == StartupFailFast {
+		return err
+	}
+
+	a.logger.Warnw("Service missed startup deadline, application is degraded",
+		"service", r.name, "deadline", r.startupDeadline)
+
+	a.healthManager.RegisterChecker(health.NewCustomCheck(startupCheckName(r.name), func(ctx context.Context) health.HealthResult {
+		if r.isReady() {
+			return health.NewHealthyResult("service is ready")
+		}
+
+		return health.NewDegradedResult(err.Error())
+	}))
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package fastapp
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/katalabut/fast-app/clock"
+	"github.com/katalabut/fast-app/health"
+)
+
+func TestStartupDeadline(t *testing.T) {
+	t.Run("FailFast", func(t *testing.T) {
+		fake := clock.NewFake(time.Now())
+		app, cancel, exitCode := newTestApp(WithClock(fake))
+		defer cancel()
+
+		svc := newTestService()
+		app.Add(svc, WithStartupDeadline(time.Minute, StartupFailFast))
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			app.Start()
+		}()
+
+		<-svc.started
+		fake.BlockUntil(2)
+		fake.Advance(time.Minute)
+
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("Expected application to stop")
+		}
+		if code := <-exitCode; code != exitCodeApplicationErr {
+			t.Errorf("Expected exit code %d, got %d", exitCodeApplicationErr, code)
+		}
+	})
+
+	t.Run("Degrade", func(t *testing.T) {
+		fake := clock.NewFake(time.Now())
+		app, cancel, exitCode := newTestApp(WithClock(fake))
+
+		svc := newTestService()
+		app.Add(svc, WithName("cache"), WithStartupDeadline(time.Minute, StartupDegrade))
+		stop := startTestApp(t, app, cancel, exitCode)
+
+		<-svc.started
+		fake.BlockUntil(2)
+		fake.Advance(time.Minute)
+
+		name := startupCheckName("cache")
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			if _, ok := app.HealthManager().CheckAll(context.Background())[name]; ok {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatal("Expected startup health check to be registered")
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		if status := app.HealthManager().CheckAll(context.Background())[name].Status; status != health.StatusDegraded {
+			t.Errorf("Expected %s, got %s", health.StatusDegraded, status)
+		}
+
+		svc.SetReady(true)
+		app.HealthManager().ClearCache()
+		if status := app.HealthManager().CheckAll(context.Background())[name].Status; status != health.StatusHealthy {
+			t.Errorf("Expected %s, got %s", health.StatusHealthy, status)
+		}
+
+		if code := stop(); code != exitCodeOk {
+			t.Errorf("Expected exit code %d, got %d", exitCodeOk, code)
+		}
+	})
+
+	t.Run("ReadyInTime", func(t *testing.T) {
+		var ready atomic.Bool
+		app, cancel, exitCode := newTestApp()
+
+		svc := newTestService()
+		app.Add(svc, WithStartupDeadline(time.Minute, StartupFailFast), WithReadinessGate(ready.Load))
+		stop := startTestApp(t, app, cancel, exitCode)
+
+		<-svc.started
+		ready.Store(true)
+		time.Sleep(2 * readinessPollInterval)
+
+		if len(app.HealthManager().GetCheckerNames()) != 0 {
+			t.Error("Expected no startup health check to be registered")
+		}
+		if code := stop(); code != exitCodeOk {
+			t.Errorf("Expected exit code %d, got %d", exitCodeOk, code)
+		}
+	})
+}
@@ -0,0 +1,10 @@
+//go:build !windows
+
+package fastapp
+
+// StartWindowsService runs the application as a Windows service when the process
+// has been launched by the Windows service control manager. On other platforms
+// it behaves like Start.
+func (a *App) StartWindowsService(name string) {
+	a.Start()
+}
@@ -0,0 +1,136 @@
+//go:build windows
+
+package fastapp
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/windows/svc"
+	"golang.org/x/sys/windows/svc/mgr"
+)
+
+// StartWindowsService runs the application as a Windows service with the given name
+// when the process has been launched by the service control manager. Stop and
+// shutdown control requests initiate a graceful shutdown and status transitions
+// are reported to the service control manager. Otherwise, e.g. when started from
+// a console, it behaves like Start.
+func (a *App) StartWindowsService(name string) {
+	isService, err := svc.IsWindowsService()
+	if err != nil {
+		a.logger.Errorw("Failed to detect Windows service mode", "error", err)
+	}
+	if !isService {
+		a.Start()
+		return
+	}
+
+	exit := a.opts.exit
+	h := &windowsServiceHandler{app: a, exitCode: exitCodeOk}
+
+	// The exit code is reported to the service control manager, the process
+	// must not exit before the service has reported it has stopped.
+	a.opts.exit = func(code int) { h.exitCode = code }
+
+	if err := svc.Run(name, h); err != nil {
+		a.logger.Errorw("Windows service failed", "service", name, "error", err)
+		exit(exitCodeApplicationErr)
+		return
+	}
+
+	exit(h.exitCode)
+}
+
+type windowsServiceHandler struct {
+	app      *App
+	exitCode int
+}
+
+// Execute implements svc.Handler.
+func (h *windowsServiceHandler) Execute(args []string, requests <-chan svc.ChangeRequest, status chan<- svc.Status) (bool, uint32) {
+	const accepts = svc.AcceptStop | svc.AcceptShutdown
+
+	status <- svc.Status{State: svc.StartPending}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		h.app.Start()
+	}()
+
+	status <- svc.Status{State: svc.Running, Accepts: accepts}
+
+	for {
+		select {
+		case <-done:
+			status <- svc.Status{State: svc.Stopped}
+			return false, uint32(h.exitCode)
+		case req := <-requests:
+			switch req.Cmd {
+			case svc.Interrogate:
+				status <- req.CurrentStatus
+			case svc.Stop, svc.Shutdown:
+				status <- svc.Status{State: svc.StopPending}
+				h.app.Stop("windows service control request")
+			}
+		}
+	}
+}
+
+// InstallWindowsService registers the current executable as an automatically
+// started Windows service. The args are passed to the executable when the service starts.
+func InstallWindowsService(name, displayName, description string, args ...string) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return errors.Wrap(err, "failed to get executable path")
+	}
+	exe, err = filepath.Abs(exe)
+	if err != nil {
+		return errors.Wrap(err, "failed to get executable path")
+	}
+
+	m, err := mgr.Connect()
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to service control manager")
+	}
+	defer m.Disconnect()
+
+	if s, err := m.OpenService(name); err == nil {
+		s.Close()
+		return errors.Errorf("service %q already exists", name)
+	}
+
+	s, err := m.CreateService(name, exe, mgr.Config{
+		DisplayName: displayName,
+		Description: description,
+		StartType:   mgr.StartAutomatic,
+	}, args...)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create service %q", name)
+	}
+	defer s.Close()
+
+	return nil
+}
+
+// UninstallWindowsService removes the Windows service with the given name.
+func UninstallWindowsService(name string) error {
+	m, err := mgr.Connect()
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to service control manager")
+	}
+	defer m.Disconnect()
+
+	s, err := m.OpenService(name)
+	if err != nil {
+		return errors.Wrapf(err, "service %q is not installed", name)
+	}
+	defer s.Close()
+
+	if err := s.Delete(); err != nil {
+		return errors.Wrapf(err, "failed to delete service %q", name)
+	}
+
+	return nil
+}